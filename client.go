@@ -50,6 +50,8 @@ func (c *singleClient) B() Builder {
 
 func (c *singleClient) Do(ctx context.Context, cmd Completed) (resp ValkeyResult) {
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
+	trace.node(c.conn.Addr())
 retry:
 	resp = c.conn.Do(ctx, cmd)
 	if err := resp.Error(); err != nil {
@@ -59,6 +61,7 @@ retry:
 		if c.retry && cmd.IsReadOnly() && c.isRetryable(err, ctx) {
 			if c.retryHandler.WaitOrSkipRetry(ctx, attempts, cmd, err) {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -91,6 +94,8 @@ func (c *singleClient) DoMulti(ctx context.Context, multi ...Completed) (resps [
 		return nil
 	}
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
+	trace.node(c.conn.Addr())
 retry:
 	resps = c.conn.DoMulti(ctx, multi...).s
 	if c.hasLftm {
@@ -128,6 +133,7 @@ retry:
 				)
 				if shouldRetry {
 					attempts++
+					trace.retry()
 					goto retry
 				}
 			}
@@ -146,6 +152,8 @@ func (c *singleClient) DoMultiCache(ctx context.Context, multi ...CacheableTTL)
 		return nil
 	}
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
+	trace.node(c.conn.Addr())
 retry:
 	resps = c.conn.DoMultiCache(ctx, multi...).s
 	if c.hasLftm {
@@ -172,6 +180,7 @@ retry:
 				)
 				if shouldRetry {
 					attempts++
+					trace.retry()
 					goto retry
 				}
 			}
@@ -182,11 +191,14 @@ retry:
 			cmds.PutCacheable(cmd.Cmd)
 		}
 	}
+	trace.cached(resps...)
 	return resps
 }
 
 func (c *singleClient) DoCache(ctx context.Context, cmd Cacheable, ttl time.Duration) (resp ValkeyResult) {
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
+	trace.node(c.conn.Addr())
 retry:
 	resp = c.conn.DoCache(ctx, cmd, ttl)
 	if err := resp.Error(); err != nil {
@@ -196,6 +208,7 @@ retry:
 		if c.retry && c.isRetryable(err, ctx) {
 			if c.retryHandler.WaitOrSkipRetry(ctx, attempts, Completed(cmd), err) {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -203,6 +216,7 @@ retry:
 	if err := resp.NonValkeyError(); err == nil || err == ErrDoCacheAborted {
 		cmds.PutCacheable(cmd)
 	}
+	trace.cached(resp)
 	return resp
 }
 
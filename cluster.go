@@ -464,6 +464,9 @@ func (c *clusterClient) pick(ctx context.Context, slot uint16, toReplica bool) (
 }
 
 func (c *clusterClient) redirectOrNew(addr string, prev conn, slot uint16, mode RedirectMode) conn {
+	if c.opt.MetricsHooks.OnRedirect != nil {
+		c.opt.MetricsHooks.OnRedirect(prev.Addr(), addr, mode)
+	}
 	c.mu.RLock()
 	cc := c.conns[addr]
 	c.mu.RUnlock()
@@ -510,11 +513,13 @@ func (c *clusterClient) Do(ctx context.Context, cmd Completed) (resp ValkeyResul
 
 func (c *clusterClient) do(ctx context.Context, cmd Completed) (resp ValkeyResult) {
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
 retry:
 	cc, err := c.pick(ctx, cmd.Slot(), c.toReplica(cmd))
 	if err != nil {
 		return newErrResult(err)
 	}
+	trace.node(cc.Addr())
 	resp = cc.Do(ctx, cmd)
 	if resp.NonValkeyError() == errConnExpired {
 		goto retry
@@ -523,6 +528,8 @@ process:
 	switch addr, mode := c.shouldRefreshRetry(resp.Error(), ctx); mode {
 	case RedirectMove:
 		ncc := c.redirectOrNew(addr, cc, cmd.Slot(), mode)
+		trace.redirect(1)
+		trace.node(ncc.Addr())
 	recover1:
 		resp = ncc.Do(ctx, cmd)
 		if resp.NonValkeyError() == errConnExpired {
@@ -531,6 +538,8 @@ process:
 		goto process
 	case RedirectAsk:
 		ncc := c.redirectOrNew(addr, cc, cmd.Slot(), mode)
+		trace.redirect(1)
+		trace.node(ncc.Addr())
 	recover2:
 		results := ncc.DoMulti(ctx, cmds.AskingCmd, cmd)
 		resp = results.s[1]
@@ -544,6 +553,7 @@ process:
 			shouldRetry := c.retryHandler.WaitOrSkipRetry(ctx, attempts, cmd, resp.Error())
 			if shouldRetry {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -765,9 +775,10 @@ func (c *clusterClient) doresultfn(
 }
 
 func (c *clusterClient) doretry(
-	ctx context.Context, cc conn, results *valkeyresults, retries *connretry, re *retry, mu *sync.Mutex, wg *sync.WaitGroup, attempts int, hasInit bool,
+	ctx context.Context, cc conn, results *valkeyresults, retries *connretry, re *retry, mu *sync.Mutex, wg *sync.WaitGroup, attempts int, hasInit bool, trace *CommandTrace,
 ) {
 	clean := true
+	trace.node(cc.Addr())
 	if len(re.commands) != 0 {
 		resps := cc.DoMulti(ctx, re.commands...)
 		if c.hasLftm {
@@ -828,6 +839,7 @@ func (c *clusterClient) DoMulti(ctx context.Context, multi ...Completed) []Valke
 	results := resultsp.Get(len(multi), len(multi))
 
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
 
 retry:
 	retries.RetryDelay = -1 // Assume no retry. Because a client retry flag can be set to false.
@@ -844,20 +856,22 @@ retry:
 	}
 	for cc, re := range retries.m {
 		delete(retries.m, cc)
-		go c.doretry(ctx, cc, results, retries, re, &mu, &wg, attempts, hasInit)
+		go c.doretry(ctx, cc, results, retries, re, &mu, &wg, attempts, hasInit, trace)
 	}
 	mu.Unlock()
-	c.doretry(ctx, cc1, results, retries, re1, &mu, &wg, attempts, hasInit)
+	c.doretry(ctx, cc1, results, retries, re1, &mu, &wg, attempts, hasInit, trace)
 	wg.Wait()
 
 	if len(retries.m) != 0 {
 		if retries.Redirects > 0 {
+			trace.redirect(int(retries.Redirects))
 			retries.Redirects = 0
 			goto retry
 		}
 		if retries.RetryDelay >= 0 {
 			c.retryHandler.WaitForRetry(ctx, retries.RetryDelay)
 			attempts++
+			trace.retry()
 			goto retry
 		}
 	}
@@ -878,7 +892,7 @@ func fillErrs(n int, err error) (results []ValkeyResult) {
 	return results
 }
 
-func (c *clusterClient) doCache(ctx context.Context, cmd Cacheable, ttl time.Duration) (resp ValkeyResult) {
+func (c *clusterClient) doCache(ctx context.Context, cmd Cacheable, ttl time.Duration, trace *CommandTrace) (resp ValkeyResult) {
 	attempts := 1
 
 retry:
@@ -886,6 +900,7 @@ retry:
 	if err != nil {
 		return newErrResult(err)
 	}
+	trace.node(cc.Addr())
 	resp = cc.DoCache(ctx, cmd, ttl)
 	if resp.NonValkeyError() == errConnExpired {
 		goto retry
@@ -894,6 +909,8 @@ process:
 	switch addr, mode := c.shouldRefreshRetry(resp.Error(), ctx); mode {
 	case RedirectMove:
 		ncc := c.redirectOrNew(addr, cc, cmd.Slot(), mode)
+		trace.redirect(1)
+		trace.node(ncc.Addr())
 	recover:
 		resp = ncc.DoCache(ctx, cmd, ttl)
 		if resp.NonValkeyError() == errConnExpired {
@@ -901,7 +918,10 @@ process:
 		}
 		goto process
 	case RedirectAsk:
-		results := c.askingMultiCache(c.redirectOrNew(addr, cc, cmd.Slot(), mode), ctx, []CacheableTTL{CT(cmd, ttl)})
+		ncc := c.redirectOrNew(addr, cc, cmd.Slot(), mode)
+		trace.redirect(1)
+		trace.node(ncc.Addr())
+		results := c.askingMultiCache(ncc, ctx, []CacheableTTL{CT(cmd, ttl)})
 		resp = results.s[0]
 		resultsp.Put(results)
 		goto process
@@ -910,6 +930,7 @@ process:
 			shouldRetry := c.retryHandler.WaitOrSkipRetry(ctx, attempts, Completed(cmd), resp.Error())
 			if shouldRetry {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -918,10 +939,12 @@ process:
 }
 
 func (c *clusterClient) DoCache(ctx context.Context, cmd Cacheable, ttl time.Duration) (resp ValkeyResult) {
-	resp = c.doCache(ctx, cmd, ttl)
+	trace := CommandTraceFromContext(ctx)
+	resp = c.doCache(ctx, cmd, ttl, trace)
 	if err := resp.NonValkeyError(); err == nil || err == ErrDoCacheAborted {
 		cmds.PutCacheable(cmd)
 	}
+	trace.cached(resp)
 	return resp
 }
 
@@ -1133,9 +1156,10 @@ func (c *clusterClient) resultcachefn(
 }
 
 func (c *clusterClient) doretrycache(
-	ctx context.Context, cc conn, results *valkeyresults, retries *connretrycache, re *retrycache, mu *sync.Mutex, wg *sync.WaitGroup, attempts int,
+	ctx context.Context, cc conn, results *valkeyresults, retries *connretrycache, re *retrycache, mu *sync.Mutex, wg *sync.WaitGroup, attempts int, trace *CommandTrace,
 ) {
 	clean := true
+	trace.node(cc.Addr())
 	if len(re.commands) != 0 {
 		resps := cc.DoMultiCache(ctx, re.commands...)
 		if c.hasLftm {
@@ -1185,6 +1209,7 @@ func (c *clusterClient) DoMultiCache(ctx context.Context, multi ...CacheableTTL)
 	results := resultsp.Get(len(multi), len(multi))
 
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
 
 retry:
 	retries.RetryDelay = -1 // Assume no retry. Because a client retry flag can be set to false.
@@ -1201,20 +1226,22 @@ retry:
 	}
 	for cc, re := range retries.m {
 		delete(retries.m, cc)
-		go c.doretrycache(ctx, cc, results, retries, re, &mu, &wg, attempts)
+		go c.doretrycache(ctx, cc, results, retries, re, &mu, &wg, attempts, trace)
 	}
 	mu.Unlock()
-	c.doretrycache(ctx, cc1, results, retries, re1, &mu, &wg, attempts)
+	c.doretrycache(ctx, cc1, results, retries, re1, &mu, &wg, attempts, trace)
 	wg.Wait()
 
 	if len(retries.m) != 0 {
 		if retries.Redirects > 0 {
+			trace.redirect(int(retries.Redirects))
 			retries.Redirects = 0
 			goto retry
 		}
 		if retries.RetryDelay >= 0 {
 			c.retryHandler.WaitForRetry(ctx, retries.RetryDelay)
 			attempts++
+			trace.retry()
 			goto retry
 		}
 	}
@@ -1224,6 +1251,7 @@ retry:
 			cmds.PutCacheable(cmd.Cmd)
 		}
 	}
+	trace.cached(results.s...)
 	return results.s
 }
 
@@ -842,6 +842,9 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 	if len(appends) == 0 && next.Variadic && len(next.BuildDef.Parameters) == 1 && toGoType(next.BuildDef.Parameters[0].Type) == "string" {
 		appends = append(appends, toGoName(next.BuildDef.Parameters[0].Name)+"...")
 		fmt.Fprintf(w, "\tc.cs.s = append(c.cs.s, %s)\n", strings.Join(appends, ", "))
+		if next.BuildDef.Parameters[0].Type == "key" {
+			fmt.Fprintf(w, "\tc.cs.keys(len(%s))\n", toGoName(next.BuildDef.Parameters[0].Name))
+		}
 	} else if len(next.BuildDef.Parameters) != 1 && (next.Variadic || next.MultipleToken) && parent.FullName != next.FullName {
 		// no parameter
 		if len(appends) != 0 && !next.MultipleToken {
@@ -856,10 +859,15 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 			}
 		}
 		if allstring && !next.Variadic {
+			var keys []int
 			for _, p := range next.BuildDef.Parameters {
+				if p.Type == "key" {
+					keys = append(keys, len(appends))
+				}
 				appends = append(appends, toGoName(p.Name))
 			}
 			fmt.Fprintf(w, "\tc.cs.s = append(c.cs.s, %s)\n", strings.Join(appends, ", "))
+			printKeys(w, keys, len(appends))
 		} else {
 			if len(next.BuildDef.Parameters) == 1 && next.Variadic {
 				if len(appends) != 0 {
@@ -867,6 +875,9 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 				}
 				if toGoType(next.BuildDef.Parameters[0].Type) == "string" {
 					fmt.Fprintf(w, "\tc.cs.s = append(c.cs.s, %s...)\n", toGoName(next.BuildDef.Parameters[0].Name))
+					if next.BuildDef.Parameters[0].Type == "key" {
+						fmt.Fprintf(w, "\tc.cs.keys(len(%s))\n", toGoName(next.BuildDef.Parameters[0].Name))
+					}
 				} else {
 					fmt.Fprintf(w, "\tfor _, n := range %s {\n", toGoName(next.BuildDef.Parameters[0].Name))
 					switch toGoType(next.BuildDef.Parameters[0].Type) {
@@ -885,7 +896,11 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 				}
 			} else {
 				var follows []string
+				var keys []int
 				for _, p := range next.BuildDef.Parameters {
+					if p.Type == "key" {
+						keys = append(keys, len(appends))
+					}
 					switch toGoType(p.Type) {
 					case "float64":
 						appends = append(appends, fmt.Sprintf("strconv.FormatFloat(%s, 'f', -1, 64)", toGoName(p.Name)))
@@ -918,6 +933,7 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 					}
 				}
 				fmt.Fprintf(w, "\tc.cs.s = append(c.cs.s, %s)\n", strings.Join(appends, ", "))
+				printKeys(w, keys, len(appends))
 				for _, follow := range follows {
 					fmt.Fprintf(w, "\tc.cs.s = append(c.cs.s, %s)\n", follow)
 				}
@@ -933,6 +949,13 @@ func printBuilder(w io.Writer, parent, next goStruct) {
 	fmt.Fprintf(w, "}\n\n")
 }
 
+// printKeys marks the appended arguments at the indexes as keys.
+func printKeys(w io.Writer, indexes []int, appended int) {
+	for _, i := range indexes {
+		fmt.Fprintf(w, "\tc.cs.key(%d)\n", appended-i)
+	}
+}
+
 func makeChildNodes(parent *node, args []argument) (first *node) {
 	if len(args) == 0 {
 		return nil
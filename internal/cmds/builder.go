@@ -15,6 +15,7 @@ var pool = &sync.Pool{New: func() any {
 // CommandSlice is the command container managed by the sync.Pool
 type CommandSlice struct {
 	s []string
+	k []int32 // indexes of keys in the s
 	l int32
 	r int32
 }
@@ -32,6 +33,18 @@ func (cs *CommandSlice) Verify() {
 	}
 }
 
+// key marks the nth last element of the s as a key
+func (cs *CommandSlice) key(n int) {
+	cs.k = append(cs.k, int32(len(cs.s)-n))
+}
+
+// keys marks the last n elements of the s as keys
+func (cs *CommandSlice) keys(n int) {
+	for i := len(cs.s) - n; i < len(cs.s); i++ {
+		cs.k = append(cs.k, int32(i))
+	}
+}
+
 func newCommandSlice(s []string) *CommandSlice {
 	return &CommandSlice{s: s, l: int32(len(s))}
 }
@@ -94,6 +107,7 @@ func (c Arbitrary) Keys(keys ...string) Arbitrary {
 		}
 	}
 	c.cs.s = append(c.cs.s, keys...)
+	c.cs.keys(len(keys))
 	return c
 }
 
@@ -9,12 +9,13 @@ func TestPutCompleted(t *testing.T) {
 retry:
 	cs1 := get()
 	cs1.s = append(cs1.s, "1", "1", "1", "1", "1")
+	cs1.k = append(cs1.k, 1)
 	PutCompleted(Completed{cs: cs1})
 	cs2 := get()
 	if cs1 != cs2 {
 		goto retry
 	}
-	if len(cs2.s) != 0 {
+	if len(cs2.s) != 0 || len(cs2.k) != 0 {
 		t.Fatalf("Put doesn't clean the CommandSlice")
 	}
 }
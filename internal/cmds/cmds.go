@@ -271,6 +271,71 @@ func CacheableCS(c Cacheable) *CommandSlice {
 	return c.cs
 }
 
+// CompletedKeys returns the indexes of the keys in the c.Commands()
+func CompletedKeys(c Completed) []int32 {
+	return c.cs.k
+}
+
+// CacheableKeys returns the indexes of the keys in the c.Commands()
+func CacheableKeys(c Cacheable) []int32 {
+	return c.cs.k
+}
+
+// RewriteCompletedKeys returns a new Completed with its keys replaced by the fn and its key slot recomputed.
+// The c is recycled unless it is pinned.
+func RewriteCompletedKeys(c Completed, fn func(key string) string) Completed {
+	if len(c.cs.k) == 0 {
+		return c
+	}
+	r := Completed{cs: rewriteKeys(c.cs, fn), cf: c.cf}
+	r.ks = keySlot(r.cs, c.ks)
+	PutCompleted(c)
+	return r
+}
+
+// RewriteCacheableKeys returns a new Cacheable with its keys replaced by the fn and its key slot recomputed.
+// The c is recycled unless it is pinned.
+func RewriteCacheableKeys(c Cacheable, fn func(key string) string) Cacheable {
+	if len(c.cs.k) == 0 {
+		return c
+	}
+	r := Cacheable{cs: rewriteKeys(c.cs, fn), cf: c.cf}
+	r.ks = keySlot(r.cs, c.ks)
+	PutCacheable(c)
+	return r
+}
+
+// ReplaceCompletedArgs returns a new Completed with the args while keeping the flags and the key slot of the c.
+// The args must not contain keys. The c is recycled unless it is pinned.
+func ReplaceCompletedArgs(c Completed, args []string) Completed {
+	cs := get()
+	cs.s = append(cs.s, args...)
+	cs.l = int32(len(cs.s))
+	r := Completed{cs: cs, cf: c.cf, ks: c.ks}
+	PutCompleted(c)
+	return r
+}
+
+func rewriteKeys(cs *CommandSlice, fn func(key string) string) *CommandSlice {
+	r := get()
+	r.s = append(r.s, cs.s...)
+	r.k = append(r.k, cs.k...)
+	r.l = int32(len(r.s))
+	for _, i := range r.k {
+		r.s[i] = fn(r.s[i])
+	}
+	return r
+}
+
+// keySlot computes the key slot of the first key while keeping the NoSlot flag of the ks.
+// Unlike the builder, it does not panic on keys of different slots and leaves the server to reject them.
+func keySlot(cs *CommandSlice, ks uint16) uint16 {
+	if ks&NoSlot == NoSlot {
+		return NoSlot | slot(cs.s[cs.k[0]])
+	}
+	return slot(cs.s[cs.k[0]])
+}
+
 // NewCompleted creates an arbitrary Completed command.
 func NewCompleted(ss []string) Completed {
 	return Completed{cs: newCommandSlice(ss)}
@@ -336,6 +401,7 @@ func JsonMSets(kvs map[string]string, path string) map[uint16]Completed {
 			ret[ks] = Completed{cs: cs, ks: ks}
 		}
 		cs.s = append(cs.s, key, path, value)
+		cs.key(3)
 		cs.l += 3
 	}
 	return ret
@@ -355,6 +421,7 @@ func slotMCMDs(cmd string, keys []string, cf uint16) map[uint16]Completed {
 			ret[ks] = Completed{cs: cs, cf: cf, ks: ks}
 		}
 		cs.s = append(cs.s, key)
+		cs.key(1)
 		cs.l++
 	}
 	return ret
@@ -374,6 +441,7 @@ func slotMSets(cmd string, kvs map[string]string) map[uint16]Completed {
 			ret[ks] = Completed{cs: cs, ks: ks}
 		}
 		cs.s = append(cs.s, key, value)
+		cs.key(2)
 		cs.l += 2
 	}
 	return ret
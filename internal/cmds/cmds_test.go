@@ -359,3 +359,93 @@ func TestCacheableCS(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestCompletedKeys(t *testing.T) {
+	b := NewBuilder(NoSlot)
+	for _, c := range []struct {
+		cmd  Completed
+		keys []int32
+	}{
+		{cmd: b.Get().Key("a").Build(), keys: []int32{1}},
+		{cmd: b.Mset().KeyValue().KeyValue("a", "1").KeyValue("b", "2").Build(), keys: []int32{1, 3}},
+		{cmd: b.Xread().Count(1).Streams().Key("a", "b").Id("0", "0").Build(), keys: []int32{4, 5}},
+		{cmd: b.Eval().Script("s").Numkeys(2).Key("a", "b").Arg("c").Build(), keys: []int32{3, 4}},
+		{cmd: b.Georadius().Key("a").Longitude(1).Latitude(1).Radius(1).M().Store("b").Build(), keys: []int32{1, 7}},
+		{cmd: b.Arbitrary("CMD").Keys("a", "b").Args("c").Keys("d").Build(), keys: []int32{1, 2, 4}},
+		{cmd: b.Scan().Cursor(0).Match("a*").Build(), keys: nil},
+	} {
+		if keys := CompletedKeys(c.cmd); !reflect.DeepEqual(keys, c.keys) && (len(keys) != 0 || len(c.keys) != 0) {
+			t.Fatalf("unexpected keys %v of %v", keys, c.cmd.Commands())
+		}
+	}
+	for _, cp := range MSets(map[string]string{"{1}a": "1", "{1}b": "2"}) {
+		if keys := CompletedKeys(cp); !reflect.DeepEqual(keys, []int32{1, 3}) {
+			t.Fatalf("unexpected keys %v", keys)
+		}
+	}
+	if c := b.Get().Key("a").Cache(); !reflect.DeepEqual(CacheableKeys(c), []int32{1}) {
+		t.Fatalf("unexpected keys %v", CacheableKeys(c))
+	}
+}
+
+func TestRewriteCompletedKeys(t *testing.T) {
+	prefix := func(key string) string { return "p:" + key }
+
+	b := NewBuilder(InitSlot)
+	cmd := b.Blmove().Source("{a}1").Destination("{a}2").Left().Right().Timeout(1).Build().Pin()
+	rewritten := RewriteCompletedKeys(cmd, prefix)
+	if !reflect.DeepEqual(rewritten.Commands(), []string{"BLMOVE", "p:{a}1", "p:{a}2", "LEFT", "RIGHT", "1"}) {
+		t.Fatalf("unexpected commands %v", rewritten.Commands())
+	}
+	if !reflect.DeepEqual(cmd.Commands(), []string{"BLMOVE", "{a}1", "{a}2", "LEFT", "RIGHT", "1"}) {
+		t.Fatalf("pinned command should not be modified %v", cmd.Commands())
+	}
+	if rewritten.Slot() != slot("a") || !rewritten.IsBlock() || rewritten.cs.r != 0 {
+		t.Fatalf("unexpected rewritten command %v %v %v", rewritten.Slot(), rewritten.IsBlock(), rewritten.cs.r)
+	}
+	rewritten.cs.Verify()
+
+	if rewritten = RewriteCompletedKeys(b.Get().Key("a").Build(), prefix); rewritten.Slot() != slot("p:a") {
+		t.Fatalf("unexpected slot %v", rewritten.Slot())
+	}
+	if rewritten = RewriteCompletedKeys(NewBuilder(NoSlot).Get().Key("a").Build(), prefix); rewritten.Slot() != NoSlot|slot("p:a") {
+		t.Fatalf("unexpected slot %v", rewritten.Slot())
+	}
+	if rewritten = RewriteCompletedKeys(b.Get().Key("a").Build(), func(key string) string { return "{a}" }); rewritten.Slot() != slot("a") {
+		t.Fatalf("unexpected slot %v", rewritten.Slot())
+	}
+
+	cmd = b.Ping().Build()
+	if rewritten = RewriteCompletedKeys(cmd, prefix); rewritten.cs != cmd.cs || rewritten.Slot() != InitSlot {
+		t.Fatalf("command without keys should not be rewritten")
+	}
+}
+
+func TestRewriteCacheableKeys(t *testing.T) {
+	prefix := func(key string) string { return "p:" + key }
+
+	b := NewBuilder(InitSlot)
+	rewritten := RewriteCacheableKeys(b.Mget().Key("{a}1", "{a}2").Cache(), prefix)
+	if !reflect.DeepEqual(rewritten.Commands(), []string{"MGET", "p:{a}1", "p:{a}2"}) {
+		t.Fatalf("unexpected commands %v", rewritten.Commands())
+	}
+	if rewritten.Slot() != slot("a") || !rewritten.IsMGet() {
+		t.Fatalf("unexpected rewritten command %v %v", rewritten.Slot(), rewritten.IsMGet())
+	}
+	if key, _ := CacheKey(RewriteCacheableKeys(b.Get().Key("a").Cache(), prefix)); key != "p:a" {
+		t.Fatalf("unexpected cache key %v", key)
+	}
+}
+
+func TestReplaceCompletedArgs(t *testing.T) {
+	cmd := NewBuilder(InitSlot).Scan().Cursor(0).Build()
+	args := append([]string(nil), cmd.Commands()...)
+	replaced := ReplaceCompletedArgs(cmd, append(args, "MATCH", "p:*"))
+	if !reflect.DeepEqual(replaced.Commands(), []string{"SCAN", "0", "MATCH", "p:*"}) {
+		t.Fatalf("unexpected commands %v", replaced.Commands())
+	}
+	if replaced.Slot() != InitSlot || !replaced.IsReadOnly() {
+		t.Fatalf("unexpected replaced command %v %v", replaced.Slot(), replaced.IsReadOnly())
+	}
+	replaced.cs.Verify()
+}
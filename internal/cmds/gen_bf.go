@@ -19,6 +19,7 @@ func (c BfAdd) Key(key string) BfAddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfAddKey)(c)
 }
 
@@ -51,6 +52,7 @@ func (c BfCard) Key(key string) BfCardKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfCardKey)(c)
 }
 
@@ -76,6 +78,7 @@ func (c BfExists) Key(key string) BfExistsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfExistsKey)(c)
 }
 
@@ -113,6 +116,7 @@ func (c BfInfo) Key(key string) BfInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfInfoKey)(c)
 }
 
@@ -228,6 +232,7 @@ func (c BfInsert) Key(key string) BfInsertKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfInsertKey)(c)
 }
 
@@ -382,6 +387,7 @@ func (c BfLoadchunk) Key(key string) BfLoadchunkKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfLoadchunkKey)(c)
 }
 
@@ -421,6 +427,7 @@ func (c BfMadd) Key(key string) BfMaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfMaddKey)(c)
 }
 
@@ -458,6 +465,7 @@ func (c BfMexists) Key(key string) BfMexistsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfMexistsKey)(c)
 }
 
@@ -495,6 +503,7 @@ func (c BfReserve) Key(key string) BfReserveKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfReserveKey)(c)
 }
 
@@ -563,6 +572,7 @@ func (c BfScandump) Key(key string) BfScandumpKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BfScandumpKey)(c)
 }
 
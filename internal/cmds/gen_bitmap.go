@@ -19,6 +19,7 @@ func (c Bitcount) Key(key string) BitcountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BitcountKey)(c)
 }
 
@@ -107,6 +108,7 @@ func (c Bitfield) Key(key string) BitfieldKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BitfieldKey)(c)
 }
 
@@ -309,6 +311,7 @@ func (c BitfieldRo) Key(key string) BitfieldRoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BitfieldRoKey)(c)
 }
 
@@ -407,6 +410,7 @@ func (c BitopDestkey) Key(key ...string) BitopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BitopKey)(c)
 }
 
@@ -424,6 +428,7 @@ func (c BitopKey) Key(key ...string) BitopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -441,6 +446,7 @@ func (c BitopOperationAnd) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -453,6 +459,7 @@ func (c BitopOperationAndor) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -465,6 +472,7 @@ func (c BitopOperationDiff) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -477,6 +485,7 @@ func (c BitopOperationDiff1) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -489,6 +498,7 @@ func (c BitopOperationNot) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -501,6 +511,7 @@ func (c BitopOperationOne) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -513,6 +524,7 @@ func (c BitopOperationOr) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -525,6 +537,7 @@ func (c BitopOperationXor) Destkey(destkey string) BitopDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (BitopDestkey)(c)
 }
 
@@ -543,6 +556,7 @@ func (c Bitpos) Key(key string) BitposKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (BitposKey)(c)
 }
 
@@ -648,6 +662,7 @@ func (c Getbit) Key(key string) GetbitKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetbitKey)(c)
 }
 
@@ -685,6 +700,7 @@ func (c Setbit) Key(key string) SetbitKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SetbitKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c CfAdd) Key(key string) CfAddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfAddKey)(c)
 }
 
@@ -51,6 +52,7 @@ func (c CfAddnx) Key(key string) CfAddnxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfAddnxKey)(c)
 }
 
@@ -83,6 +85,7 @@ func (c CfCount) Key(key string) CfCountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfCountKey)(c)
 }
 
@@ -120,6 +123,7 @@ func (c CfDel) Key(key string) CfDelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfDelKey)(c)
 }
 
@@ -152,6 +156,7 @@ func (c CfExists) Key(key string) CfExistsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfExistsKey)(c)
 }
 
@@ -189,6 +194,7 @@ func (c CfInfo) Key(key string) CfInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfInfoKey)(c)
 }
 
@@ -219,6 +225,7 @@ func (c CfInsert) Key(key string) CfInsertKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfInsertKey)(c)
 }
 
@@ -292,6 +299,7 @@ func (c CfInsertnx) Key(key string) CfInsertnxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfInsertnxKey)(c)
 }
 
@@ -365,6 +373,7 @@ func (c CfLoadchunk) Key(key string) CfLoadchunkKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfLoadchunkKey)(c)
 }
 
@@ -404,6 +413,7 @@ func (c CfMexists) Key(key string) CfMexistsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfMexistsKey)(c)
 }
 
@@ -441,6 +451,7 @@ func (c CfReserve) Key(key string) CfReserveKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfReserveKey)(c)
 }
 
@@ -524,6 +535,7 @@ func (c CfScandump) Key(key string) CfScandumpKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CfScandumpKey)(c)
 }
 
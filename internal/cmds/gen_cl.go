@@ -19,6 +19,7 @@ func (c ClThrottle) Key(key string) ClThrottleKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ClThrottleKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c CmsIncrby) Key(key string) CmsIncrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CmsIncrbyKey)(c)
 }
 
@@ -63,6 +64,7 @@ func (c CmsInfo) Key(key string) CmsInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CmsInfoKey)(c)
 }
 
@@ -93,6 +95,7 @@ func (c CmsInitbydim) Key(key string) CmsInitbydimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CmsInitbydimKey)(c)
 }
 
@@ -132,6 +135,7 @@ func (c CmsInitbyprob) Key(key string) CmsInitbyprobKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CmsInitbyprobKey)(c)
 }
 
@@ -171,6 +175,7 @@ func (c CmsMerge) Destination(destination string) CmsMergeDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (CmsMergeDestination)(c)
 }
 
@@ -195,6 +200,7 @@ func (c CmsMergeNumkeys) Source(source ...string) CmsMergeSource {
 		}
 	}
 	c.cs.s = append(c.cs.s, source...)
+	c.cs.keys(len(source))
 	return (CmsMergeSource)(c)
 }
 
@@ -212,6 +218,7 @@ func (c CmsMergeSource) Source(source ...string) CmsMergeSource {
 		}
 	}
 	c.cs.s = append(c.cs.s, source...)
+	c.cs.keys(len(source))
 	return c
 }
 
@@ -263,6 +270,7 @@ func (c CmsQuery) Key(key string) CmsQueryKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (CmsQueryKey)(c)
 }
 
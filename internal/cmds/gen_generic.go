@@ -19,6 +19,7 @@ func (c Copy) Source(source string) CopySource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (CopySource)(c)
 }
 
@@ -67,6 +68,7 @@ func (c CopySource) Destination(destination string) CopyDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (CopyDestination)(c)
 }
 
@@ -90,6 +92,7 @@ func (c Del) Key(key ...string) DelKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (DelKey)(c)
 }
 
@@ -107,6 +110,7 @@ func (c DelKey) Key(key ...string) DelKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -130,6 +134,7 @@ func (c Dump) Key(key string) DumpKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (DumpKey)(c)
 }
 
@@ -160,6 +165,7 @@ func (c Exists) Key(key ...string) ExistsKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ExistsKey)(c)
 }
 
@@ -177,6 +183,7 @@ func (c ExistsKey) Key(key ...string) ExistsKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -200,6 +207,7 @@ func (c Expire) Key(key string) ExpireKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ExpireKey)(c)
 }
 
@@ -280,6 +288,7 @@ func (c Expireat) Key(key string) ExpireatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ExpireatKey)(c)
 }
 
@@ -360,6 +369,7 @@ func (c Expiretime) Key(key string) ExpiretimeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ExpiretimeKey)(c)
 }
 
@@ -428,6 +438,7 @@ func (c MigrateAuthAuth) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MigrateKeys)(c)
 }
 
@@ -451,6 +462,7 @@ func (c MigrateAuthAuth2) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MigrateKeys)(c)
 }
 
@@ -489,6 +501,7 @@ func (c MigrateCopy) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MigrateKeys)(c)
 }
 
@@ -533,6 +546,7 @@ func (c MigrateKeys) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -550,6 +564,7 @@ func (c MigratePort) Key(key string) MigrateKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (MigrateKey)(c)
 }
 
@@ -578,6 +593,7 @@ func (c MigrateReplace) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MigrateKeys)(c)
 }
 
@@ -621,6 +637,7 @@ func (c MigrateTimeout) Keys(key ...string) MigrateKeys {
 	}
 	c.cs.s = append(c.cs.s, "KEYS")
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MigrateKeys)(c)
 }
 
@@ -644,6 +661,7 @@ func (c Move) Key(key string) MoveKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (MoveKey)(c)
 }
 
@@ -676,6 +694,7 @@ func (c ObjectEncoding) Key(key string) ObjectEncodingKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ObjectEncodingKey)(c)
 }
 
@@ -701,6 +720,7 @@ func (c ObjectFreq) Key(key string) ObjectFreqKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ObjectFreqKey)(c)
 }
 
@@ -739,6 +759,7 @@ func (c ObjectIdletime) Key(key string) ObjectIdletimeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ObjectIdletimeKey)(c)
 }
 
@@ -764,6 +785,7 @@ func (c ObjectRefcount) Key(key string) ObjectRefcountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ObjectRefcountKey)(c)
 }
 
@@ -789,6 +811,7 @@ func (c Persist) Key(key string) PersistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PersistKey)(c)
 }
 
@@ -814,6 +837,7 @@ func (c Pexpire) Key(key string) PexpireKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PexpireKey)(c)
 }
 
@@ -894,6 +918,7 @@ func (c Pexpireat) Key(key string) PexpireatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PexpireatKey)(c)
 }
 
@@ -974,6 +999,7 @@ func (c Pexpiretime) Key(key string) PexpiretimeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PexpiretimeKey)(c)
 }
 
@@ -1004,6 +1030,7 @@ func (c Pttl) Key(key string) PttlKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PttlKey)(c)
 }
 
@@ -1047,6 +1074,7 @@ func (c Rename) Key(key string) RenameKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RenameKey)(c)
 }
 
@@ -1059,6 +1087,7 @@ func (c RenameKey) Newkey(newkey string) RenameNewkey {
 		c.ks = check(c.ks, slot(newkey))
 	}
 	c.cs.s = append(c.cs.s, newkey)
+	c.cs.key(1)
 	return (RenameNewkey)(c)
 }
 
@@ -1084,6 +1113,7 @@ func (c Renamenx) Key(key string) RenamenxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RenamenxKey)(c)
 }
 
@@ -1096,6 +1126,7 @@ func (c RenamenxKey) Newkey(newkey string) RenamenxNewkey {
 		c.ks = check(c.ks, slot(newkey))
 	}
 	c.cs.s = append(c.cs.s, newkey)
+	c.cs.key(1)
 	return (RenamenxNewkey)(c)
 }
 
@@ -1121,6 +1152,7 @@ func (c Restore) Key(key string) RestoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RestoreKey)(c)
 }
 
@@ -1309,6 +1341,7 @@ func (c Sort) Key(key string) SortKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SortKey)(c)
 }
 
@@ -1345,6 +1378,7 @@ func (c SortBy) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1382,6 +1416,7 @@ func (c SortGet) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1428,6 +1463,7 @@ func (c SortKey) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1464,6 +1500,7 @@ func (c SortLimit) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1486,6 +1523,7 @@ func (c SortOrderAsc) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1508,6 +1546,7 @@ func (c SortOrderDesc) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1531,6 +1570,7 @@ func (c SortRo) Key(key string) SortRoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SortRoKey)(c)
 }
 
@@ -1729,6 +1769,7 @@ func (c SortSortingAlpha) Store(destination string) SortStore {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, "STORE", destination)
+	c.cs.key(1)
 	return (SortStore)(c)
 }
 
@@ -1764,6 +1805,7 @@ func (c Touch) Key(key ...string) TouchKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (TouchKey)(c)
 }
 
@@ -1781,6 +1823,7 @@ func (c TouchKey) Key(key ...string) TouchKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -1804,6 +1847,7 @@ func (c Ttl) Key(key string) TtlKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TtlKey)(c)
 }
 
@@ -1834,6 +1878,7 @@ func (c Type) Key(key string) TypeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TypeKey)(c)
 }
 
@@ -1869,6 +1914,7 @@ func (c Unlink) Key(key ...string) UnlinkKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (UnlinkKey)(c)
 }
 
@@ -1886,6 +1932,7 @@ func (c UnlinkKey) Key(key ...string) UnlinkKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
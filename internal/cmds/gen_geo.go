@@ -19,6 +19,7 @@ func (c Geoadd) Key(key string) GeoaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoaddKey)(c)
 }
 
@@ -98,6 +99,7 @@ func (c Geodist) Key(key string) GeodistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeodistKey)(c)
 }
 
@@ -210,6 +212,7 @@ func (c Geohash) Key(key string) GeohashKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeohashKey)(c)
 }
 
@@ -262,6 +265,7 @@ func (c Geopos) Key(key string) GeoposKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoposKey)(c)
 }
 
@@ -314,6 +318,7 @@ func (c Georadius) Key(key string) GeoradiusKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoradiusKey)(c)
 }
 
@@ -336,6 +341,7 @@ func (c GeoradiusCountAny) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -346,6 +352,7 @@ func (c GeoradiusCountAny) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -378,6 +385,7 @@ func (c GeoradiusCountCount) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -388,6 +396,7 @@ func (c GeoradiusCountCount) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -426,6 +435,7 @@ func (c GeoradiusOrderAsc) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -436,6 +446,7 @@ func (c GeoradiusOrderAsc) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -453,6 +464,7 @@ func (c GeoradiusOrderDesc) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -463,6 +475,7 @@ func (c GeoradiusOrderDesc) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -508,6 +521,7 @@ func (c GeoradiusRo) Key(key string) GeoradiusRoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoradiusRoKey)(c)
 }
 
@@ -944,6 +958,7 @@ func (c GeoradiusUnitFt) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -954,6 +969,7 @@ func (c GeoradiusUnitFt) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1001,6 +1017,7 @@ func (c GeoradiusUnitKm) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1011,6 +1028,7 @@ func (c GeoradiusUnitKm) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1058,6 +1076,7 @@ func (c GeoradiusUnitM) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1068,6 +1087,7 @@ func (c GeoradiusUnitM) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1115,6 +1135,7 @@ func (c GeoradiusUnitMi) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1125,6 +1146,7 @@ func (c GeoradiusUnitMi) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1167,6 +1189,7 @@ func (c GeoradiusWithcoord) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1177,6 +1200,7 @@ func (c GeoradiusWithcoord) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1214,6 +1238,7 @@ func (c GeoradiusWithdist) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1224,6 +1249,7 @@ func (c GeoradiusWithdist) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1256,6 +1282,7 @@ func (c GeoradiusWithhash) Store(key string) GeoradiusStoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusStoreKey)(c)
 }
 
@@ -1266,6 +1293,7 @@ func (c GeoradiusWithhash) Storedist(key string) GeoradiusStoredistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusStoredistKey)(c)
 }
 
@@ -1289,6 +1317,7 @@ func (c Georadiusbymember) Key(key string) GeoradiusbymemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoradiusbymemberKey)(c)
 }
 
@@ -1311,6 +1340,7 @@ func (c GeoradiusbymemberCountAny) Store(key string) GeoradiusbymemberStoreStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1321,6 +1351,7 @@ func (c GeoradiusbymemberCountAny) Storedist(key string) GeoradiusbymemberStoreS
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -1353,6 +1384,7 @@ func (c GeoradiusbymemberCountCount) Store(key string) GeoradiusbymemberStoreSto
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1363,6 +1395,7 @@ func (c GeoradiusbymemberCountCount) Storedist(key string) GeoradiusbymemberStor
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -1394,6 +1427,7 @@ func (c GeoradiusbymemberOrderAsc) Store(key string) GeoradiusbymemberStoreStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1404,6 +1438,7 @@ func (c GeoradiusbymemberOrderAsc) Storedist(key string) GeoradiusbymemberStoreS
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -1421,6 +1456,7 @@ func (c GeoradiusbymemberOrderDesc) Store(key string) GeoradiusbymemberStoreStor
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1431,6 +1467,7 @@ func (c GeoradiusbymemberOrderDesc) Storedist(key string) GeoradiusbymemberStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -1476,6 +1513,7 @@ func (c GeoradiusbymemberRo) Key(key string) GeoradiusbymemberRoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeoradiusbymemberRoKey)(c)
 }
 
@@ -1905,6 +1943,7 @@ func (c GeoradiusbymemberUnitFt) Store(key string) GeoradiusbymemberStoreStoreKe
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1915,6 +1954,7 @@ func (c GeoradiusbymemberUnitFt) Storedist(key string) GeoradiusbymemberStoreSto
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -1962,6 +2002,7 @@ func (c GeoradiusbymemberUnitKm) Store(key string) GeoradiusbymemberStoreStoreKe
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -1972,6 +2013,7 @@ func (c GeoradiusbymemberUnitKm) Storedist(key string) GeoradiusbymemberStoreSto
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2019,6 +2061,7 @@ func (c GeoradiusbymemberUnitM) Store(key string) GeoradiusbymemberStoreStoreKey
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -2029,6 +2072,7 @@ func (c GeoradiusbymemberUnitM) Storedist(key string) GeoradiusbymemberStoreStor
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2076,6 +2120,7 @@ func (c GeoradiusbymemberUnitMi) Store(key string) GeoradiusbymemberStoreStoreKe
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -2086,6 +2131,7 @@ func (c GeoradiusbymemberUnitMi) Storedist(key string) GeoradiusbymemberStoreSto
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2128,6 +2174,7 @@ func (c GeoradiusbymemberWithcoord) Store(key string) GeoradiusbymemberStoreStor
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -2138,6 +2185,7 @@ func (c GeoradiusbymemberWithcoord) Storedist(key string) GeoradiusbymemberStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2175,6 +2223,7 @@ func (c GeoradiusbymemberWithdist) Store(key string) GeoradiusbymemberStoreStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -2185,6 +2234,7 @@ func (c GeoradiusbymemberWithdist) Storedist(key string) GeoradiusbymemberStoreS
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2217,6 +2267,7 @@ func (c GeoradiusbymemberWithhash) Store(key string) GeoradiusbymemberStoreStore
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STORE", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoreKey)(c)
 }
 
@@ -2227,6 +2278,7 @@ func (c GeoradiusbymemberWithhash) Storedist(key string) GeoradiusbymemberStoreS
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, "STOREDIST", key)
+	c.cs.key(1)
 	return (GeoradiusbymemberStoreStoredistKey)(c)
 }
 
@@ -2250,6 +2302,7 @@ func (c Geosearch) Key(key string) GeosearchKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GeosearchKey)(c)
 }
 
@@ -2890,6 +2943,7 @@ func (c Geosearchstore) Destination(destination string) GeosearchstoreDestinatio
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (GeosearchstoreDestination)(c)
 }
 
@@ -3218,6 +3272,7 @@ func (c GeosearchstoreDestination) Source(source string) GeosearchstoreSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (GeosearchstoreSource)(c)
 }
 
@@ -66,6 +66,7 @@ func (c GraphConstraintCreate) Key(key string) GraphConstraintCreateKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GraphConstraintCreateKey)(c)
 }
 
@@ -153,6 +154,7 @@ func (c GraphConstraintDrop) Key(key string) GraphConstraintDropKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GraphConstraintDropKey)(c)
 }
 
@@ -240,6 +242,7 @@ func (c GraphDelete) Graph(graph string) GraphDeleteGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphDeleteGraph)(c)
 }
 
@@ -265,6 +268,7 @@ func (c GraphExplain) Graph(graph string) GraphExplainGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphExplainGraph)(c)
 }
 
@@ -310,6 +314,7 @@ func (c GraphProfile) Graph(graph string) GraphProfileGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphProfileGraph)(c)
 }
 
@@ -354,6 +359,7 @@ func (c GraphQuery) Graph(graph string) GraphQueryGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphQueryGraph)(c)
 }
 
@@ -398,6 +404,7 @@ func (c GraphRoQuery) Graph(graph string) GraphRoQueryGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphRoQueryGraph)(c)
 }
 
@@ -452,6 +459,7 @@ func (c GraphSlowlog) Graph(graph string) GraphSlowlogGraph {
 		c.ks = check(c.ks, slot(graph))
 	}
 	c.cs.s = append(c.cs.s, graph)
+	c.cs.key(1)
 	return (GraphSlowlogGraph)(c)
 }
 
@@ -19,6 +19,7 @@ func (c Hdel) Key(key string) HdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HdelKey)(c)
 }
 
@@ -56,6 +57,7 @@ func (c Hexists) Key(key string) HexistsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HexistsKey)(c)
 }
 
@@ -93,6 +95,7 @@ func (c Hexpire) Key(key string) HexpireKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HexpireKey)(c)
 }
 
@@ -199,6 +202,7 @@ func (c Hexpireat) Key(key string) HexpireatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HexpireatKey)(c)
 }
 
@@ -305,6 +309,7 @@ func (c Hexpiretime) Key(key string) HexpiretimeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HexpiretimeKey)(c)
 }
 
@@ -356,6 +361,7 @@ func (c Hget) Key(key string) HgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HgetKey)(c)
 }
 
@@ -393,6 +399,7 @@ func (c Hgetall) Key(key string) HgetallKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HgetallKey)(c)
 }
 
@@ -423,6 +430,7 @@ func (c Hgetdel) Key(key string) HgetdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HgetdelKey)(c)
 }
 
@@ -474,6 +482,7 @@ func (c Hgetex) Key(key string) HgetexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HgetexKey)(c)
 }
 
@@ -585,6 +594,7 @@ func (c Hincrby) Key(key string) HincrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HincrbyKey)(c)
 }
 
@@ -624,6 +634,7 @@ func (c Hincrbyfloat) Key(key string) HincrbyfloatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HincrbyfloatKey)(c)
 }
 
@@ -663,6 +674,7 @@ func (c Hkeys) Key(key string) HkeysKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HkeysKey)(c)
 }
 
@@ -693,6 +705,7 @@ func (c Hlen) Key(key string) HlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HlenKey)(c)
 }
 
@@ -723,6 +736,7 @@ func (c Hmget) Key(key string) HmgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HmgetKey)(c)
 }
 
@@ -765,6 +779,7 @@ func (c Hmset) Key(key string) HmsetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HmsetKey)(c)
 }
 
@@ -801,6 +816,7 @@ func (c Hpersist) Key(key string) HpersistKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HpersistKey)(c)
 }
 
@@ -852,6 +868,7 @@ func (c Hpexpire) Key(key string) HpexpireKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HpexpireKey)(c)
 }
 
@@ -958,6 +975,7 @@ func (c Hpexpireat) Key(key string) HpexpireatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HpexpireatKey)(c)
 }
 
@@ -1064,6 +1082,7 @@ func (c Hpexpiretime) Key(key string) HpexpiretimeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HpexpiretimeKey)(c)
 }
 
@@ -1115,6 +1134,7 @@ func (c Hpttl) Key(key string) HpttlKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HpttlKey)(c)
 }
 
@@ -1166,6 +1186,7 @@ func (c Hrandfield) Key(key string) HrandfieldKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HrandfieldKey)(c)
 }
 
@@ -1215,6 +1236,7 @@ func (c Hscan) Key(key string) HscanKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HscanKey)(c)
 }
 
@@ -1298,6 +1320,7 @@ func (c Hset) Key(key string) HsetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HsetKey)(c)
 }
 
@@ -1334,6 +1357,7 @@ func (c Hsetex) Key(key string) HsetexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HsetexKey)(c)
 }
 
@@ -1518,6 +1542,7 @@ func (c Hsetnx) Key(key string) HsetnxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HsetnxKey)(c)
 }
 
@@ -1557,6 +1582,7 @@ func (c Hstrlen) Key(key string) HstrlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HstrlenKey)(c)
 }
 
@@ -1594,6 +1620,7 @@ func (c Httl) Key(key string) HttlKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HttlKey)(c)
 }
 
@@ -1645,6 +1672,7 @@ func (c Hvals) Key(key string) HvalsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (HvalsKey)(c)
 }
 
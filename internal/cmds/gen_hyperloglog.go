@@ -17,6 +17,7 @@ func (c Pfadd) Key(key string) PfaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PfaddKey)(c)
 }
 
@@ -64,6 +65,7 @@ func (c Pfcount) Key(key ...string) PfcountKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (PfcountKey)(c)
 }
 
@@ -81,6 +83,7 @@ func (c PfcountKey) Key(key ...string) PfcountKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -104,6 +107,7 @@ func (c Pfmerge) Destkey(destkey string) PfmergeDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (PfmergeDestkey)(c)
 }
 
@@ -121,6 +125,7 @@ func (c PfmergeDestkey) Sourcekey(sourcekey ...string) PfmergeSourcekey {
 		}
 	}
 	c.cs.s = append(c.cs.s, sourcekey...)
+	c.cs.keys(len(sourcekey))
 	return (PfmergeSourcekey)(c)
 }
 
@@ -143,6 +148,7 @@ func (c PfmergeSourcekey) Sourcekey(sourcekey ...string) PfmergeSourcekey {
 		}
 	}
 	c.cs.s = append(c.cs.s, sourcekey...)
+	c.cs.keys(len(sourcekey))
 	return c
 }
 
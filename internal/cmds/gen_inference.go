@@ -19,6 +19,7 @@ func (c AiModelexecute) Key(key string) AiModelexecuteKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiModelexecuteKey)(c)
 }
 
@@ -104,6 +105,7 @@ func (c AiScriptexecute) Key(key string) AiScriptexecuteKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiScriptexecuteKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c JsonArrappend) Key(key string) JsonArrappendKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrappendKey)(c)
 }
 
@@ -68,6 +69,7 @@ func (c JsonArrindex) Key(key string) JsonArrindexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrindexKey)(c)
 }
 
@@ -146,6 +148,7 @@ func (c JsonArrinsert) Key(key string) JsonArrinsertKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrinsertKey)(c)
 }
 
@@ -197,6 +200,7 @@ func (c JsonArrlen) Key(key string) JsonArrlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrlenKey)(c)
 }
 
@@ -244,6 +248,7 @@ func (c JsonArrpop) Key(key string) JsonArrpopKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrpopKey)(c)
 }
 
@@ -293,6 +298,7 @@ func (c JsonArrtrim) Key(key string) JsonArrtrimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonArrtrimKey)(c)
 }
 
@@ -339,6 +345,7 @@ func (c JsonClear) Key(key string) JsonClearKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonClearKey)(c)
 }
 
@@ -389,6 +396,7 @@ func (c JsonDebugMemory) Key(key string) JsonDebugMemoryKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonDebugMemoryKey)(c)
 }
 
@@ -426,6 +434,7 @@ func (c JsonDel) Key(key string) JsonDelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonDelKey)(c)
 }
 
@@ -463,6 +472,7 @@ func (c JsonForget) Key(key string) JsonForgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonForgetKey)(c)
 }
 
@@ -500,6 +510,7 @@ func (c JsonGet) Key(key string) JsonGetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonGetKey)(c)
 }
 
@@ -633,6 +644,7 @@ func (c JsonMerge) Key(key string) JsonMergeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonMergeKey)(c)
 }
 
@@ -677,6 +689,7 @@ func (c JsonMget) Key(key ...string) JsonMgetKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (JsonMgetKey)(c)
 }
 
@@ -694,6 +707,7 @@ func (c JsonMgetKey) Key(key ...string) JsonMgetKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -729,6 +743,7 @@ func (c JsonMset) Key(key string) JsonMsetTripletKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonMsetTripletKey)(c)
 }
 
@@ -755,6 +770,7 @@ func (c JsonMsetTripletValue) Key(key string) JsonMsetTripletKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonMsetTripletKey)(c)
 }
 
@@ -778,6 +794,7 @@ func (c JsonNumincrby) Key(key string) JsonNumincrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonNumincrbyKey)(c)
 }
 
@@ -817,6 +834,7 @@ func (c JsonNummultby) Key(key string) JsonNummultbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonNummultbyKey)(c)
 }
 
@@ -856,6 +874,7 @@ func (c JsonObjkeys) Key(key string) JsonObjkeysKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonObjkeysKey)(c)
 }
 
@@ -903,6 +922,7 @@ func (c JsonObjlen) Key(key string) JsonObjlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonObjlenKey)(c)
 }
 
@@ -950,6 +970,7 @@ func (c JsonResp) Key(key string) JsonRespKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonRespKey)(c)
 }
 
@@ -997,6 +1018,7 @@ func (c JsonSet) Key(key string) JsonSetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonSetKey)(c)
 }
 
@@ -1060,6 +1082,7 @@ func (c JsonStrappend) Key(key string) JsonStrappendKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonStrappendKey)(c)
 }
 
@@ -1104,6 +1127,7 @@ func (c JsonStrlen) Key(key string) JsonStrlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonStrlenKey)(c)
 }
 
@@ -1151,6 +1175,7 @@ func (c JsonToggle) Key(key string) JsonToggleKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonToggleKey)(c)
 }
 
@@ -1183,6 +1208,7 @@ func (c JsonType) Key(key string) JsonTypeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (JsonTypeKey)(c)
 }
 
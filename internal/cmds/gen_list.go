@@ -19,6 +19,7 @@ func (c Blmove) Source(source string) BlmoveSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (BlmoveSource)(c)
 }
 
@@ -43,6 +44,7 @@ func (c BlmoveSource) Destination(destination string) BlmoveDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (BlmoveDestination)(c)
 }
 
@@ -125,6 +127,7 @@ func (c BlmpopKey) Key(key ...string) BlmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -152,6 +155,7 @@ func (c BlmpopNumkeys) Key(key ...string) BlmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BlmpopKey)(c)
 }
 
@@ -206,6 +210,7 @@ func (c Blpop) Key(key ...string) BlpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BlpopKey)(c)
 }
 
@@ -223,6 +228,7 @@ func (c BlpopKey) Key(key ...string) BlpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -258,6 +264,7 @@ func (c Brpop) Key(key ...string) BrpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BrpopKey)(c)
 }
 
@@ -275,6 +282,7 @@ func (c BrpopKey) Key(key ...string) BrpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -305,6 +313,7 @@ func (c Brpoplpush) Source(source string) BrpoplpushSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (BrpoplpushSource)(c)
 }
 
@@ -324,6 +333,7 @@ func (c BrpoplpushSource) Destination(destination string) BrpoplpushDestination
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (BrpoplpushDestination)(c)
 }
 
@@ -349,6 +359,7 @@ func (c Lindex) Key(key string) LindexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LindexKey)(c)
 }
 
@@ -386,6 +397,7 @@ func (c Linsert) Key(key string) LinsertKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LinsertKey)(c)
 }
 
@@ -444,6 +456,7 @@ func (c Llen) Key(key string) LlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LlenKey)(c)
 }
 
@@ -474,6 +487,7 @@ func (c Lmove) Source(source string) LmoveSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (LmoveSource)(c)
 }
 
@@ -498,6 +512,7 @@ func (c LmoveSource) Destination(destination string) LmoveDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (LmoveDestination)(c)
 }
 
@@ -573,6 +588,7 @@ func (c LmpopKey) Key(key ...string) LmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -600,6 +616,7 @@ func (c LmpopNumkeys) Key(key ...string) LmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (LmpopKey)(c)
 }
 
@@ -642,6 +659,7 @@ func (c Lpop) Key(key string) LpopKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LpopKey)(c)
 }
 
@@ -679,6 +697,7 @@ func (c Lpos) Key(key string) LposKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LposKey)(c)
 }
 
@@ -782,6 +801,7 @@ func (c Lpush) Key(key string) LpushKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LpushKey)(c)
 }
 
@@ -819,6 +839,7 @@ func (c Lpushx) Key(key string) LpushxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LpushxKey)(c)
 }
 
@@ -856,6 +877,7 @@ func (c Lrange) Key(key string) LrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LrangeKey)(c)
 }
 
@@ -900,6 +922,7 @@ func (c Lrem) Key(key string) LremKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LremKey)(c)
 }
 
@@ -939,6 +962,7 @@ func (c Lset) Key(key string) LsetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LsetKey)(c)
 }
 
@@ -978,6 +1002,7 @@ func (c Ltrim) Key(key string) LtrimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (LtrimKey)(c)
 }
 
@@ -1017,6 +1042,7 @@ func (c Rpop) Key(key string) RpopKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RpopKey)(c)
 }
 
@@ -1054,6 +1080,7 @@ func (c Rpoplpush) Source(source string) RpoplpushSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (RpoplpushSource)(c)
 }
 
@@ -1073,6 +1100,7 @@ func (c RpoplpushSource) Destination(destination string) RpoplpushDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (RpoplpushDestination)(c)
 }
 
@@ -1091,6 +1119,7 @@ func (c Rpush) Key(key string) RpushKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RpushKey)(c)
 }
 
@@ -1128,6 +1157,7 @@ func (c Rpushx) Key(key string) RpushxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (RpushxKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c AiModeldel) Key(key string) AiModeldelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiModeldelKey)(c)
 }
 
@@ -44,6 +45,7 @@ func (c AiModelget) Key(key string) AiModelgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiModelgetKey)(c)
 }
 
@@ -113,6 +115,7 @@ func (c AiModelstore) Key(key string) AiModelstoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiModelstoreKey)(c)
 }
 
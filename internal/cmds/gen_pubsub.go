@@ -180,6 +180,7 @@ func (c PubsubShardnumsub) Channel(channel ...string) PubsubShardnumsubChannel {
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return (PubsubShardnumsubChannel)(c)
 }
 
@@ -202,6 +203,7 @@ func (c PubsubShardnumsubChannel) Channel(channel ...string) PubsubShardnumsubCh
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return c
 }
 
@@ -255,6 +257,7 @@ func (c Spublish) Channel(channel string) SpublishChannel {
 		c.ks = check(c.ks, slot(channel))
 	}
 	c.cs.s = append(c.cs.s, channel)
+	c.cs.key(1)
 	return (SpublishChannel)(c)
 }
 
@@ -292,6 +295,7 @@ func (c Ssubscribe) Channel(channel ...string) SsubscribeChannel {
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return (SsubscribeChannel)(c)
 }
 
@@ -309,6 +313,7 @@ func (c SsubscribeChannel) Channel(channel ...string) SsubscribeChannel {
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return c
 }
 
@@ -362,6 +367,7 @@ func (c Sunsubscribe) Channel(channel ...string) SunsubscribeChannel {
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return (SunsubscribeChannel)(c)
 }
 
@@ -384,6 +390,7 @@ func (c SunsubscribeChannel) Channel(channel ...string) SunsubscribeChannel {
 		}
 	}
 	c.cs.s = append(c.cs.s, channel...)
+	c.cs.keys(len(channel))
 	return c
 }
 
@@ -19,6 +19,7 @@ func (c AiScriptdel) Key(key string) AiScriptdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiScriptdelKey)(c)
 }
 
@@ -44,6 +45,7 @@ func (c AiScriptget) Key(key string) AiScriptgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiScriptgetKey)(c)
 }
 
@@ -113,6 +115,7 @@ func (c AiScriptstore) Key(key string) AiScriptstoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiScriptstoreKey)(c)
 }
 
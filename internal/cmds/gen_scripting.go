@@ -43,6 +43,7 @@ func (c EvalKey) Key(key ...string) EvalKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -70,6 +71,7 @@ func (c EvalNumkeys) Key(key ...string) EvalKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (EvalKey)(c)
 }
 
@@ -127,6 +129,7 @@ func (c EvalRoKey) Key(key ...string) EvalRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -159,6 +162,7 @@ func (c EvalRoNumkeys) Key(key ...string) EvalRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (EvalRoKey)(c)
 }
 
@@ -230,6 +234,7 @@ func (c EvalshaKey) Key(key ...string) EvalshaKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -257,6 +262,7 @@ func (c EvalshaNumkeys) Key(key ...string) EvalshaKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (EvalshaKey)(c)
 }
 
@@ -314,6 +320,7 @@ func (c EvalshaRoKey) Key(key ...string) EvalshaRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -346,6 +353,7 @@ func (c EvalshaRoNumkeys) Key(key ...string) EvalshaRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (EvalshaRoKey)(c)
 }
 
@@ -424,6 +432,7 @@ func (c FcallKey) Key(key ...string) FcallKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -451,6 +460,7 @@ func (c FcallNumkeys) Key(key ...string) FcallKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (FcallKey)(c)
 }
 
@@ -515,6 +525,7 @@ func (c FcallRoKey) Key(key ...string) FcallRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -547,6 +558,7 @@ func (c FcallRoNumkeys) Key(key ...string) FcallRoKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (FcallRoKey)(c)
 }
 
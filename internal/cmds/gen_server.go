@@ -645,6 +645,7 @@ func (c DebugObject) Key(key string) DebugObjectKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (DebugObjectKey)(c)
 }
 
@@ -1129,6 +1130,7 @@ func (c MemoryUsage) Key(key string) MemoryUsageKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (MemoryUsageKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c Sadd) Key(key string) SaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SaddKey)(c)
 }
 
@@ -56,6 +57,7 @@ func (c Scard) Key(key string) ScardKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ScardKey)(c)
 }
 
@@ -91,6 +93,7 @@ func (c Sdiff) Key(key ...string) SdiffKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SdiffKey)(c)
 }
 
@@ -108,6 +111,7 @@ func (c SdiffKey) Key(key ...string) SdiffKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -131,6 +135,7 @@ func (c Sdiffstore) Destination(destination string) SdiffstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (SdiffstoreDestination)(c)
 }
 
@@ -148,6 +153,7 @@ func (c SdiffstoreDestination) Key(key ...string) SdiffstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SdiffstoreKey)(c)
 }
 
@@ -165,6 +171,7 @@ func (c SdiffstoreKey) Key(key ...string) SdiffstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -193,6 +200,7 @@ func (c Sinter) Key(key ...string) SinterKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SinterKey)(c)
 }
 
@@ -210,6 +218,7 @@ func (c SinterKey) Key(key ...string) SinterKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -245,6 +254,7 @@ func (c SintercardKey) Key(key ...string) SintercardKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -279,6 +289,7 @@ func (c SintercardNumkeys) Key(key ...string) SintercardKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SintercardKey)(c)
 }
 
@@ -297,6 +308,7 @@ func (c Sinterstore) Destination(destination string) SinterstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (SinterstoreDestination)(c)
 }
 
@@ -314,6 +326,7 @@ func (c SinterstoreDestination) Key(key ...string) SinterstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SinterstoreKey)(c)
 }
 
@@ -331,6 +344,7 @@ func (c SinterstoreKey) Key(key ...string) SinterstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -354,6 +368,7 @@ func (c Sismember) Key(key string) SismemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SismemberKey)(c)
 }
 
@@ -391,6 +406,7 @@ func (c Smembers) Key(key string) SmembersKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SmembersKey)(c)
 }
 
@@ -421,6 +437,7 @@ func (c Smismember) Key(key string) SmismemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SmismemberKey)(c)
 }
 
@@ -463,6 +480,7 @@ func (c Smove) Source(source string) SmoveSource {
 		c.ks = check(c.ks, slot(source))
 	}
 	c.cs.s = append(c.cs.s, source)
+	c.cs.key(1)
 	return (SmoveSource)(c)
 }
 
@@ -489,6 +507,7 @@ func (c SmoveSource) Destination(destination string) SmoveDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (SmoveDestination)(c)
 }
 
@@ -507,6 +526,7 @@ func (c Spop) Key(key string) SpopKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SpopKey)(c)
 }
 
@@ -544,6 +564,7 @@ func (c Srandmember) Key(key string) SrandmemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SrandmemberKey)(c)
 }
 
@@ -581,6 +602,7 @@ func (c Srem) Key(key string) SremKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SremKey)(c)
 }
 
@@ -618,6 +640,7 @@ func (c Sscan) Key(key string) SscanKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SscanKey)(c)
 }
 
@@ -684,6 +707,7 @@ func (c Sunion) Key(key ...string) SunionKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SunionKey)(c)
 }
 
@@ -701,6 +725,7 @@ func (c SunionKey) Key(key ...string) SunionKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -724,6 +749,7 @@ func (c Sunionstore) Destination(destination string) SunionstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (SunionstoreDestination)(c)
 }
 
@@ -741,6 +767,7 @@ func (c SunionstoreDestination) Key(key ...string) SunionstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (SunionstoreKey)(c)
 }
 
@@ -758,6 +785,7 @@ func (c SunionstoreKey) Key(key ...string) SunionstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -38,6 +38,7 @@ func (c BzmpopKey) Key(key ...string) BzmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -65,6 +66,7 @@ func (c BzmpopNumkeys) Key(key ...string) BzmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BzmpopKey)(c)
 }
 
@@ -119,6 +121,7 @@ func (c Bzpopmax) Key(key ...string) BzpopmaxKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BzpopmaxKey)(c)
 }
 
@@ -136,6 +139,7 @@ func (c BzpopmaxKey) Key(key ...string) BzpopmaxKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -171,6 +175,7 @@ func (c Bzpopmin) Key(key ...string) BzpopminKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (BzpopminKey)(c)
 }
 
@@ -188,6 +193,7 @@ func (c BzpopminKey) Key(key ...string) BzpopminKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -218,6 +224,7 @@ func (c Zadd) Key(key string) ZaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZaddKey)(c)
 }
 
@@ -385,6 +392,7 @@ func (c Zcard) Key(key string) ZcardKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZcardKey)(c)
 }
 
@@ -415,6 +423,7 @@ func (c Zcount) Key(key string) ZcountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZcountKey)(c)
 }
 
@@ -471,6 +480,7 @@ func (c ZdiffKey) Key(key ...string) ZdiffKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -498,6 +508,7 @@ func (c ZdiffNumkeys) Key(key ...string) ZdiffKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZdiffKey)(c)
 }
 
@@ -523,6 +534,7 @@ func (c Zdiffstore) Destination(destination string) ZdiffstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (ZdiffstoreDestination)(c)
 }
 
@@ -547,6 +559,7 @@ func (c ZdiffstoreKey) Key(key ...string) ZdiffstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -569,6 +582,7 @@ func (c ZdiffstoreNumkeys) Key(key ...string) ZdiffstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZdiffstoreKey)(c)
 }
 
@@ -587,6 +601,7 @@ func (c Zincrby) Key(key string) ZincrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZincrbyKey)(c)
 }
 
@@ -674,6 +689,7 @@ func (c ZinterKey) Key(key ...string) ZinterKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -724,6 +740,7 @@ func (c ZinterNumkeys) Key(key ...string) ZinterKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZinterKey)(c)
 }
 
@@ -796,6 +813,7 @@ func (c ZintercardKey) Key(key ...string) ZintercardKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -830,6 +848,7 @@ func (c ZintercardNumkeys) Key(key ...string) ZintercardKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZintercardKey)(c)
 }
 
@@ -848,6 +867,7 @@ func (c Zinterstore) Destination(destination string) ZinterstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (ZinterstoreDestination)(c)
 }
 
@@ -893,6 +913,7 @@ func (c ZinterstoreKey) Key(key ...string) ZinterstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -938,6 +959,7 @@ func (c ZinterstoreNumkeys) Key(key ...string) ZinterstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZinterstoreKey)(c)
 }
 
@@ -986,6 +1008,7 @@ func (c Zlexcount) Key(key string) ZlexcountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZlexcountKey)(c)
 }
 
@@ -1049,6 +1072,7 @@ func (c ZmpopKey) Key(key ...string) ZmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -1076,6 +1100,7 @@ func (c ZmpopNumkeys) Key(key ...string) ZmpopKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZmpopKey)(c)
 }
 
@@ -1118,6 +1143,7 @@ func (c Zmscore) Key(key string) ZmscoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZmscoreKey)(c)
 }
 
@@ -1160,6 +1186,7 @@ func (c Zpopmax) Key(key string) ZpopmaxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZpopmaxKey)(c)
 }
 
@@ -1197,6 +1224,7 @@ func (c Zpopmin) Key(key string) ZpopminKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZpopminKey)(c)
 }
 
@@ -1234,6 +1262,7 @@ func (c Zrandmember) Key(key string) ZrandmemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrandmemberKey)(c)
 }
 
@@ -1283,6 +1312,7 @@ func (c Zrange) Key(key string) ZrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrangeKey)(c)
 }
 
@@ -1457,6 +1487,7 @@ func (c Zrangebylex) Key(key string) ZrangebylexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrangebylexKey)(c)
 }
 
@@ -1518,6 +1549,7 @@ func (c Zrangebyscore) Key(key string) ZrangebyscoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrangebyscoreKey)(c)
 }
 
@@ -1601,6 +1633,7 @@ func (c Zrangestore) Dst(dst string) ZrangestoreDst {
 		c.ks = check(c.ks, slot(dst))
 	}
 	c.cs.s = append(c.cs.s, dst)
+	c.cs.key(1)
 	return (ZrangestoreDst)(c)
 }
 
@@ -1613,6 +1646,7 @@ func (c ZrangestoreDst) Src(src string) ZrangestoreSrc {
 		c.ks = check(c.ks, slot(src))
 	}
 	c.cs.s = append(c.cs.s, src)
+	c.cs.key(1)
 	return (ZrangestoreSrc)(c)
 }
 
@@ -1725,6 +1759,7 @@ func (c Zrank) Key(key string) ZrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrankKey)(c)
 }
 
@@ -1779,6 +1814,7 @@ func (c Zrem) Key(key string) ZremKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZremKey)(c)
 }
 
@@ -1816,6 +1852,7 @@ func (c Zremrangebylex) Key(key string) ZremrangebylexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZremrangebylexKey)(c)
 }
 
@@ -1855,6 +1892,7 @@ func (c Zremrangebyrank) Key(key string) ZremrangebyrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZremrangebyrankKey)(c)
 }
 
@@ -1894,6 +1932,7 @@ func (c Zremrangebyscore) Key(key string) ZremrangebyscoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZremrangebyscoreKey)(c)
 }
 
@@ -1933,6 +1972,7 @@ func (c Zrevrange) Key(key string) ZrevrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrevrangeKey)(c)
 }
 
@@ -1994,6 +2034,7 @@ func (c Zrevrangebylex) Key(key string) ZrevrangebylexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrevrangebylexKey)(c)
 }
 
@@ -2055,6 +2096,7 @@ func (c Zrevrangebyscore) Key(key string) ZrevrangebyscoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrevrangebyscoreKey)(c)
 }
 
@@ -2138,6 +2180,7 @@ func (c Zrevrank) Key(key string) ZrevrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZrevrankKey)(c)
 }
 
@@ -2192,6 +2235,7 @@ func (c Zscan) Key(key string) ZscanKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZscanKey)(c)
 }
 
@@ -2275,6 +2319,7 @@ func (c Zscore) Key(key string) ZscoreKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (ZscoreKey)(c)
 }
 
@@ -2360,6 +2405,7 @@ func (c ZunionKey) Key(key ...string) ZunionKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -2410,6 +2456,7 @@ func (c ZunionNumkeys) Key(key ...string) ZunionKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZunionKey)(c)
 }
 
@@ -2470,6 +2517,7 @@ func (c Zunionstore) Destination(destination string) ZunionstoreDestination {
 		c.ks = check(c.ks, slot(destination))
 	}
 	c.cs.s = append(c.cs.s, destination)
+	c.cs.key(1)
 	return (ZunionstoreDestination)(c)
 }
 
@@ -2515,6 +2563,7 @@ func (c ZunionstoreKey) Key(key ...string) ZunionstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -2560,6 +2609,7 @@ func (c ZunionstoreNumkeys) Key(key ...string) ZunionstoreKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (ZunionstoreKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c Xack) Key(key string) XackKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XackKey)(c)
 }
 
@@ -63,6 +64,7 @@ func (c Xackdel) Key(key string) XackdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XackdelKey)(c)
 }
 
@@ -157,6 +159,7 @@ func (c Xadd) Key(key string) XaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XaddKey)(c)
 }
 
@@ -350,6 +353,7 @@ func (c Xautoclaim) Key(key string) XautoclaimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XautoclaimKey)(c)
 }
 
@@ -432,6 +436,7 @@ func (c Xclaim) Key(key string) XclaimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XclaimKey)(c)
 }
 
@@ -637,6 +642,7 @@ func (c Xdel) Key(key string) XdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XdelKey)(c)
 }
 
@@ -674,6 +680,7 @@ func (c Xdelex) Key(key string) XdelexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XdelexKey)(c)
 }
 
@@ -761,6 +768,7 @@ func (c XgroupCreate) Key(key string) XgroupCreateKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XgroupCreateKey)(c)
 }
 
@@ -829,6 +837,7 @@ func (c XgroupCreateconsumer) Key(key string) XgroupCreateconsumerKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XgroupCreateconsumerKey)(c)
 }
 
@@ -868,6 +877,7 @@ func (c XgroupDelconsumer) Key(key string) XgroupDelconsumerKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XgroupDelconsumerKey)(c)
 }
 
@@ -907,6 +917,7 @@ func (c XgroupDestroy) Key(key string) XgroupDestroyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XgroupDestroyKey)(c)
 }
 
@@ -952,6 +963,7 @@ func (c XgroupSetid) Key(key string) XgroupSetidKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XgroupSetidKey)(c)
 }
 
@@ -1003,6 +1015,7 @@ func (c XinfoConsumers) Key(key string) XinfoConsumersKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XinfoConsumersKey)(c)
 }
 
@@ -1035,6 +1048,7 @@ func (c XinfoGroups) Key(key string) XinfoGroupsKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XinfoGroupsKey)(c)
 }
 
@@ -1073,6 +1087,7 @@ func (c XinfoStream) Key(key string) XinfoStreamKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XinfoStreamKey)(c)
 }
 
@@ -1122,6 +1137,7 @@ func (c Xlen) Key(key string) XlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XlenKey)(c)
 }
 
@@ -1147,6 +1163,7 @@ func (c Xpending) Key(key string) XpendingKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XpendingKey)(c)
 }
 
@@ -1229,6 +1246,7 @@ func (c Xrange) Key(key string) XrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XrangeKey)(c)
 }
 
@@ -1335,6 +1353,7 @@ func (c XreadKey) Key(key ...string) XreadKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -1357,6 +1376,7 @@ func (c XreadStreams) Key(key ...string) XreadKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (XreadKey)(c)
 }
 
@@ -1452,6 +1472,7 @@ func (c XreadgroupKey) Key(key ...string) XreadgroupKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -1481,6 +1502,7 @@ func (c XreadgroupStreams) Key(key ...string) XreadgroupKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (XreadgroupKey)(c)
 }
 
@@ -1499,6 +1521,7 @@ func (c Xrevrange) Key(key string) XrevrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XrevrangeKey)(c)
 }
 
@@ -1550,6 +1573,7 @@ func (c Xsetid) Key(key string) XsetidKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XsetidKey)(c)
 }
 
@@ -1611,6 +1635,7 @@ func (c Xtrim) Key(key string) XtrimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (XtrimKey)(c)
 }
 
@@ -22,6 +22,7 @@ func (c Append) Key(key string) AppendKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AppendKey)(c)
 }
 
@@ -54,6 +55,7 @@ func (c Decr) Key(key string) DecrKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (DecrKey)(c)
 }
 
@@ -79,6 +81,7 @@ func (c Decrby) Key(key string) DecrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (DecrbyKey)(c)
 }
 
@@ -111,6 +114,7 @@ func (c Delifeq) Key(key string) DelifeqKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (DelifeqKey)(c)
 }
 
@@ -143,6 +147,7 @@ func (c Get) Key(key string) GetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetKey)(c)
 }
 
@@ -173,6 +178,7 @@ func (c Getdel) Key(key string) GetdelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetdelKey)(c)
 }
 
@@ -198,6 +204,7 @@ func (c Getex) Key(key string) GetexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetexKey)(c)
 }
 
@@ -331,6 +338,7 @@ func (c Getrange) Key(key string) GetrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetrangeKey)(c)
 }
 
@@ -375,6 +383,7 @@ func (c Getset) Key(key string) GetsetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (GetsetKey)(c)
 }
 
@@ -407,6 +416,7 @@ func (c Incr) Key(key string) IncrKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (IncrKey)(c)
 }
 
@@ -432,6 +442,7 @@ func (c Incrby) Key(key string) IncrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (IncrbyKey)(c)
 }
 
@@ -464,6 +475,7 @@ func (c Incrbyfloat) Key(key string) IncrbyfloatKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (IncrbyfloatKey)(c)
 }
 
@@ -496,6 +508,7 @@ func (c Lcs) Key1(key1 string) LcsKey1 {
 		c.ks = check(c.ks, slot(key1))
 	}
 	c.cs.s = append(c.cs.s, key1)
+	c.cs.key(1)
 	return (LcsKey1)(c)
 }
 
@@ -525,6 +538,7 @@ func (c LcsKey1) Key2(key2 string) LcsKey2 {
 		c.ks = check(c.ks, slot(key2))
 	}
 	c.cs.s = append(c.cs.s, key2)
+	c.cs.key(1)
 	return (LcsKey2)(c)
 }
 
@@ -616,6 +630,7 @@ func (c Mget) Key(key ...string) MgetKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (MgetKey)(c)
 }
 
@@ -633,6 +648,7 @@ func (c MgetKey) Key(key ...string) MgetKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -667,6 +683,7 @@ func (c MsetKeyValue) KeyValue(key string, value string) MsetKeyValue {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key, value)
+	c.cs.key(2)
 	return c
 }
 
@@ -696,6 +713,7 @@ func (c MsetnxKeyValue) KeyValue(key string, value string) MsetnxKeyValue {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key, value)
+	c.cs.key(2)
 	return c
 }
 
@@ -719,6 +737,7 @@ func (c Psetex) Key(key string) PsetexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (PsetexKey)(c)
 }
 
@@ -758,6 +777,7 @@ func (c Set) Key(key string) SetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SetKey)(c)
 }
 
@@ -1141,6 +1161,7 @@ func (c Setex) Key(key string) SetexKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SetexKey)(c)
 }
 
@@ -1180,6 +1201,7 @@ func (c Setnx) Key(key string) SetnxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SetnxKey)(c)
 }
 
@@ -1212,6 +1234,7 @@ func (c Setrange) Key(key string) SetrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (SetrangeKey)(c)
 }
 
@@ -1251,6 +1274,7 @@ func (c Strlen) Key(key string) StrlenKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (StrlenKey)(c)
 }
 
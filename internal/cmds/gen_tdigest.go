@@ -19,6 +19,7 @@ func (c TdigestAdd) Key(key string) TdigestAddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestAddKey)(c)
 }
 
@@ -56,6 +57,7 @@ func (c TdigestByrank) Key(key string) TdigestByrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestByrankKey)(c)
 }
 
@@ -97,6 +99,7 @@ func (c TdigestByrevrank) Key(key string) TdigestByrevrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestByrevrankKey)(c)
 }
 
@@ -138,6 +141,7 @@ func (c TdigestCdf) Key(key string) TdigestCdfKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestCdfKey)(c)
 }
 
@@ -179,6 +183,7 @@ func (c TdigestCreate) Key(key string) TdigestCreateKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestCreateKey)(c)
 }
 
@@ -216,6 +221,7 @@ func (c TdigestInfo) Key(key string) TdigestInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestInfoKey)(c)
 }
 
@@ -241,6 +247,7 @@ func (c TdigestMax) Key(key string) TdigestMaxKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestMaxKey)(c)
 }
 
@@ -266,6 +273,7 @@ func (c TdigestMerge) DestinationKey(destinationKey string) TdigestMergeDestinat
 		c.ks = check(c.ks, slot(destinationKey))
 	}
 	c.cs.s = append(c.cs.s, destinationKey)
+	c.cs.key(1)
 	return (TdigestMergeDestinationKey)(c)
 }
 
@@ -302,6 +310,7 @@ func (c TdigestMergeNumkeys) SourceKey(sourceKey ...string) TdigestMergeSourceKe
 		}
 	}
 	c.cs.s = append(c.cs.s, sourceKey...)
+	c.cs.keys(len(sourceKey))
 	return (TdigestMergeSourceKey)(c)
 }
 
@@ -326,6 +335,7 @@ func (c TdigestMergeSourceKey) SourceKey(sourceKey ...string) TdigestMergeSource
 		}
 	}
 	c.cs.s = append(c.cs.s, sourceKey...)
+	c.cs.keys(len(sourceKey))
 	return c
 }
 
@@ -359,6 +369,7 @@ func (c TdigestMin) Key(key string) TdigestMinKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestMinKey)(c)
 }
 
@@ -384,6 +395,7 @@ func (c TdigestQuantile) Key(key string) TdigestQuantileKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestQuantileKey)(c)
 }
 
@@ -425,6 +437,7 @@ func (c TdigestRank) Key(key string) TdigestRankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestRankKey)(c)
 }
 
@@ -466,6 +479,7 @@ func (c TdigestReset) Key(key string) TdigestResetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestResetKey)(c)
 }
 
@@ -491,6 +505,7 @@ func (c TdigestRevrank) Key(key string) TdigestRevrankKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestRevrankKey)(c)
 }
 
@@ -532,6 +547,7 @@ func (c TdigestTrimmedMean) Key(key string) TdigestTrimmedMeanKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TdigestTrimmedMeanKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c AiTensorget) Key(key string) AiTensorgetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiTensorgetKey)(c)
 }
 
@@ -90,6 +91,7 @@ func (c AiTensorset) Key(key string) AiTensorsetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (AiTensorsetKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c TsAdd) Key(key string) TsAddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsAddKey)(c)
 }
 
@@ -395,6 +396,7 @@ func (c TsAlter) Key(key string) TsAlterKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsAlterKey)(c)
 }
 
@@ -641,6 +643,7 @@ func (c TsCreate) Key(key string) TsCreateKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsCreateKey)(c)
 }
 
@@ -1003,6 +1006,7 @@ func (c TsCreaterule) Sourcekey(sourcekey string) TsCreateruleSourcekey {
 		c.ks = check(c.ks, slot(sourcekey))
 	}
 	c.cs.s = append(c.cs.s, sourcekey)
+	c.cs.key(1)
 	return (TsCreateruleSourcekey)(c)
 }
 
@@ -1192,6 +1196,7 @@ func (c TsCreateruleSourcekey) Destkey(destkey string) TsCreateruleDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (TsCreateruleDestkey)(c)
 }
 
@@ -1210,6 +1215,7 @@ func (c TsDecrby) Key(key string) TsDecrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsDecrbyKey)(c)
 }
 
@@ -1357,6 +1363,7 @@ func (c TsDel) Key(key string) TsDelKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsDelKey)(c)
 }
 
@@ -1396,6 +1403,7 @@ func (c TsDeleterule) Sourcekey(sourcekey string) TsDeleteruleSourcekey {
 		c.ks = check(c.ks, slot(sourcekey))
 	}
 	c.cs.s = append(c.cs.s, sourcekey)
+	c.cs.key(1)
 	return (TsDeleteruleSourcekey)(c)
 }
 
@@ -1415,6 +1423,7 @@ func (c TsDeleteruleSourcekey) Destkey(destkey string) TsDeleteruleDestkey {
 		c.ks = check(c.ks, slot(destkey))
 	}
 	c.cs.s = append(c.cs.s, destkey)
+	c.cs.key(1)
 	return (TsDeleteruleDestkey)(c)
 }
 
@@ -1433,6 +1442,7 @@ func (c TsGet) Key(key string) TsGetKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsGetKey)(c)
 }
 
@@ -1470,6 +1480,7 @@ func (c TsIncrby) Key(key string) TsIncrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsIncrbyKey)(c)
 }
 
@@ -1617,6 +1628,7 @@ func (c TsInfo) Key(key string) TsInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsInfoKey)(c)
 }
 
@@ -1660,6 +1672,7 @@ func (c TsMaddKeyTimestampValue) KeyTimestampValue(key string, timestamp int64,
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key, strconv.FormatInt(timestamp, 10), strconv.FormatFloat(value, 'f', -1, 64))
+	c.cs.key(3)
 	return c
 }
 
@@ -3610,6 +3623,7 @@ func (c TsRange) Key(key string) TsRangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsRangeKey)(c)
 }
 
@@ -4290,6 +4304,7 @@ func (c TsRevrange) Key(key string) TsRevrangeKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TsRevrangeKey)(c)
 }
 
@@ -19,6 +19,7 @@ func (c TopkAdd) Key(key string) TopkAddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkAddKey)(c)
 }
 
@@ -56,6 +57,7 @@ func (c TopkCount) Key(key string) TopkCountKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkCountKey)(c)
 }
 
@@ -93,6 +95,7 @@ func (c TopkIncrby) Key(key string) TopkIncrbyKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkIncrbyKey)(c)
 }
 
@@ -137,6 +140,7 @@ func (c TopkInfo) Key(key string) TopkInfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkInfoKey)(c)
 }
 
@@ -167,6 +171,7 @@ func (c TopkList) Key(key string) TopkListKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkListKey)(c)
 }
 
@@ -214,6 +219,7 @@ func (c TopkQuery) Key(key string) TopkQueryKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkQueryKey)(c)
 }
 
@@ -256,6 +262,7 @@ func (c TopkReserve) Key(key string) TopkReserveKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (TopkReserveKey)(c)
 }
 
@@ -74,6 +74,7 @@ func (c Watch) Key(key ...string) WatchKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (WatchKey)(c)
 }
 
@@ -91,6 +92,7 @@ func (c WatchKey) Key(key ...string) WatchKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -43,6 +43,7 @@ func (c TfcallKey) Key(key ...string) TfcallKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -77,6 +78,7 @@ func (c TfcallNumkeys) Key(key ...string) TfcallKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (TfcallKey)(c)
 }
 
@@ -129,6 +131,7 @@ func (c TfcallasyncKey) Key(key ...string) TfcallasyncKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return c
 }
 
@@ -163,6 +166,7 @@ func (c TfcallasyncNumkeys) Key(key ...string) TfcallasyncKey {
 		}
 	}
 	c.cs.s = append(c.cs.s, key...)
+	c.cs.keys(len(key))
 	return (TfcallasyncKey)(c)
 }
 
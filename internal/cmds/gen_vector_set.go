@@ -19,6 +19,7 @@ func (c Vadd) Key(key string) VaddKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VaddKey)(c)
 }
 
@@ -284,6 +285,7 @@ func (c Vcard) Key(key string) VcardKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VcardKey)(c)
 }
 
@@ -309,6 +311,7 @@ func (c Vdim) Key(key string) VdimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VdimKey)(c)
 }
 
@@ -334,6 +337,7 @@ func (c Vemb) Key(key string) VembKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VembKey)(c)
 }
 
@@ -378,6 +382,7 @@ func (c Vgetattr) Key(key string) VgetattrKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VgetattrKey)(c)
 }
 
@@ -410,6 +415,7 @@ func (c Vinfo) Key(key string) VinfoKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VinfoKey)(c)
 }
 
@@ -435,6 +441,7 @@ func (c Vlinks) Key(key string) VlinksKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VlinksKey)(c)
 }
 
@@ -479,6 +486,7 @@ func (c Vrandmember) Key(key string) VrandmemberKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VrandmemberKey)(c)
 }
 
@@ -516,6 +524,7 @@ func (c Vrem) Key(key string) VremKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VremKey)(c)
 }
 
@@ -548,6 +557,7 @@ func (c Vsetattr) Key(key string) VsetattrKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VsetattrKey)(c)
 }
 
@@ -587,6 +597,7 @@ func (c Vsim) Key(key string) VsimKey {
 		c.ks = check(c.ks, slot(key))
 	}
 	c.cs.s = append(c.cs.s, key)
+	c.cs.key(1)
 	return (VsimKey)(c)
 }
 
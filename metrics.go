@@ -0,0 +1,98 @@
+package valkey
+
+import (
+	"net"
+	"time"
+)
+
+// PipeStats is a snapshot of a pipelining connection.
+type PipeStats struct {
+	// InFlight is the number of commands waiting for their replies, including the queued ones.
+	InFlight int
+	// Queued is the number of commands in the ring queue waiting to be written.
+	Queued int
+}
+
+// PoolStats is a snapshot of the connection pool for blocking commands and dedicated clients of a node.
+type PoolStats struct {
+	// Size is the number of connections made by the pool, including the ones in use.
+	Size int
+	// Idle is the number of idle connections in the pool.
+	Idle int
+}
+
+// MetricsHooks are called by the client to report its internal metrics.
+// Every hook is optional and receives the address of the node as its first argument.
+// Hooks are called synchronously in the hot paths of the client, so they must be fast and must not block.
+// See the valkeyotel package for an implementation exporting them as OpenTelemetry metrics.
+type MetricsHooks struct {
+	// OnPipeOpen is called when a pipelining connection is established.
+	// The stats function reports the current state of the connection and is safe to be called concurrently.
+	// The returned function, if not nil, is called once the connection is closed.
+	OnPipeOpen func(addr string, stats func() PipeStats) (onClose func())
+	// OnPoolOpen is called when the connection pool for blocking commands and dedicated clients of a node is created.
+	// The stats function reports the current state of the pool and is safe to be called concurrently.
+	// The returned function, if not nil, is called once the pool is closed.
+	OnPoolOpen func(addr string, stats func() PoolStats) (onClose func())
+	// OnPoolWait is called when acquiring a connection from the pool had to wait for another one to be released.
+	OnPoolWait func(addr string, wait time.Duration)
+	// OnBytesRead is called with the number of bytes of each read from a connection.
+	OnBytesRead func(addr string, n int)
+	// OnBytesWritten is called with the number of bytes of each write to a connection.
+	OnBytesWritten func(addr string, n int)
+	// OnFlush is called with the number of commands written by each flush of the background writer of a pipelining connection.
+	OnFlush func(addr string, commands int)
+	// OnRedirect is called when the node at addr redirects a command to the node at target with MOVED or ASK.
+	OnRedirect func(addr string, target string, mode RedirectMode)
+}
+
+func (h *MetricsHooks) enabled() bool {
+	return h.OnPipeOpen != nil || h.OnPoolOpen != nil || h.OnPoolWait != nil ||
+		h.OnBytesRead != nil || h.OnBytesWritten != nil || h.OnFlush != nil || h.OnRedirect != nil
+}
+
+// meteredConn reports the bytes read and written to the MetricsHooks.
+// It also carries the node address to the pipe built on top of it.
+type meteredConn struct {
+	net.Conn
+	hooks *MetricsHooks
+	addr  string
+}
+
+func (c *meteredConn) Read(b []byte) (n int, err error) {
+	n, err = c.Conn.Read(b)
+	if n > 0 && c.hooks.OnBytesRead != nil {
+		c.hooks.OnBytesRead(c.addr, n)
+	}
+	return
+}
+
+func (c *meteredConn) Write(b []byte) (n int, err error) {
+	n, err = c.Conn.Write(b)
+	if n > 0 && c.hooks.OnBytesWritten != nil {
+		c.hooks.OnBytesWritten(c.addr, n)
+	}
+	return
+}
+
+func (c *meteredConn) flushed(commands int) {
+	if commands > 0 && c.hooks.OnFlush != nil {
+		c.hooks.OnFlush(c.addr, commands)
+	}
+}
+
+// openPipe registers the pipe to the OnPipeOpen hook.
+func (c *meteredConn) openPipe(p *pipe) {
+	if c.hooks.OnPipeOpen != nil {
+		if fn := c.hooks.OnPipeOpen(c.addr, p.stats); fn != nil {
+			p.unmeter.Store(&fn)
+		}
+	}
+}
+
+func meter(conn net.Conn, addr string, hooks *MetricsHooks) net.Conn {
+	if !hooks.enabled() {
+		return conn
+	}
+	return &meteredConn{Conn: conn, hooks: hooks, addr: addr}
+}
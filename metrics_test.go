@@ -0,0 +1,177 @@
+package valkey
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+func TestMetricsHooksPipe(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var (
+		read, written, flushed int64
+		opened, closed         int32
+		stats                  func() PipeStats
+	)
+	option := ClientOption{
+		DisableCache:     true,
+		AlwaysPipelining: true,
+		ClientSetInfo:    DisableClientSetInfo,
+		MetricsHooks: MetricsHooks{
+			OnPipeOpen: func(addr string, s func() PipeStats) func() {
+				if addr != "node:6379" {
+					t.Errorf("unexpected addr %v", addr)
+				}
+				atomic.AddInt32(&opened, 1)
+				stats = s
+				return func() { atomic.AddInt32(&closed, 1) }
+			},
+			OnBytesRead:    func(addr string, n int) { atomic.AddInt64(&read, int64(n)) },
+			OnBytesWritten: func(addr string, n int) { atomic.AddInt64(&written, int64(n)) },
+			OnFlush:        func(addr string, commands int) { atomic.AddInt64(&flushed, int64(commands)) },
+		},
+	}
+	n1, n2 := net.Pipe()
+	defer n1.Close()
+	defer n2.Close()
+	mock := &valkeyMock{t: t, buf: bufio.NewReader(n2), conn: n2}
+	go func() {
+		mock.Expect("HELLO", "3").
+			Reply(slicemsg('%', []ValkeyMessage{
+				strmsg('+', "proto"),
+				{typ: ':', intlen: 3},
+			}))
+		mock.Expect("GET", "a").ReplyString("b")
+		mock.Expect("PING").ReplyString("OK")
+	}()
+	p, err := newPipe(context.Background(), func(ctx context.Context) (net.Conn, error) {
+		return meter(n1, "node:6379", &option.MetricsHooks), nil
+	}, &option)
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if atomic.LoadInt32(&opened) != 1 {
+		t.Fatalf("OnPipeOpen is not called")
+	}
+	if v, err := p.Do(context.Background(), cmds.NewCompleted([]string{"GET", "a"})).ToString(); err != nil || v != "b" {
+		t.Fatalf("unexpected resp %v %v", v, err)
+	}
+	if s := stats(); s.InFlight != 0 || s.Queued != 0 {
+		t.Fatalf("unexpected stats %v", s)
+	}
+	p.Close()
+	mock.Close()
+	for atomic.LoadInt32(&p.state) != 4 {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if atomic.LoadInt64(&read) == 0 || atomic.LoadInt64(&written) == 0 {
+		t.Fatalf("bytes are not reported %v %v", read, written)
+	}
+	if atomic.LoadInt64(&flushed) == 0 {
+		t.Fatalf("flush is not reported")
+	}
+	if atomic.LoadInt32(&closed) != 1 {
+		t.Fatalf("OnPipeOpen closer is not called once %v", closed)
+	}
+}
+
+func TestMetricsHooksMeter(t *testing.T) {
+	n1, n2 := net.Pipe()
+	defer n1.Close()
+	defer n2.Close()
+	if c := meter(n1, "", &MetricsHooks{}); c != n1 {
+		t.Fatalf("conn should not be wrapped without hooks")
+	}
+	if c := meter(n1, "", &MetricsHooks{OnFlush: func(string, int) {}}); c == n1 {
+		t.Fatalf("conn should be wrapped with hooks")
+	}
+}
+
+func TestMetricsHooksPool(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var (
+		mu     sync.Mutex
+		waits  []time.Duration
+		closed int32
+		stats  func() PoolStats
+	)
+	hooks := &MetricsHooks{
+		OnPoolOpen: func(addr string, s func() PoolStats) func() {
+			stats = s
+			return func() { atomic.AddInt32(&closed, 1) }
+		},
+		OnPoolWait: func(addr string, wait time.Duration) {
+			mu.Lock()
+			waits = append(waits, wait)
+			mu.Unlock()
+		},
+	}
+	p := newPool(1, dead, 0, 0, func(_ context.Context) wire { return &mockWire{} })
+	p.meter("node:6379", hooks)
+
+	w := p.Acquire(context.Background())
+	if s := stats(); s.Size != 1 || s.Idle != 0 {
+		t.Fatalf("unexpected stats %v", s)
+	}
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		p.Store(w)
+	}()
+	w = p.Acquire(context.Background())
+	p.Store(w)
+	if s := stats(); s.Size != 1 || s.Idle != 1 {
+		t.Fatalf("unexpected stats %v", s)
+	}
+	mu.Lock()
+	if len(waits) != 1 || waits[0] < time.Millisecond*10 {
+		t.Fatalf("unexpected waits %v", waits)
+	}
+	mu.Unlock()
+	p.Close()
+	p.Close()
+	if atomic.LoadInt32(&closed) != 1 {
+		t.Fatalf("OnPoolOpen closer is not called once %v", closed)
+	}
+}
+
+func TestMetricsHooksRedirect(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var count int64
+	var redirects []string
+	client, err := newClusterClient(
+		&ClientOption{InitAddress: []string{":0"}, MetricsHooks: MetricsHooks{
+			OnRedirect: func(addr string, target string, mode RedirectMode) {
+				redirects = append(redirects, addr+">"+target)
+			},
+		}},
+		func(dst string, opt *ClientOption) conn {
+			return &mockConn{AddrFn: func() string { return dst }, DoFn: func(cmd Completed) ValkeyResult {
+				if strings.Join(cmd.Commands(), " ") == "CLUSTER SLOTS" {
+					return slotsMultiResp
+				}
+				if atomic.AddInt64(&count, 1) <= 1 {
+					return newResult(strmsg('-', "MOVED 0 :2"), nil)
+				}
+				return newResult(strmsg('+', "b"), nil)
+			}}
+		},
+		newRetryer(defaultRetryDelayFn),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	defer client.Close()
+	if v, err := client.Do(context.Background(), client.B().Get().Key("a").Build()).ToString(); err != nil || v != "b" {
+		t.Fatalf("unexpected resp %v %v", v, err)
+	}
+	if len(redirects) != 1 || !strings.HasSuffix(redirects[0], ">:2") {
+		t.Fatalf("unexpected redirects %v", redirects)
+	}
+}
@@ -25,6 +25,9 @@ func WithSlotCheck() ClientOption {
 		if cc, ok := c.(*DedicatedClient); ok {
 			cc.slot = cmds.InitSlot
 		}
+		if cc, ok := c.(*ReplayClient); ok {
+			cc.slot = cmds.InitSlot
+		}
 	}
 }
 
@@ -0,0 +1,607 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+var _ valkey.Client = (*RecordClient)(nil)
+var _ valkey.Client = (*ReplayClient)(nil)
+
+// ErrNotRecorded is returned by the ReplayClient when there is no recorded reply for a command.
+var ErrNotRecorded = errors.New("mock: valkey command is not recorded")
+
+// WithStrictOrder makes the ReplayClient serve replies only if commands are sent in the same order as they were recorded.
+func WithStrictOrder() ClientOption {
+	return func(c any) {
+		if cc, ok := c.(*ReplayClient); ok {
+			cc.r.strict = true
+		}
+	}
+}
+
+// record is an entry of a golden file.
+type record struct {
+	Reply   *reply   `json:"reply,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Command []string `json:"command"`
+}
+
+// reply is the type-preserving form of a valkey.ValkeyMessage in a golden file.
+// Strings that are not valid UTF-8 are kept in the Bytes, which is base64 encoded by encoding/json.
+type reply struct {
+	Type    string  `json:"type"`
+	String  string  `json:"string,omitempty"`
+	Bytes   []byte  `json:"bytes,omitempty"`
+	Values  []reply `json:"values,omitempty"`
+	Integer int64   `json:"integer,omitempty"`
+}
+
+func (g *reply) string() string {
+	if g.Bytes != nil {
+		return string(g.Bytes)
+	}
+	return g.String
+}
+
+func encode(v valkey.ValkeyMessage) reply {
+	m := *(*message)(unsafe.Pointer(&v))
+	g := reply{Type: string(m.typ)}
+	switch m.typ {
+	case ':', '#':
+		g.Integer = m.integer
+	case '_':
+	case '*', '~', '%', '>':
+		values := m.values()
+		g.Values = make([]reply, len(values))
+		for i, value := range values {
+			g.Values[i] = encode(value)
+		}
+	default:
+		if s := m.string(); utf8.ValidString(s) {
+			g.String = s
+		} else {
+			g.Bytes = []byte(s)
+		}
+	}
+	return g
+}
+
+func decode(g reply) valkey.ValkeyMessage {
+	var typ byte
+	if len(g.Type) != 0 {
+		typ = g.Type[0]
+	}
+	values := make([]valkey.ValkeyMessage, len(g.Values))
+	for i, value := range g.Values {
+		values[i] = decode(value)
+	}
+	switch typ {
+	case '+':
+		return ValkeyString(g.string())
+	case '$':
+		return ValkeyBlobString(g.string())
+	case '-':
+		return ValkeyError(g.string())
+	case ':':
+		return ValkeyInt64(g.Integer)
+	case '#':
+		return ValkeyBool(g.Integer != 0)
+	case '_':
+		return ValkeyNil()
+	case '*':
+		return ValkeyArray(values...)
+	case '~', '%', '>':
+		m := slicemsg(typ, values)
+		return *(*valkey.ValkeyMessage)(unsafe.Pointer(&m))
+	default:
+		m := strmsg(typ, g.string())
+		return *(*valkey.ValkeyMessage)(unsafe.Pointer(&m))
+	}
+}
+
+func encodePubSub(msg valkey.PubSubMessage) reply {
+	return encode(ValkeyArray(ValkeyBlobString(msg.Pattern), ValkeyBlobString(msg.Channel), ValkeyBlobString(msg.Message)))
+}
+
+func decodePubSub(g reply) (msg valkey.PubSubMessage) {
+	if len(g.Values) == 3 {
+		msg.Pattern, msg.Channel, msg.Message = g.Values[0].string(), g.Values[1].string(), g.Values[2].string()
+	}
+	return msg
+}
+
+// knownErrors are errors whose identity is restored by the ReplayClient, so that they can still be checked with errors.Is.
+var knownErrors = []error{
+	context.Canceled,
+	context.DeadlineExceeded,
+	valkey.ErrClosing,
+	valkey.ErrDoCacheAborted,
+	valkey.ErrDedicatedClientRecycled,
+}
+
+func replayError(s string) error {
+	for _, err := range knownErrors {
+		if err.Error() == s {
+			return err
+		}
+	}
+	return errors.New(s)
+}
+
+// recording is shared by the RecordClient and the dedicated clients derived from it.
+type recording struct {
+	records []record
+	mu      sync.Mutex
+}
+
+// reserve appends the commands to the recording in the order they are sent and returns the index of the first one.
+func (r *recording) reserve(commands ...[]string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := len(r.records)
+	for _, cmd := range commands {
+		r.records = append(r.records, record{Command: append([]string(nil), cmd...)})
+	}
+	return i
+}
+
+func (r *recording) result(i int, resp valkey.ValkeyResult) {
+	rs := *(*result)(unsafe.Pointer(&resp))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rs.err != nil {
+		r.records[i].Error = rs.err.Error()
+	} else {
+		g := encode(rs.val)
+		r.records[i].Reply = &g
+	}
+}
+
+func (r *recording) message(i int, msg valkey.ValkeyMessage) {
+	g := encode(msg)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[i].Reply = &g
+}
+
+func (r *recording) error(i int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[i].Error = err.Error()
+}
+
+func (r *recording) pubsub(i int, msg *valkey.PubSubMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.records[i].Reply == nil {
+		r.records[i].Reply = &reply{Type: "*"}
+	}
+	if msg != nil {
+		r.records[i].Reply.Values = append(r.records[i].Reply.Values, encodePubSub(*msg))
+	}
+}
+
+func (r *recording) do(ctx context.Context, client valkey.CoreClient, cmd valkey.Completed) valkey.ValkeyResult {
+	i := r.reserve(cmd.Commands())
+	resp := client.Do(ctx, cmd)
+	r.result(i, resp)
+	return resp
+}
+
+func (r *recording) doMulti(ctx context.Context, client valkey.CoreClient, multi []valkey.Completed) []valkey.ValkeyResult {
+	commands := make([][]string, len(multi))
+	for j, cmd := range multi {
+		commands[j] = cmd.Commands()
+	}
+	i := r.reserve(commands...)
+	resps := client.DoMulti(ctx, multi...)
+	for j, resp := range resps {
+		r.result(i+j, resp)
+	}
+	return resps
+}
+
+func (r *recording) receive(ctx context.Context, client valkey.CoreClient, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	i := r.reserve(subscribe.Commands())
+	r.pubsub(i, nil)
+	err := client.Receive(ctx, subscribe, func(msg valkey.PubSubMessage) {
+		r.pubsub(i, &msg)
+		fn(msg)
+	})
+	if err != nil {
+		r.error(i, err)
+	}
+	return err
+}
+
+// drain reads all the replies of the stream and records them as blob strings, because a golden file can't hold a live stream.
+func (r *recording) drain(i, n int, s valkey.ValkeyResultStream) valkey.ValkeyResultStream {
+	msgs := make([]valkey.ValkeyMessage, 0, n)
+	var failed error
+	for j := 0; j < n; j++ {
+		buf := bytes.NewBuffer(nil)
+		_, err := s.WriteTo(buf)
+		if ve, ok := valkey.IsValkeyErr(err); ok {
+			msgs = append(msgs, valkey.ValkeyMessage(*ve))
+		} else if valkey.IsValkeyNil(err) {
+			msgs = append(msgs, ValkeyNil())
+		} else if err != nil {
+			r.error(i+j, err)
+			if failed == nil {
+				failed = err
+			}
+			continue
+		} else {
+			msgs = append(msgs, ValkeyBlobString(buf.String()))
+		}
+		r.message(i+j, msgs[len(msgs)-1])
+	}
+	if failed != nil {
+		return ValkeyResultStreamError(failed)
+	}
+	return ValkeyResultStream(msgs...)
+}
+
+// NewRecordClient wraps the client and records every command sent through it together with its reply.
+// The recorded commands can be saved into a golden file by Save and later served by the ReplayClient.
+// Replies of DoStream and DoMultiStream are read completely before being returned.
+func NewRecordClient(client valkey.Client) *RecordClient {
+	return &RecordClient{client: client, r: &recording{}}
+}
+
+// RecordClient is a valkey.Client that records commands and replies of the wrapped client.
+type RecordClient struct {
+	client valkey.Client
+	r      *recording
+}
+
+// Save writes all the recorded commands and replies into the golden file at the path.
+// Missing parent directories of the path are created.
+func (c *RecordClient) Save(path string) error {
+	c.r.mu.Lock()
+	b, err := json.MarshalIndent(c.r.records, "", "  ")
+	c.r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+func (c *RecordClient) B() valkey.Builder {
+	return c.client.B()
+}
+
+func (c *RecordClient) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return c.r.do(ctx, c.client, cmd)
+}
+
+func (c *RecordClient) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return c.r.doMulti(ctx, c.client, multi)
+}
+
+func (c *RecordClient) DoCache(ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) valkey.ValkeyResult {
+	i := c.r.reserve(cmd.Commands())
+	resp := c.client.DoCache(ctx, cmd, ttl)
+	c.r.result(i, resp)
+	return resp
+}
+
+func (c *RecordClient) DoMultiCache(ctx context.Context, multi ...valkey.CacheableTTL) []valkey.ValkeyResult {
+	commands := make([][]string, len(multi))
+	for j, ct := range multi {
+		commands[j] = ct.Cmd.Commands()
+	}
+	i := c.r.reserve(commands...)
+	resps := c.client.DoMultiCache(ctx, multi...)
+	for j, resp := range resps {
+		c.r.result(i+j, resp)
+	}
+	return resps
+}
+
+func (c *RecordClient) DoStream(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	i := c.r.reserve(cmd.Commands())
+	return c.r.drain(i, 1, c.client.DoStream(ctx, cmd))
+}
+
+func (c *RecordClient) DoMultiStream(ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	commands := make([][]string, len(multi))
+	for j, cmd := range multi {
+		commands[j] = cmd.Commands()
+	}
+	i := c.r.reserve(commands...)
+	return c.r.drain(i, len(multi), c.client.DoMultiStream(ctx, multi...))
+}
+
+func (c *RecordClient) Receive(ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	return c.r.receive(ctx, c.client, subscribe, fn)
+}
+
+func (c *RecordClient) Dedicated(fn func(valkey.DedicatedClient) error) error {
+	return c.client.Dedicated(func(client valkey.DedicatedClient) error {
+		return fn(&recordDedicated{client: client, r: c.r})
+	})
+}
+
+func (c *RecordClient) Dedicate() (valkey.DedicatedClient, func()) {
+	client, cancel := c.client.Dedicate()
+	return &recordDedicated{client: client, r: c.r}, cancel
+}
+
+// Nodes returns the nodes of the wrapped client, which record into the same golden file.
+func (c *RecordClient) Nodes() map[string]valkey.Client {
+	nodes := c.client.Nodes()
+	recorded := make(map[string]valkey.Client, len(nodes))
+	for addr, client := range nodes {
+		recorded[addr] = &RecordClient{client: client, r: c.r}
+	}
+	return recorded
+}
+
+func (c *RecordClient) Mode() valkey.ClientMode {
+	return c.client.Mode()
+}
+
+// Close closes the wrapped client. It doesn't save the recorded commands.
+func (c *RecordClient) Close() {
+	c.client.Close()
+}
+
+type recordDedicated struct {
+	client valkey.DedicatedClient
+	r      *recording
+}
+
+func (d *recordDedicated) B() valkey.Builder {
+	return d.client.B()
+}
+
+func (d *recordDedicated) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return d.r.do(ctx, d.client, cmd)
+}
+
+func (d *recordDedicated) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return d.r.doMulti(ctx, d.client, multi)
+}
+
+func (d *recordDedicated) Receive(ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	return d.r.receive(ctx, d.client, subscribe, fn)
+}
+
+func (d *recordDedicated) SetPubSubHooks(hooks valkey.PubSubHooks) <-chan error {
+	return d.client.SetPubSubHooks(hooks)
+}
+
+func (d *recordDedicated) Close() {
+	d.client.Close()
+}
+
+// replaying is shared by the ReplayClient and the dedicated clients derived from it.
+type replaying struct {
+	records []record
+	used    []bool
+	next    int
+	strict  bool
+	mu      sync.Mutex
+}
+
+// find consumes the record matching the cmd with the Match matcher.
+// The first unused matching record is consumed unless the strict is set, which only allows the next record to be consumed.
+func (r *replaying) find(cmd any) (record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.strict {
+		if r.next == len(r.records) {
+			return record{}, fmt.Errorf("%w: %v, all recorded commands are replayed", ErrNotRecorded, commands(cmd))
+		}
+		if rec := r.records[r.next]; !Match(rec.Command...).Matches(cmd) {
+			return record{}, fmt.Errorf("%w: %v, expected %v at index %d", ErrNotRecorded, commands(cmd), rec.Command, r.next)
+		}
+		r.used[r.next] = true
+		r.next++
+		return r.records[r.next-1], nil
+	}
+	for i, rec := range r.records {
+		if !r.used[i] && Match(rec.Command...).Matches(cmd) {
+			r.used[i] = true
+			return rec, nil
+		}
+	}
+	return record{}, fmt.Errorf("%w: %v", ErrNotRecorded, commands(cmd))
+}
+
+func (r *replaying) result(cmd any) valkey.ValkeyResult {
+	rec, err := r.find(cmd)
+	if err != nil {
+		return ErrorResult(err)
+	}
+	if rec.Error != "" {
+		return ErrorResult(replayError(rec.Error))
+	}
+	if rec.Reply == nil {
+		return ErrorResult(fmt.Errorf("%w: %v has no recorded reply", ErrNotRecorded, rec.Command))
+	}
+	return Result(decode(*rec.Reply))
+}
+
+func (r *replaying) doMulti(multi []valkey.Completed) []valkey.ValkeyResult {
+	resps := make([]valkey.ValkeyResult, len(multi))
+	for i, cmd := range multi {
+		resps[i] = r.result(cmd)
+	}
+	return resps
+}
+
+func (r *replaying) stream(multi []valkey.Completed) valkey.ValkeyResultStream {
+	msgs := make([]valkey.ValkeyMessage, len(multi))
+	for i, cmd := range multi {
+		var err error
+		if msgs[i], err = r.result(cmd).ToMessage(); err != nil {
+			if ve, ok := valkey.IsValkeyErr(err); ok {
+				msgs[i] = valkey.ValkeyMessage(*ve)
+			} else if valkey.IsValkeyNil(err) {
+				msgs[i] = ValkeyNil()
+			} else {
+				return ValkeyResultStreamError(err)
+			}
+		}
+	}
+	return ValkeyResultStream(msgs...)
+}
+
+func (r *replaying) receive(subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	rec, err := r.find(subscribe)
+	if err != nil {
+		return err
+	}
+	if rec.Reply != nil {
+		for _, g := range rec.Reply.Values {
+			fn(decodePubSub(g))
+		}
+	}
+	if rec.Error != "" {
+		return replayError(rec.Error)
+	}
+	return nil
+}
+
+// NewReplayClient creates a ReplayClient serving the replies recorded in the golden file at the path.
+// Each recorded reply is served once. Use WithStrictOrder to also require commands to be sent in the recorded order.
+func NewReplayClient(path string, options ...ClientOption) (*ReplayClient, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &replaying{}
+	if err = json.Unmarshal(b, &r.records); err != nil {
+		return nil, fmt.Errorf("mock: invalid golden file %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.records))
+	c := &ReplayClient{r: r, slot: cmds.NoSlot}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ReplayClient is a valkey.Client that serves replies from a golden file recorded by the RecordClient.
+// A command without a recorded reply gets an error wrapping the ErrNotRecorded.
+type ReplayClient struct {
+	r    *replaying
+	slot uint16
+}
+
+// Pending returns the recorded commands that have not been replayed yet.
+func (c *ReplayClient) Pending() [][]string {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	var pending [][]string
+	for i, rec := range c.r.records {
+		if !c.r.used[i] {
+			pending = append(pending, rec.Command)
+		}
+	}
+	return pending
+}
+
+func (c *ReplayClient) B() valkey.Builder {
+	return cmds.NewBuilder(c.slot)
+}
+
+func (c *ReplayClient) Do(_ context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return c.r.result(cmd)
+}
+
+func (c *ReplayClient) DoMulti(_ context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return c.r.doMulti(multi)
+}
+
+func (c *ReplayClient) DoCache(_ context.Context, cmd valkey.Cacheable, _ time.Duration) valkey.ValkeyResult {
+	return c.r.result(cmd)
+}
+
+func (c *ReplayClient) DoMultiCache(_ context.Context, multi ...valkey.CacheableTTL) []valkey.ValkeyResult {
+	resps := make([]valkey.ValkeyResult, len(multi))
+	for i, ct := range multi {
+		resps[i] = c.r.result(ct)
+	}
+	return resps
+}
+
+func (c *ReplayClient) DoStream(_ context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	return c.r.stream([]valkey.Completed{cmd})
+}
+
+func (c *ReplayClient) DoMultiStream(_ context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	return c.r.stream(multi)
+}
+
+func (c *ReplayClient) Receive(_ context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	return c.r.receive(subscribe, fn)
+}
+
+func (c *ReplayClient) Dedicated(fn func(valkey.DedicatedClient) error) error {
+	return fn(&replayDedicated{r: c.r, slot: c.slot})
+}
+
+func (c *ReplayClient) Dedicate() (valkey.DedicatedClient, func()) {
+	return &replayDedicated{r: c.r, slot: c.slot}, func() {}
+}
+
+// Nodes returns the ReplayClient itself because the golden file doesn't distinguish nodes.
+func (c *ReplayClient) Nodes() map[string]valkey.Client {
+	return map[string]valkey.Client{"": c}
+}
+
+func (c *ReplayClient) Mode() valkey.ClientMode {
+	return valkey.ClientModeStandalone
+}
+
+func (c *ReplayClient) Close() {}
+
+type replayDedicated struct {
+	r    *replaying
+	slot uint16
+}
+
+func (d *replayDedicated) B() valkey.Builder {
+	return cmds.NewBuilder(d.slot)
+}
+
+func (d *replayDedicated) Do(_ context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return d.r.result(cmd)
+}
+
+func (d *replayDedicated) DoMulti(_ context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return d.r.doMulti(multi)
+}
+
+func (d *replayDedicated) Receive(_ context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) error {
+	return d.r.receive(subscribe, fn)
+}
+
+// SetPubSubHooks returns a channel that is never closed because pub/sub messages are only replayed by Receive.
+func (d *replayDedicated) SetPubSubHooks(hooks valkey.PubSubHooks) <-chan error {
+	if hooks.OnMessage == nil && hooks.OnSubscription == nil {
+		return nil
+	}
+	return make(chan error)
+}
+
+func (d *replayDedicated) Close() {}
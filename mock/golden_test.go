@@ -0,0 +1,192 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"go.uber.org/mock/gomock"
+)
+
+func recordGolden(t *testing.T, path string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	client := NewClient(ctrl)
+	client.EXPECT().Do(ctx, Match("GET", "a")).Return(Result(ValkeyString("1")))
+	client.EXPECT().Do(ctx, Match("GET", "a")).Return(Result(ValkeyBlobString("\xff\x00")))
+	client.EXPECT().DoCache(ctx, Match("HGETALL", "h"), time.Second).Return(Result(ValkeyMap(map[string]valkey.ValkeyMessage{"f": ValkeyInt64(1)})))
+	client.EXPECT().DoMulti(ctx, Match("GET", "n"), Match("INCR", "e")).Return([]valkey.ValkeyResult{
+		Result(ValkeyNil()),
+		Result(ValkeyError("ERR not an integer")),
+	})
+	client.EXPECT().Do(ctx, Match("GET", "t")).Return(ErrorResult(context.DeadlineExceeded))
+	client.EXPECT().DoMultiCache(ctx, Match("GET", "c")).Return([]valkey.ValkeyResult{Result(ValkeyArray(ValkeyBool(true), ValkeyFloat64(1.5)))})
+	client.EXPECT().DoStream(ctx, Match("GET", "s")).Return(ValkeyResultStream(ValkeyBlobString("stream")))
+	client.EXPECT().DoMultiStream(ctx, Match("GET", "s1"), Match("GET", "s2")).Return(MultiValkeyResultStream(ValkeyBlobString("s1"), ValkeyError("ERR s2")))
+	client.EXPECT().Receive(ctx, Match("SUBSCRIBE", "ch"), gomock.Any()).DoAndReturn(func(_ context.Context, _ valkey.Completed, fn func(valkey.PubSubMessage)) error {
+		fn(valkey.PubSubMessage{Channel: "ch", Message: "m1"})
+		fn(valkey.PubSubMessage{Channel: "ch", Message: "m2"})
+		return errors.New("closed")
+	})
+	dedicated := NewDedicatedClient(ctrl)
+	client.EXPECT().Dedicated(gomock.Any()).DoAndReturn(func(fn func(valkey.DedicatedClient) error) error {
+		return fn(dedicated)
+	})
+	dedicated.EXPECT().Do(ctx, Match("WATCH", "w")).Return(Result(ValkeyString("OK")))
+	client.EXPECT().Close()
+
+	rc := NewRecordClient(client)
+	if v, err := rc.Do(ctx, rc.B().Get().Key("a").Build()).ToString(); err != nil || v != "1" {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	if v, err := rc.Do(ctx, rc.B().Get().Key("a").Build()).ToString(); err != nil || v != "\xff\x00" {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	rc.DoCache(ctx, rc.B().Hgetall().Key("h").Cache(), time.Second)
+	rc.DoMulti(ctx, rc.B().Get().Key("n").Build(), rc.B().Incr().Key("e").Build())
+	rc.Do(ctx, rc.B().Get().Key("t").Build())
+	rc.DoMultiCache(ctx, valkey.CT(rc.B().Get().Key("c").Cache(), time.Second))
+	s := rc.DoStream(ctx, rc.B().Get().Key("s").Build())
+	buf := bytes.NewBuffer(nil)
+	if _, err := s.WriteTo(buf); err != nil || buf.String() != "stream" {
+		t.Fatalf("unexpected stream %v %v", buf.String(), err)
+	}
+	rc.DoMultiStream(ctx, rc.B().Get().Key("s1").Build(), rc.B().Get().Key("s2").Build())
+	var msgs []valkey.PubSubMessage
+	if err := rc.Receive(ctx, rc.B().Subscribe().Channel("ch").Build(), func(msg valkey.PubSubMessage) {
+		msgs = append(msgs, msg)
+	}); err == nil || err.Error() != "closed" || len(msgs) != 2 {
+		t.Fatalf("unexpected receive %v %v", msgs, err)
+	}
+	if err := rc.Dedicated(func(c valkey.DedicatedClient) error {
+		return c.Do(ctx, c.B().Watch().Key("w").Build()).Error()
+	}); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	rc.Close()
+	if err := rc.Save(path); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "testdata", "golden.json")
+	recordGolden(t, path)
+
+	client, err := NewReplayClient(path)
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if v, err := client.Do(ctx, client.B().Get().Key("a").Build()).ToString(); err != nil || v != "1" {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	if v, err := client.Do(ctx, client.B().Get().Key("a").Build()).ToString(); err != nil || v != "\xff\x00" {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	if err := client.Do(ctx, client.B().Get().Key("a").Build()).Error(); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if v, err := client.Do(ctx, client.B().Get().Key("t").Build()).ToString(); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	if v, err := client.DoCache(ctx, client.B().Hgetall().Key("h").Cache(), time.Second).AsIntMap(); err != nil || !reflect.DeepEqual(v, map[string]int64{"f": 1}) {
+		t.Fatalf("unexpected val %v %v", v, err)
+	}
+	resps := client.DoMulti(ctx, client.B().Get().Key("n").Build(), client.B().Incr().Key("e").Build())
+	if err := resps[0].Error(); !valkey.IsValkeyNil(err) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if ret, ok := valkey.IsValkeyErr(resps[1].Error()); !ok || ret.Error() != "not an integer" {
+		t.Fatalf("unexpected err %v", resps[1].Error())
+	}
+	resps = client.DoMultiCache(ctx, valkey.CT(client.B().Get().Key("c").Cache(), time.Second))
+	if v, err := resps[0].ToArray(); err != nil || len(v) != 2 {
+		t.Fatalf("unexpected val %v %v", v, err)
+	} else if b, _ := v[0].AsBool(); !b {
+		t.Fatalf("unexpected val %v", v[0])
+	} else if f, _ := v[1].AsFloat64(); f != 1.5 {
+		t.Fatalf("unexpected val %v", v[1])
+	}
+	s := client.DoStream(ctx, client.B().Get().Key("s").Build())
+	buf := bytes.NewBuffer(nil)
+	if _, err := s.WriteTo(buf); err != nil || buf.String() != "stream" {
+		t.Fatalf("unexpected stream %v %v", buf.String(), err)
+	}
+	s = client.DoMultiStream(ctx, client.B().Get().Key("s1").Build(), client.B().Get().Key("s2").Build())
+	buf.Reset()
+	if _, err := s.WriteTo(buf); err != nil || buf.String() != "s1" {
+		t.Fatalf("unexpected stream %v %v", buf.String(), err)
+	}
+	if _, err := s.WriteTo(buf); err == nil || err.Error() != "s2" {
+		t.Fatalf("unexpected err %v", err)
+	}
+	var msgs []valkey.PubSubMessage
+	if err := client.Receive(ctx, client.B().Subscribe().Channel("ch").Build(), func(msg valkey.PubSubMessage) {
+		msgs = append(msgs, msg)
+	}); err == nil || err.Error() != "closed" {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if !reflect.DeepEqual(msgs, []valkey.PubSubMessage{{Channel: "ch", Message: "m1"}, {Channel: "ch", Message: "m2"}}) {
+		t.Fatalf("unexpected msgs %v", msgs)
+	}
+	if pending := client.Pending(); !reflect.DeepEqual(pending, [][]string{{"WATCH", "w"}}) {
+		t.Fatalf("unexpected pending %v", pending)
+	}
+	if err := client.Dedicated(func(c valkey.DedicatedClient) error {
+		return c.Do(ctx, c.B().Watch().Key("w").Build()).Error()
+	}); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if pending := client.Pending(); len(pending) != 0 {
+		t.Fatalf("unexpected pending %v", pending)
+	}
+}
+
+func TestReplayStrictOrder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "golden.json")
+	recordGolden(t, path)
+
+	client, err := NewReplayClient(path, WithStrictOrder())
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := client.Do(ctx, client.B().Get().Key("t").Build()).Error(); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("unexpected err %v", err)
+	} else if err.Error() != "mock: valkey command is not recorded: [GET t], expected [GET a] at index 0" {
+		t.Fatalf("unexpected err %v", err)
+	}
+	for _, v := range []string{"1", "\xff\x00"} {
+		if s, err := client.Do(ctx, client.B().Get().Key("a").Build()).ToString(); err != nil || s != v {
+			t.Fatalf("unexpected val %v %v", s, err)
+		}
+	}
+	if err := client.Do(ctx, client.B().Get().Key("t").Build()).Error(); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if len(client.Pending()) != 10 {
+		t.Fatalf("unexpected pending %v", client.Pending())
+	}
+}
+
+func TestReplayInvalidGolden(t *testing.T) {
+	if _, err := NewReplayClient(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if _, err := NewReplayClient(path); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+}
@@ -174,6 +174,9 @@ type pool struct {
 	cond    *sync.Cond
 	timer   *time.Timer
 	make    func() any
+	hooks   *any
+	unmeter func()
+	addr    string
 	list    []any
 	cleanup time.Duration
 	size    int
@@ -189,6 +192,7 @@ type pipe struct {
 	queue           any
 	cache           any
 	pshks           atomic.Pointer[pshks] // pubsub hook, registered by the SetPubSubHooks
+	unmeter         atomic.Pointer[func()]
 	error           atomic.Pointer[errs]
 	r               *bufio.Reader
 	w               *bufio.Writer
@@ -198,6 +202,7 @@ type pipe struct {
 	nsubs           *any // pubsub  message subscriptions
 	psubs           *any // pubsub pmessage subscriptions
 	r2p             *any
+	mtrc            *any
 	pingTimer       *time.Timer // timer for background ping
 	lftmTimer       *time.Timer // lifetime timer
 	info            map[string]valkey.ValkeyMessage
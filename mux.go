@@ -72,7 +72,11 @@ type mux struct {
 func makeMux(dst string, option *ClientOption, dialFn dialFn) *mux {
 	dead := deadFn()
 	connFn := func(ctx context.Context) (net.Conn, error) {
-		return dialFn(ctx, dst, option)
+		conn, err := dialFn(ctx, dst, option)
+		if err != nil {
+			return nil, err
+		}
+		return meter(conn, dst, &option.MetricsHooks), nil
 	}
 	wireFn := func(pipeFn pipeFn) func(context.Context) wire {
 		return func(ctx context.Context) (w wire) {
@@ -109,6 +113,9 @@ func newMux(dst string, option *ClientOption, init, dead wire, wireFn wireFn, wi
 
 	m.dpool = newPool(option.BlockingPoolSize, dead, option.BlockingPoolCleanup, option.BlockingPoolMinSize, wireFn)
 	m.spool = newPool(option.BlockingPoolSize, dead, option.BlockingPoolCleanup, option.BlockingPoolMinSize, wireNoBgFn)
+	if option.MetricsHooks.enabled() {
+		m.dpool.meter(dst, &option.MetricsHooks)
+	}
 	return m
 }
 
@@ -0,0 +1,51 @@
+package om
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrChangeStreamCrossSlot indicates that the stream of WithChangeStream doesn't have the same hash tag as the prefix of the repository in a valkey cluster.
+var ErrChangeStreamCrossSlot = errors.New("the stream of WithChangeStream requires the same hash tag as the prefix, such as {prefix}:changes, in a valkey cluster")
+
+// WithChangeStream makes every Save, SaveMulti and Remove of the repository also XADD a change event to the stream atomically
+// in the same Lua script. Each event has these fields:
+//   - id: the id of the entity.
+//   - ver: the version of the entity after the save, or before the remove and the migrate.
+//   - op: one of save, remove, softremove and migrate, where migrate is added by the Migrate of MigrateRepository.
+//   - fields: a JSON array of the names of the changed fields, which is empty for remove and softremove.
+//
+// The stream is trimmed by `MAXLEN ~ maxLen` if maxLen is positive.
+// In a valkey cluster, the stream must be in the same slot as all the entities, such as `{prefix}:changes` with a `{prefix}` prefix.
+// Otherwise, the writes of the repository return ErrChangeStreamCrossSlot.
+func WithChangeStream(stream string, maxLen int64) RepositoryOption {
+	return func(r Repository[any]) {
+		switch repo := r.(type) {
+		case *HashRepository[any]:
+			repo.stream, repo.maxLen = stream, maxLen
+		case *JSONRepository[any]:
+			repo.stream, repo.maxLen = stream, maxLen
+		}
+	}
+}
+
+// changeArgs generates the change section of the save and remove scripts, which presents only if the stream is the last one of KEYS.
+func changeArgs(id, ver string, maxLen int64) []string {
+	return []string{strconv.FormatInt(maxLen, 10), id, ver}
+}
+
+// changeScript is shared by the save and remove scripts to XADD a change event to the stream, which is the last one of KEYS.
+// The change section of ARGV starts at i with the MAXLEN, the record id and the ver field name. It does nothing if the section is absent.
+const changeScript = `
+local function change(i, op, ver, fields)
+  if not ARGV[i] then return end
+  local args = {'XADD',KEYS[#KEYS]}
+  if tonumber(ARGV[i]) > 0 then
+    table.insert(args,'MAXLEN') table.insert(args,'~') table.insert(args,ARGV[i])
+  end
+  local f = '[]'
+  if #fields > 0 then f = cjson.encode(fields) end
+  for _, v in ipairs({'*','id',ARGV[i+1],'ver',ver,'op',op,'fields',f}) do table.insert(args,v) end
+  redis.call(unpack(args))
+end
+`
@@ -0,0 +1,68 @@
+package om
+
+import (
+	"context"
+	"testing"
+)
+
+type ChangeStruct struct {
+	Key  string `json:"key" valkey:",key"`
+	Ver  int64  `json:"ver" valkey:",ver"`
+	Name string `json:"name"`
+	Age  int64  `json:"age"`
+}
+
+func TestWithChangeStream(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, c := range []struct {
+		repo   Repository[ChangeStruct]
+		stream string
+	}{
+		{repo: NewHashRepository("{hashchange}", ChangeStruct{}, client, WithChangeStream("{hashchange}:changes", 100)), stream: "{hashchange}:changes"},
+		{repo: NewJSONRepository("{jsonchange}", ChangeStruct{}, client, WithChangeStream("{jsonchange}:changes", 0)), stream: "{jsonchange}:changes"},
+	} {
+		e := c.repo.NewEntity()
+		e.Name = "a"
+		if err := c.repo.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		e.Age = 1
+		if err := c.repo.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		e.Ver = 0
+		if err := c.repo.Save(ctx, e); err != ErrVersionMismatch {
+			t.Fatalf("unexpected err %v", err)
+		}
+		if err := c.repo.Remove(ctx, e.Key); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.repo.Remove(ctx, e.Key); err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := client.Do(ctx, client.B().Xrange().Key(c.stream).Start("-").End("+").Build()).AsXRange()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("unexpected entries %v", entries)
+		}
+		for i, want := range []map[string]string{
+			{"id": e.Key, "ver": "1", "op": "save", "fields": `["age","key","name"]`},
+			{"id": e.Key, "ver": "2", "op": "save", "fields": `["age"]`},
+			{"id": e.Key, "ver": "2", "op": "remove", "fields": `[]`},
+		} {
+			for k, v := range want {
+				if entries[i].FieldValues[k] != v {
+					t.Fatalf("unexpected entry %d %v", i, entries[i].FieldValues)
+				}
+			}
+		}
+	}
+}
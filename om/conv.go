@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/valkey-io/valkey-go"
@@ -13,18 +15,7 @@ import (
 func newHashConvFactory(t reflect.Type, schema schema) *hashConvFactory {
 	factory := &hashConvFactory{fields: make(map[string]fieldConv, len(schema.fields))}
 	for name, f := range schema.fields {
-		conv, ok := converters.val[f.typ.Kind()]
-		switch f.typ.Kind() {
-		case reflect.Ptr:
-			conv, ok = converters.ptr[f.typ.Elem().Kind()]
-		case reflect.Slice:
-			conv, ok = converters.slice[f.typ.Elem().Kind()]
-		}
-		if !ok {
-			k := f.typ.Kind()
-			panic(fmt.Sprintf("schema %q should not contain unsupported field type %s.", t, k))
-		}
-		factory.fields[name] = fieldConv{conv: conv, idx: f.idx}
+		factory.add(t, name, []int{f.idx}, f)
 	}
 	return factory
 }
@@ -35,7 +26,51 @@ type hashConvFactory struct {
 
 type fieldConv struct {
 	conv converter
-	idx  int
+	path []int
+}
+
+func (f *hashConvFactory) add(t reflect.Type, name string, path []int, field *field) {
+	var conv converter
+	var ok bool
+	switch field.enc {
+	case encFlat:
+		st := field.typ
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		for i := 0; i < st.NumField(); i++ {
+			sf := st.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			sub := parse(sf)
+			if sub.name == ignoreField {
+				continue
+			}
+			if err := sub.validateEnc(); err != nil {
+				panic(fmt.Sprintf("field %s in schema %q has an invalid `valkey:\",enc\"` tag: %v", sf.Name, t, err))
+			}
+			f.add(t, name+"."+sub.name, append(path[:len(path):len(path)], i), &sub)
+		}
+		return
+	case encJSON:
+		conv, ok = converter{}, true
+	case encDelim:
+		conv, ok = delimConverter(field.typ, field.sep), true
+	default:
+		conv, ok = converters.val[field.typ.Kind()]
+		switch field.typ.Kind() {
+		case reflect.Ptr:
+			conv, ok = converters.ptr[field.typ.Elem().Kind()]
+		case reflect.Slice:
+			conv, ok = converters.slice[field.typ.Elem().Kind()]
+		}
+	}
+	if !ok {
+		k := field.typ.Kind()
+		panic(fmt.Sprintf("schema %q should not contain unsupported field type %s. Use `valkey:\",enc=json\"` to store it as a JSON string.", t, k))
+	}
+	f.fields[name] = fieldConv{conv: conv, path: path}
 }
 
 func (f hashConvFactory) NewConverter(entity reflect.Value) hashConv {
@@ -47,10 +82,32 @@ type hashConv struct {
 	entity  reflect.Value
 }
 
+// field walks the path of the fieldConv. The nil pointers to the nested structs are allocated only if alloc is true,
+// otherwise the walk stops and returns false.
+func (r hashConv) field(f fieldConv, alloc bool) (reflect.Value, bool) {
+	v := r.entity.Field(f.path[0])
+	for _, i := range f.path[1:] {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
 func (r hashConv) ToHash() (fields map[string]string) {
 	fields = make(map[string]string, len(r.factory.fields))
 	for k, f := range r.factory.fields {
-		ref := r.entity.Field(f.idx)
+		ref, ok := r.field(f, false)
+		if !ok {
+			continue
+		}
 		if f.conv.ValueToString == nil {
 			if bs, err := json.Marshal(ref.Interface()); err == nil {
 				fields[k] = valkey.BinaryString(bs)
@@ -68,8 +125,9 @@ func (r hashConv) FromHash(fields map[string]string) error {
 		if !ok {
 			continue
 		}
+		ref, _ := r.field(f, true)
 		if f.conv.StringToValue == nil {
-			if err := json.Unmarshal(unsafe.Slice(unsafe.StringData(v), len(v)), r.entity.Field(f.idx).Addr().Interface()); err != nil {
+			if err := json.Unmarshal(unsafe.Slice(unsafe.StringData(v), len(v)), ref.Addr().Interface()); err != nil {
 				return err
 			}
 		} else {
@@ -77,12 +135,116 @@ func (r hashConv) FromHash(fields map[string]string) error {
 			if err != nil {
 				return err
 			}
-			r.entity.Field(f.idx).Set(val)
+			ref.Set(val)
 		}
 	}
 	return nil
 }
 
+// delimConverter converts a slice of scalars into a string like `a,b`, or a map from string to scalars into a string like `k1=v1,k2=v2`.
+// The map entries are sorted by their keys. The elements should not contain the separator, and the map keys should not contain `=`.
+func delimConverter(typ reflect.Type, sep string) converter {
+	elem := typ.Elem()
+	if typ.Kind() == reflect.Map {
+		return converter{
+			ValueToString: func(value reflect.Value) (string, bool) {
+				keys := value.MapKeys()
+				sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+				vs := make([]string, len(keys))
+				for i, k := range keys {
+					vs[i] = k.String() + "=" + scalarToString(value.MapIndex(k))
+				}
+				return strings.Join(vs, sep), true
+			},
+			StringToValue: func(value string) (reflect.Value, error) {
+				m := reflect.MakeMap(typ)
+				if value == "" {
+					return m, nil
+				}
+				for _, kv := range strings.Split(value, sep) {
+					k, v, _ := strings.Cut(kv, "=")
+					e, err := stringToScalar(v, elem)
+					if err != nil {
+						return reflect.Value{}, err
+					}
+					m.SetMapIndex(reflect.ValueOf(k).Convert(typ.Key()), e)
+				}
+				return m, nil
+			},
+		}
+	}
+	return converter{
+		ValueToString: func(value reflect.Value) (string, bool) {
+			vs := make([]string, value.Len())
+			for i := range vs {
+				vs[i] = scalarToString(value.Index(i))
+			}
+			return strings.Join(vs, sep), true
+		},
+		StringToValue: func(value string) (reflect.Value, error) {
+			if value == "" {
+				return reflect.Zero(typ), nil
+			}
+			vs := strings.Split(value, sep)
+			s := reflect.MakeSlice(typ, len(vs), len(vs))
+			for i, v := range vs {
+				e, err := stringToScalar(v, elem)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				s.Index(i).Set(e)
+			}
+			return s, nil
+		},
+	}
+}
+
+func scalarToString(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			return "t"
+		}
+		return "f"
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10)
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+}
+
+func stringToScalar(s string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch k := t.Kind(); {
+	case k == reflect.String:
+		v.SetString(s)
+	case k == reflect.Bool:
+		v.SetBool(s == "t")
+	case v.CanInt():
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(n)
+	case v.CanUint():
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(n)
+	default:
+		n, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(n)
+	}
+	return v, nil
+}
+
 type converter struct {
 	ValueToString func(value reflect.Value) (string, bool)
 	StringToValue func(value string) (reflect.Value, error)
@@ -27,19 +27,27 @@ func NewHashRepository[T any](prefix string, schema T, client valkey.Client, opt
 	for _, opt := range opts {
 		opt((*HashRepository[any])(repo))
 	}
+	repo.err = checkSlots(client, repo.schema, repo.prefix, repo.stream)
 	return repo
 }
 
-var _ Repository[any] = (*HashRepository[any])(nil)
+var _ LookupRepository[any] = (*HashRepository[any])(nil)
+var _ MigrateRepository[any] = (*HashRepository[any])(nil)
 
 // HashRepository is an OM repository backed by valkey hash.
 type HashRepository[T any] struct {
-	schema  schema
-	typ     reflect.Type
-	client  valkey.Client
-	factory *hashConvFactory
-	prefix  string
-	idx     string
+	schema   schema
+	typ      reflect.Type
+	client   valkey.Client
+	factory  *hashConvFactory
+	prefix   string
+	idx      string
+	stream   string
+	err      error // the error of the checkSlots, returned by writes and lookups
+	upgrades []Upgrade
+	maxLen   int64
+	// softDelete enables SoftRemove and excludes the marked entities from Search and Aggregate, see WithSoftDelete.
+	softDelete bool
 }
 
 // NewEntity returns an empty entity and will have the `valkey:",key"` field be set with ULID automatically.
@@ -67,9 +75,43 @@ func (r *HashRepository[T]) FetchCache(ctx context.Context, id string, ttl time.
 	return v, err
 }
 
-func (r *HashRepository[T]) toExec(entity *T) (val reflect.Value, exec valkey.LuaExec) {
+// FetchMulti batches multiple HashRepository.Fetch at once
+func (r *HashRepository[T]) FetchMulti(ctx context.Context, ids ...string) ([]*T, []error) {
+	cmds := make(valkey.Commands, len(ids))
+	for i, id := range ids {
+		cmds[i] = r.client.B().Hgetall().Key(key(r.prefix, id)).Build()
+	}
+	return r.fromResps(r.client.DoMulti(ctx, cmds...))
+}
+
+// FetchMultiCache batches multiple HashRepository.FetchCache at once
+func (r *HashRepository[T]) FetchMultiCache(ctx context.Context, ttl time.Duration, ids ...string) ([]*T, []error) {
+	cmds := make([]valkey.CacheableTTL, len(ids))
+	for i, id := range ids {
+		cmds[i] = valkey.CT(r.client.B().Hgetall().Key(key(r.prefix, id)).Cache(), ttl)
+	}
+	return r.fromResps(r.client.DoMultiCache(ctx, cmds...))
+}
+
+func (r *HashRepository[T]) fromResps(resps []valkey.ValkeyResult) ([]*T, []error) {
+	s := make([]*T, len(resps))
+	errs := make([]error, len(resps))
+	for i, resp := range resps {
+		record, err := resp.AsStrMap()
+		if err == nil {
+			s[i], err = r.fromHash(record)
+		}
+		errs[i] = err
+	}
+	return s, errs
+}
+
+func (r *HashRepository[T]) toExec(entity *T, ttl time.Duration) (val reflect.Value, exec valkey.LuaExec) {
 	val = reflect.ValueOf(entity).Elem()
 	fields := r.factory.NewConverter(val).ToHash()
+	if len(r.upgrades) > 0 {
+		fields[schemaField] = strconv.Itoa(len(r.upgrades))
+	}
 	keyVal := fields[r.schema.key.name]
 	verVal := fields[r.schema.ver.name]
 	extVal := int64(0)
@@ -78,51 +120,68 @@ func (r *HashRepository[T]) toExec(entity *T) (val reflect.Value, exec valkey.Lu
 			extVal = ext.UnixMilli()
 		}
 	}
+	if ttl > 0 {
+		extVal = time.Now().Add(ttl).UnixMilli()
+	}
 	exec.Keys = []string{key(r.prefix, keyVal)}
+	exec.Args = make([]string, 4, len(fields)*2+4)
+	exec.Args[0], exec.Args[1] = r.schema.ver.name, verVal
 	if extVal != 0 {
-		exec.Args = make([]string, 0, len(fields)*2+1)
-	} else {
-		exec.Args = make([]string, 0, len(fields)*2)
+		exec.Args[2] = strconv.FormatInt(extVal, 10)
 	}
-	exec.Args = append(exec.Args, r.schema.ver.name, verVal) // keep the ver field be the first pair for the hashSaveScript
+	exec.Args[3] = strconv.Itoa(len(fields)*2 - 2)
 	delete(fields, r.schema.ver.name)
 	for k, v := range fields {
 		exec.Args = append(exec.Args, k, v)
 	}
-	if extVal != 0 {
-		exec.Args = append(exec.Args, strconv.FormatInt(extVal, 10))
+	exec.Keys = append(exec.Keys, lookupKeys(r.prefix, r.schema.lookups)...)
+	exec.Args = append(exec.Args, lookupArgs(keyVal, r.schema.lookups, val, true)...)
+	if r.stream != "" {
+		exec.Keys = append(exec.Keys, r.stream)
+		exec.Args = append(exec.Args, changeArgs(keyVal, r.schema.ver.name, r.maxLen)...)
 	}
 	return
 }
 
 // Save the entity under the valkey key of `{prefix}:{id}`.
 // It also uses the `valkey:",ver"` field and lua script to perform optimistic locking and prevent lost update.
+// It returns ErrRecordDeleted if the entity is marked as deleted by SoftRemove, which should be removed by Remove before saving it again.
 func (r *HashRepository[T]) Save(ctx context.Context, entity *T) (err error) {
-	val, exec := r.toExec(entity)
-	str, err := hashSaveScript.Exec(ctx, r.client, exec.Keys, exec.Args).ToString()
-	if valkey.IsValkeyNil(err) {
-		return ErrVersionMismatch
+	return r.SaveWithTTL(ctx, entity, 0)
+}
+
+// SaveWithTTL is like Save, but the entity expires after the ttl, which overrides the `valkey:",exat"` field.
+// A non-positive ttl means no override.
+func (r *HashRepository[T]) SaveWithTTL(ctx context.Context, entity *T, ttl time.Duration) (err error) {
+	if r.err != nil {
+		return r.err
 	}
+	val, exec := r.toExec(entity, ttl)
+	str, err := hashSaveScript.Exec(ctx, r.client, exec.Keys, exec.Args).ToString()
 	if err == nil {
 		ver, _ := strconv.ParseInt(str, 10, 64)
 		val.Field(r.schema.ver.idx).SetInt(ver)
 	}
-	return err
+	return saveErr(err)
 }
 
 // SaveMulti batches multiple HashRepository.Save at once
 func (r *HashRepository[T]) SaveMulti(ctx context.Context, entities ...*T) []error {
 	errs := make([]error, len(entities))
+	if r.err != nil {
+		for i := range errs {
+			errs[i] = r.err
+		}
+		return errs
+	}
 	vals := make([]reflect.Value, len(entities))
 	exec := make([]valkey.LuaExec, len(entities))
 	for i, entity := range entities {
-		vals[i], exec[i] = r.toExec(entity)
+		vals[i], exec[i] = r.toExec(entity, 0)
 	}
 	for i, resp := range hashSaveScript.ExecMulti(ctx, r.client, exec...) {
 		if str, err := resp.ToString(); err != nil {
-			if errs[i] = err; valkey.IsValkeyNil(err) {
-				errs[i] = ErrVersionMismatch
-			}
+			errs[i] = saveErr(err)
 		} else {
 			ver, _ := strconv.ParseInt(str, 10, 64)
 			vals[i].Field(r.schema.ver.idx).SetInt(ver)
@@ -132,8 +191,120 @@ func (r *HashRepository[T]) SaveMulti(ctx context.Context, entities ...*T) []err
 }
 
 // Remove the entity under the valkey key of `{prefix}:{id}`.
+// If the schema has `valkey:",lookup"` fields, the entity is also removed from their secondary indexes atomically.
+// If the WithChangeStream is used, a remove event is also added to the stream atomically.
 func (r *HashRepository[T]) Remove(ctx context.Context, id string) error {
-	return r.client.Do(ctx, r.client.B().Del().Key(key(r.prefix, id)).Build()).Error()
+	if len(r.schema.lookups) == 0 && r.stream == "" {
+		return r.client.Do(ctx, r.client.B().Del().Key(key(r.prefix, id)).Build()).Error()
+	}
+	return r.remove(ctx, id, "")
+}
+
+// SoftRemove marks the entity under the valkey key of `{prefix}:{id}` as deleted instead of deleting it.
+// The marked entity is excluded from Fetch, FetchCache, FetchMulti, FetchMultiCache, FindBy, FindByRange, Search and Aggregate,
+// and it is removed from the secondary indexes. If the WithChangeStream is used, a softremove event is added to the stream.
+// It returns ErrSoftDeleteDisabled if the repository is not created with WithSoftDelete.
+func (r *HashRepository[T]) SoftRemove(ctx context.Context, id string) error {
+	if !r.softDelete {
+		return ErrSoftDeleteDisabled
+	}
+	return r.remove(ctx, id, strconv.FormatInt(time.Now().UnixMilli(), 10))
+}
+
+func (r *HashRepository[T]) remove(ctx context.Context, id string, deleted string) error {
+	if r.err != nil {
+		return r.err
+	}
+	keys := append([]string{key(r.prefix, id)}, lookupKeys(r.prefix, r.schema.lookups)...)
+	args := append([]string{deleted}, lookupArgs(id, r.schema.lookups, reflect.Value{}, false)...)
+	if r.stream != "" {
+		keys = append(keys, r.stream)
+		args = append(args, changeArgs(id, r.schema.ver.name, r.maxLen)...)
+	}
+	return hashRemoveScript.Exec(ctx, r.client, keys, args).Error()
+}
+
+// Migrate eagerly upgrades the entities under `{prefix}:*` whose schema versions are older than the one of WithMigrations, by SCAN in batches.
+// The upgraded hash is rewritten entirely, so the fields removed by the upgrades are also removed from valkey.
+// An entity changed concurrently is skipped, and it will still be upgraded lazily when fetched. It returns the number of upgraded entities.
+// The secondary indexes of the `valkey:",lookup"` fields are updated atomically, and if the WithChangeStream is used,
+// a migrate event is also added to the stream.
+func (r *HashRepository[T]) Migrate(ctx context.Context) (n int64, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	err = scanKeys(ctx, r.client, r.prefix, "hash", func(keys []string) error {
+		cmds := make(valkey.Commands, len(keys))
+		for i, k := range keys {
+			cmds[i] = r.client.B().Hgetall().Key(k).Build()
+		}
+		exec := make([]valkey.LuaExec, 0, len(keys))
+		for i, resp := range r.client.DoMulti(ctx, cmds...) {
+			fields, err := resp.AsStrMap()
+			if err != nil {
+				return err
+			}
+			upgraded, ok, err := upgradeHash(r.upgrades, fields)
+			if err != nil {
+				return err
+			}
+			if !ok || len(fields) == 0 {
+				continue
+			}
+			entity := reflect.Value{}
+			if _, deleted := upgraded[deletedField]; !deleted && len(r.schema.lookups) > 0 {
+				e, err := r.fromFields(upgraded)
+				if err != nil {
+					return err
+				}
+				entity = reflect.ValueOf(e).Elem()
+			}
+			id := strings.TrimPrefix(keys[i], r.prefix+":")
+			args := make([]string, 0, len(upgraded)*2+3)
+			args = append(args, r.schema.ver.name, fields[r.schema.ver.name], strconv.Itoa(len(upgraded)*2))
+			for k, v := range upgraded {
+				args = append(args, k, v)
+			}
+			ks := append([]string{keys[i]}, lookupKeys(r.prefix, r.schema.lookups)...)
+			args = append(args, lookupArgs(id, r.schema.lookups, entity, false)...)
+			if r.stream != "" {
+				ks = append(ks, r.stream)
+				args = append(args, changeArgs(id, r.schema.ver.name, r.maxLen)...)
+			}
+			exec = append(exec, valkey.LuaExec{Keys: ks, Args: args})
+		}
+		if len(exec) == 0 {
+			return nil
+		}
+		for _, resp := range hashMigrateScript.ExecMulti(ctx, r.client, exec...) {
+			m, err := resp.AsInt64()
+			if err != nil {
+				return err
+			}
+			n += m
+		}
+		return nil
+	})
+	return n, err
+}
+
+// FindBy finds the entities whose `valkey:",lookup"` field equals the value from the secondary index maintained by the repository.
+// It doesn't require the RediSearch module. It panics if the field is not tagged with `valkey:",lookup"`.
+func (r *HashRepository[T]) FindBy(ctx context.Context, field string, value any) ([]*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	cmd, trim := lookupCmd(r.client.B(), r.schema, r.prefix, field, value, 0, 0)
+	return findBy(ctx, r.client, cmd, trim, r.FetchMulti)
+}
+
+// FindByRange finds the entities whose numeric `valkey:",lookup"` field is within [min, max], ordered by the field.
+func (r *HashRepository[T]) FindByRange(ctx context.Context, field string, min, max float64) ([]*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	cmd, trim := lookupCmd(r.client.B(), r.schema, r.prefix, field, nil, min, max)
+	return findBy(ctx, r.client, cmd, trim, r.FetchMulti)
 }
 
 // AlterIndex uses FT.ALTER from the RediSearch module to alter index under the name `hashidx:{prefix}`
@@ -145,7 +316,17 @@ func (r *HashRepository[T]) AlterIndex(ctx context.Context, cmdFn func(alter FtA
 // CreateIndex uses FT.CREATE from the RediSearch module to create an inverted index under the name `hashidx:{prefix}`
 // You can use the cmdFn parameter to mutate the index construction command.
 func (r *HashRepository[T]) CreateIndex(ctx context.Context, cmdFn func(schema FtCreateSchema) valkey.Completed) error {
-	return r.client.Do(ctx, cmdFn(r.client.B().FtCreate().Index(r.idx).OnHash().Prefix(1).Prefix(r.prefix+":").Schema())).Error()
+	schema := r.client.B().FtCreate().Index(r.idx).OnHash().Prefix(1).Prefix(r.prefix + ":").Schema()
+	if r.softDelete {
+		schema = withDeletedField(schema, false)
+	}
+	return r.client.Do(ctx, cmdFn(schema)).Error()
+}
+
+// CreateIndexFromSchema creates the index under the name `hashidx:{prefix}` from the fields tagged with `valkey:",index=..."`.
+// If the index already exists, the fields missing from its FT.INFO attributes are added by FT.ALTER.
+func (r *HashRepository[T]) CreateIndexFromSchema(ctx context.Context) error {
+	return createIndexFromSchema(ctx, r.client, r.idx, r.prefix, false, r.softDelete, r.schema)
 }
 
 // CreateAndAliasIndex creates a new index, aliases it, and drops the old index if needed.
@@ -193,8 +374,11 @@ func (r *HashRepository[T]) CreateAndAliasIndex(ctx context.Context, cmdFn func(
 	}
 
 	// Create the new index
-	cmd := r.client.B().FtCreate().Index(newIndex).OnHash().Prefix(1).Prefix(r.prefix + ":")
-	if err := r.client.Do(ctx, cmdFn(cmd.Schema())).Error(); err != nil {
+	schema := r.client.B().FtCreate().Index(newIndex).OnHash().Prefix(1).Prefix(r.prefix + ":").Schema()
+	if r.softDelete {
+		schema = withDeletedField(schema, false)
+	}
+	if err := r.client.Do(ctx, cmdFn(schema)).Error(); err != nil {
 		return err
 	}
 
@@ -231,22 +415,35 @@ func (r *HashRepository[T]) DropIndex(ctx context.Context) error {
 // 2. the search result, and note that its length might be smaller than the first return value.
 // 3. error if any
 // You can use the cmdFn parameter to mutate the search command.
+// If the WithSoftDelete is used, the entities marked by SoftRemove are excluded by the query,
+// so the index must have the `__deleted` NUMERIC field.
 func (r *HashRepository[T]) Search(ctx context.Context, cmdFn func(search FtSearchIndex) valkey.Completed) (n int64, s []*T, err error) {
-	n, resp, err := r.client.Do(ctx, cmdFn(r.client.B().FtSearch().Index(r.idx))).AsFtSearch()
+	cmd := cmdFn(r.client.B().FtSearch().Index(r.idx))
+	if r.softDelete {
+		cmd = excludeDeleted(cmd)
+	}
+	n, resp, err := r.client.Do(ctx, cmd).AsFtSearch()
 	if err == nil {
-		s = make([]*T, len(resp))
-		for i, v := range resp {
-			if s[i], err = r.fromFields(v.Doc); err != nil {
+		s = make([]*T, 0, len(resp))
+		for _, v := range resp {
+			e, err := r.fromFields(v.Doc)
+			if err != nil {
 				return 0, nil, err
 			}
+			s = append(s, e)
 		}
 	}
 	return n, s, err
 }
 
 // Aggregate performs the FT.AGGREGATE and returns a *AggregateCursor for accessing the results
+// If the WithSoftDelete is used, the entities marked by SoftRemove are excluded by the query.
 func (r *HashRepository[T]) Aggregate(ctx context.Context, cmdFn func(agg FtAggregateIndex) valkey.Completed) (cursor *AggregateCursor, err error) {
-	cid, total, resp, err := r.client.Do(ctx, cmdFn(r.client.B().FtAggregate().Index(r.idx))).AsFtAggregateCursor()
+	cmd := cmdFn(r.client.B().FtAggregate().Index(r.idx))
+	if r.softDelete {
+		cmd = excludeDeleted(cmd)
+	}
+	cid, total, resp, err := r.client.Do(ctx, cmd).AsFtAggregateCursor()
 	if err != nil {
 		return nil, err
 	}
@@ -266,6 +463,15 @@ func (r *HashRepository[T]) fromHash(record map[string]string) (*T, error) {
 }
 
 func (r *HashRepository[T]) fromFields(fields map[string]string) (*T, error) {
+	if _, ok := fields[deletedField]; ok {
+		return nil, ErrRecordDeleted
+	}
+	if len(r.upgrades) > 0 {
+		var err error
+		if fields, _, err = upgradeHash(r.upgrades, fields); err != nil {
+			return nil, err
+		}
+	}
 	var v T
 	if err := r.factory.NewConverter(reflect.ValueOf(&v).Elem()).FromHash(fields); err != nil {
 		return nil, err
@@ -273,17 +479,74 @@ func (r *HashRepository[T]) fromFields(fields map[string]string) (*T, error) {
 	return &v, nil
 }
 
-var hashSaveScript = valkey.NewLuaScript(`
+// hashSaveScript saves the hash with optimistic locking. Its ARGV are the ver field name, the ver, the expiry timestamp in milliseconds or an empty string,
+// the number of the following field value pairs, the field value pairs, the lookup section of the lookupScript, and then the change section of the changeScript.
+// It replies the deletedReply error without saving if the hash is marked as deleted by SoftRemove.
+var hashSaveScript = valkey.NewLuaScript(lookupScript + changeScript + `
 local v = redis.call('HGET',KEYS[1],ARGV[1])
 if (not v or v == ARGV[2])
 then
-  ARGV[2] = tostring(tonumber(ARGV[2])+1)
-  local e = (#ARGV % 2 == 1) and table.remove(ARGV) or nil
-  if redis.call('HSET',KEYS[1],unpack(ARGV))
-  then
-    if e then redis.call('PEXPIREAT',KEYS[1],e) end
-    return ARGV[2]
+  if v and redis.call('HEXISTS',KEYS[1],'` + deletedField + `') == 1 then return redis.error_reply('` + deletedReply + `') end
+  local n = tonumber(ARGV[4])
+  local kv = {ARGV[1], tostring(tonumber(ARGV[2])+1)}
+  for i = 5, 4+n do table.insert(kv, ARGV[i]) end
+  local apply, c = lookup(5+n, function(f) return redis.call('HGET',KEYS[1],f) end)
+  local changed = {}
+  if ARGV[c] then
+    for i = 3, #kv, 2 do
+      if redis.call('HGET',KEYS[1],kv[i]) ~= kv[i+1] then table.insert(changed, kv[i]) end
+    end
+    table.sort(changed)
   end
+  redis.call('HSET',KEYS[1],unpack(kv))
+  if ARGV[3] ~= '' then redis.call('PEXPIREAT',KEYS[1],ARGV[3]) end
+  apply()
+  change(c, 'save', kv[2], changed)
+  return kv[2]
 end
 return nil
 `)
+
+// hashRemoveScript deletes the hash, or marks it as deleted if ARGV[1] is not empty.
+// The following ARGV are the lookup section of the lookupScript and then the change section of the changeScript.
+var hashRemoveScript = valkey.NewLuaScript(lookupScript + changeScript + `
+local apply, c = lookup(2, function(f) return redis.call('HGET',KEYS[1],f) end)
+local ver = ARGV[c] and redis.call('HGET',KEYS[1],ARGV[c+2])
+local n, op = 0, 'remove'
+if ARGV[1] == '' then
+  n = redis.call('DEL',KEYS[1])
+elseif redis.call('EXISTS',KEYS[1]) == 1 then
+  n, op = redis.call('HSETNX',KEYS[1],'` + deletedField + `',ARGV[1]), 'softremove'
+end
+if ARGV[1] == '' or n > 0 then apply() end
+if n > 0 then change(c, op, ver or '0', {}) end
+return n
+`)
+
+// hashMigrateScript rewrites the hash with the upgraded field value pairs if its ver field still equals ARGV[2], and keeps its expiry.
+// Its ARGV are the ver field name, the ver, the number of the following field value pairs and the pairs,
+// the lookup section of the lookupScript, and then the change section of the changeScript.
+var hashMigrateScript = valkey.NewLuaScript(lookupScript + changeScript + `
+if (redis.call('HGET',KEYS[1],ARGV[1]) or '') ~= ARGV[2] then return 0 end
+local n = tonumber(ARGV[3])
+local apply, c = lookup(4+n, function(f) return redis.call('HGET',KEYS[1],f) end)
+local changed = {}
+if ARGV[c] then
+  local kv = {}
+  for i = 4, 3+n, 2 do kv[ARGV[i]] = ARGV[i+1] end
+  local old = redis.call('HGETALL',KEYS[1])
+  for i = 1, #old, 2 do
+    if kv[old[i]] ~= old[i+1] then table.insert(changed, old[i]) end
+    kv[old[i]] = nil
+  end
+  for f in pairs(kv) do table.insert(changed, f) end
+  table.sort(changed)
+end
+local ttl = redis.call('PTTL',KEYS[1])
+redis.call('DEL',KEYS[1])
+redis.call('HSET',KEYS[1],unpack(ARGV,4,3+n))
+if ttl > 0 then redis.call('PEXPIRE',KEYS[1],ttl) end
+apply()
+change(c, 'migrate', ARGV[2], changed)
+return 1
+`)
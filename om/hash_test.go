@@ -44,6 +44,160 @@ type Mismatch struct {
 	F2  *int64
 }
 
+type Address struct {
+	City    string `json:"city"`
+	Zip     *int64 `json:"zip"`
+	Country struct {
+		Code string `json:"code"`
+	} `json:"country" valkey:",enc=flat"`
+	private string
+}
+
+type HashNestedStruct struct {
+	Key     string            `valkey:",key"`
+	Ver     int64             `valkey:",ver"`
+	Addr    Address           `json:"addr" valkey:",enc=flat"`
+	Ship    *Address          `json:"ship" valkey:",enc=flat"`
+	Tags    []string          `json:"tags" valkey:",enc=delim"`
+	Nums    []int32           `json:"nums" valkey:",enc=delim(|)"`
+	Scores  map[string]int    `json:"scores" valkey:",enc=delim(;)"`
+	Attrs   map[string]string `json:"attrs" valkey:",enc=json"`
+	Points  []float32         `json:"points" valkey:",enc=json"`
+	Friends []Address         `json:"friends"`
+}
+
+func TestHashConvUntaggedNested(t *testing.T) {
+	type UntaggedMap struct {
+		Key   string            `valkey:",key"`
+		Ver   int64             `valkey:",ver"`
+		Attrs map[string]string `json:"attrs"`
+	}
+	type UntaggedSlice struct {
+		Key  string  `valkey:",key"`
+		Ver  int64   `valkey:",ver"`
+		Nums []int32 `json:"nums"`
+	}
+	if v := recovered(func() {
+		newHashConvFactory(reflect.TypeOf(UntaggedMap{}), newSchema(reflect.TypeOf(UntaggedMap{})))
+	}); !strings.Contains(v, "unsupported field type map") {
+		t.Fatalf("unexpected msg %v", v)
+	}
+	if v := recovered(func() {
+		newHashConvFactory(reflect.TypeOf(UntaggedSlice{}), newSchema(reflect.TypeOf(UntaggedSlice{})))
+	}); !strings.Contains(v, "unsupported field type slice") {
+		t.Fatalf("unexpected msg %v", v)
+	}
+}
+
+func TestHashConvNested(t *testing.T) {
+	factory := newHashConvFactory(reflect.TypeOf(HashNestedStruct{}), newSchema(reflect.TypeOf(HashNestedStruct{})))
+	zip := int64(10001)
+	e := HashNestedStruct{
+		Key:     "1",
+		Ver:     2,
+		Addr:    Address{City: "NYC", Zip: &zip},
+		Tags:    []string{"a", "b"},
+		Nums:    []int32{1, -2},
+		Scores:  map[string]int{"x": 1, "y": 2},
+		Attrs:   map[string]string{"k": "v,w"},
+		Points:  []float32{1.5},
+		Friends: []Address{{City: "LA"}},
+	}
+	e.Addr.Country.Code = "US"
+	fields := factory.NewConverter(reflect.ValueOf(&e).Elem()).ToHash()
+	if !reflect.DeepEqual(fields, map[string]string{
+		"Key":               "1",
+		"Ver":               "2",
+		"addr.city":         "NYC",
+		"addr.zip":          "10001",
+		"addr.country.code": "US",
+		"tags":              "a,b",
+		"nums":              "1|-2",
+		"scores":            "x=1;y=2",
+		"attrs":             `{"k":"v,w"}`,
+		"points":            `[1.5]`,
+		"friends":           `[{"city":"LA","zip":null,"country":{"code":""}}]`,
+	}) {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	var v HashNestedStruct
+	if err := factory.NewConverter(reflect.ValueOf(&v).Elem()).FromHash(fields); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(e, v) {
+		t.Fatalf("unexpected entity %v", v)
+	}
+
+	fields["ship.city"] = "SF"
+	fields["nums"] = "x"
+	if err := factory.NewConverter(reflect.ValueOf(&v).Elem()).FromHash(fields); err == nil {
+		t.Fatal("FromHash not failed as expected")
+	}
+	fields["nums"] = ""
+	v = HashNestedStruct{}
+	if err := factory.NewConverter(reflect.ValueOf(&v).Elem()).FromHash(fields); err != nil {
+		t.Fatal(err)
+	}
+	if v.Ship == nil || v.Ship.City != "SF" || v.Nums != nil {
+		t.Fatalf("unexpected entity %v", v)
+	}
+}
+
+func TestHashRepositoryNested(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	repo := NewHashRepository("hashnested", HashNestedStruct{}, client)
+
+	zip := int64(10001)
+	e := repo.NewEntity()
+	e.Addr = Address{City: "NYC", Zip: &zip}
+	e.Ship = &Address{City: "SF"}
+	e.Tags = []string{"a", "b"}
+	e.Scores = map[string]int{"x": 1}
+	e.Friends = []Address{{City: "LA"}}
+	if err := repo.Save(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+
+	ei, err := repo.Fetch(ctx, e.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(e, ei) {
+		t.Fatalf("ei should be the same as e")
+	}
+	ei, err = repo.FetchCache(ctx, e.Key, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(e, ei) {
+		t.Fatalf("ei should be the same as e")
+	}
+
+	if err := repo.CreateIndex(ctx, func(schema FtCreateSchema) valkey.Completed {
+		return schema.FieldName("tags").Tag().Build()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second)
+	n, records, err := repo.Search(ctx, func(search FtSearchIndex) valkey.Completed {
+		return search.Query("@tags:{b}").Build()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(records) != 1 || !reflect.DeepEqual(e, records[0]) {
+		t.Fatalf("unexpected search result %v %v", n, records)
+	}
+	if err = repo.DropIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestNewHashRepositoryPanic(t *testing.T) {
 	if v := recovered(func() {
 		NewHashRepository("hash", Unsupported{}, nil)
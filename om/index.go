@@ -0,0 +1,139 @@
+package om
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+// ErrNoIndexedFields indicates that the schema has no field tagged with `valkey:",index=..."`.
+var ErrNoIndexedFields = errors.New("schema has no field tagged with `valkey:\",index=...\"`")
+
+// createIndexFromSchema creates the index with the fields tagged with `valkey:",index=..."` if it doesn't exist.
+// Otherwise, it compares the fields with the attributes reported by FT.INFO and adds the missing ones by FT.ALTER.
+// The marker of SoftRemove is also indexed if softDelete is true.
+func createIndexFromSchema(ctx context.Context, client valkey.Client, idx, prefix string, onJSON, softDelete bool, s schema) error {
+	fields := s.indexFields()
+	if len(fields) == 0 {
+		return ErrNoIndexedFields
+	}
+
+	info, err := client.Do(ctx, client.B().FtInfo().Index(idx).Build()).ToMap()
+	if err != nil {
+		if !isUnknownIndex(err) {
+			return fmt.Errorf("failed to check if index exists: %w", err)
+		}
+		create := client.B().FtCreate().Index(idx)
+		var schema FtCreateSchema
+		if onJSON {
+			schema = create.OnJson().Prefix(1).Prefix(prefix + ":").Schema()
+		} else {
+			schema = create.OnHash().Prefix(1).Prefix(prefix + ":").Schema()
+		}
+		if softDelete {
+			schema = withDeletedField(schema, onJSON)
+		}
+		cmd := fields[0].define(schema.FieldName, onJSON)
+		for _, f := range fields[1:] {
+			cmd = f.define(cmd.FieldName, onJSON)
+		}
+		return client.Do(ctx, cmd.Build()).Error()
+	}
+
+	existing := indexedAttributes(info)
+	if softDelete && !existing[deletedField] {
+		args := deletedArgs(onJSON)
+		cmd := client.B().FtAlter().Index(idx).Schema().Add().Field(args[0]).Options(args[1:]...).Build()
+		if err := client.Do(ctx, cmd).Error(); err != nil {
+			return fmt.Errorf("failed to add field %s to index %s: %w", deletedField, idx, err)
+		}
+	}
+	for _, f := range fields {
+		if existing[f.name] {
+			continue
+		}
+		args := f.schemaArgs(onJSON)
+		cmd := client.B().FtAlter().Index(idx).Schema().Add().Field(args[0]).Options(args[1:]...).Build()
+		if err := client.Do(ctx, cmd).Error(); err != nil {
+			return fmt.Errorf("failed to add field %s to index %s: %w", f.name, idx, err)
+		}
+	}
+	return nil
+}
+
+// deletedQuery matches the entities marked by SoftRemove, whose marker is indexed as a NUMERIC field.
+const deletedQuery = "@" + deletedField + ":[-inf +inf]"
+
+// deletedArgs generates the arguments after the SCHEMA ADD tokens of FT.ALTER for the marker of SoftRemove.
+func deletedArgs(onJSON bool) []string {
+	if onJSON {
+		return []string{"$." + deletedField, "AS", deletedField, "NUMERIC"}
+	}
+	return []string{deletedField, "NUMERIC"}
+}
+
+// withDeletedField puts the marker of SoftRemove in front of the schema, so that Search and Aggregate can exclude the marked entities.
+func withDeletedField(schema FtCreateSchema, onJSON bool) FtCreateSchema {
+	if onJSON {
+		return FtCreateSchema(schema.FieldName("$." + deletedField).As(deletedField).Numeric())
+	}
+	return FtCreateSchema(schema.FieldName(deletedField).Numeric())
+}
+
+// excludeDeleted rewrites the query of the FT.SEARCH or FT.AGGREGATE to exclude the entities marked by SoftRemove,
+// so that the total count and the pagination are computed by valkey without them.
+func excludeDeleted(cmd valkey.Completed) valkey.Completed {
+	args := cmd.Commands()
+	if len(args) < 3 {
+		return cmd
+	}
+	args = append([]string(nil), args...)
+	if args[2] == "*" {
+		args[2] = "-" + deletedQuery
+	} else {
+		args[2] = "(" + args[2] + ") -" + deletedQuery
+	}
+	return cmds.ReplaceCompletedArgs(cmd, args)
+}
+
+// isUnknownIndex checks if the err is the "Unknown index name" reply of FT.INFO.
+func isUnknownIndex(err error) bool {
+	ret, ok := valkey.IsValkeyErr(err)
+	return ok && ret.Error() == "Unknown index name"
+}
+
+// indexedAttributes collects the attribute names of the FT.INFO response.
+// Each attribute is either a map in RESP3 or a flat array of key value pairs in RESP2.
+func indexedAttributes(info map[string]valkey.ValkeyMessage) map[string]bool {
+	names := make(map[string]bool)
+	attrs, ok := info["attributes"]
+	if !ok {
+		return names
+	}
+	entries, _ := attrs.ToArray()
+	for _, entry := range entries {
+		if m, err := entry.AsMap(); err == nil && entry.IsMap() {
+			for _, k := range []string{"attribute", "identifier"} {
+				if v, ok := m[k]; ok {
+					if name, err := v.ToString(); err == nil {
+						names[strings.TrimPrefix(name, "$.")] = true
+					}
+				}
+			}
+			continue
+		}
+		values, _ := entry.ToArray()
+		for i := 0; i+1 < len(values); i++ {
+			if k, _ := values[i].ToString(); k == "attribute" || k == "identifier" {
+				if name, err := values[i+1].ToString(); err == nil {
+					names[strings.TrimPrefix(name, "$.")] = true
+				}
+			}
+		}
+	}
+	return names
+}
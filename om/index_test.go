@@ -0,0 +1,117 @@
+package om
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+type IndexedV1 struct {
+	Key  string `json:"key" valkey:",key"`
+	Ver  int64  `json:"ver" valkey:",ver"`
+	Name string `json:"name" valkey:",index=tag"`
+}
+
+type IndexedV2 struct {
+	Key  string `json:"key" valkey:",key"`
+	Ver  int64  `json:"ver" valkey:",ver"`
+	Name string `json:"name" valkey:",index=tag"`
+	Age  int64  `json:"age" valkey:",index=numeric,sortable"`
+}
+
+func TestCreateIndexFromSchema(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, c := range []struct {
+		v1 Repository[IndexedV1]
+		v2 Repository[IndexedV2]
+	}{
+		{v1: NewHashRepository("hashschema", IndexedV1{}, client), v2: NewHashRepository("hashschema", IndexedV2{}, client)},
+		{v1: NewJSONRepository("jsonschema", IndexedV1{}, client), v2: NewJSONRepository("jsonschema", IndexedV2{}, client)},
+	} {
+		if err := c.v1.CreateIndexFromSchema(ctx); err != nil {
+			t.Fatal(err)
+		}
+		// a second call should be a no-op
+		if err := c.v1.CreateIndexFromSchema(ctx); err != nil {
+			t.Fatal(err)
+		}
+		// the new Age field should be added by FT.ALTER
+		if err := c.v2.CreateIndexFromSchema(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := client.Do(ctx, client.B().FtInfo().Index(c.v2.IndexName()).Build()).ToMap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attrs := indexedAttributes(info); !attrs["name"] || !attrs["age"] {
+			t.Fatalf("unexpected attributes %v", attrs)
+		}
+
+		e := c.v2.NewEntity()
+		e.Name = "a:b"
+		e.Age = 20
+		if err := c.v2.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Second)
+		n, records, err := c.v2.Search(ctx, func(search FtSearchIndex) valkey.Completed {
+			return search.Query("@age:[18 30]").Build()
+		})
+		if err != nil || n != 1 || records[0].Key != e.Key {
+			t.Fatalf("unexpected search result %v %v %v", n, records, err)
+		}
+		if err := c.v2.DropIndex(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("no indexed fields", func(t *testing.T) {
+		if err := NewHashRepository("hashschema", TestStruct{}, client).CreateIndexFromSchema(ctx); err != ErrNoIndexedFields {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestIsUnknownIndex(t *testing.T) {
+	if isUnknownIndex(errors.New("Unknown index name")) {
+		t.Fatalf("non valkey errors should not be treated as the unknown index reply")
+	}
+}
+
+func TestExcludeDeleted(t *testing.T) {
+	b := cmds.NewBuilder(cmds.NoSlot)
+	for _, c := range []struct {
+		cmd  valkey.Completed
+		want []string
+	}{
+		{cmd: b.FtSearch().Index("idx").Query("*").Limit().OffsetNum(10, 10).Build(), want: []string{"FT.SEARCH", "idx", "-@__deleted:[-inf +inf]", "LIMIT", "10", "10"}},
+		{cmd: b.FtSearch().Index("idx").Query("@a:{b} | @c:{d}").Build(), want: []string{"FT.SEARCH", "idx", "(@a:{b} | @c:{d}) -@__deleted:[-inf +inf]"}},
+		{cmd: b.FtAggregate().Index("idx").Query("*").Build(), want: []string{"FT.AGGREGATE", "idx", "-@__deleted:[-inf +inf]"}},
+	} {
+		cmd := excludeDeleted(c.cmd)
+		if v := cmd.Commands(); !reflect.DeepEqual(v, c.want) {
+			t.Fatalf("unexpected command %v", v)
+		}
+	}
+}
+
+func TestWithDeletedField(t *testing.T) {
+	for _, onJSON := range []bool{false, true} {
+		cmd := withDeletedField(cmds.NewBuilder(cmds.NoSlot).FtCreate().Index("idx").Schema(), onJSON).FieldName("a").Tag().Build()
+		want := append(append([]string{"FT.CREATE", "idx", "SCHEMA"}, deletedArgs(onJSON)...), "a", "TAG")
+		if v := cmd.Commands(); !reflect.DeepEqual(v, want) {
+			t.Fatalf("unexpected command %v", v)
+		}
+	}
+}
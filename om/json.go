@@ -27,18 +27,26 @@ func NewJSONRepository[T any](prefix string, schema T, client valkey.Client, opt
 	for _, opt := range opts {
 		opt((*JSONRepository[any])(repo))
 	}
+	repo.err = checkSlots(client, repo.schema, repo.prefix, repo.stream)
 	return repo
 }
 
-var _ Repository[any] = (*JSONRepository[any])(nil)
+var _ LookupRepository[any] = (*JSONRepository[any])(nil)
+var _ MigrateRepository[any] = (*JSONRepository[any])(nil)
 
 // JSONRepository is an OM repository backed by RedisJSON.
 type JSONRepository[T any] struct {
-	schema schema
-	typ    reflect.Type
-	client valkey.Client
-	prefix string
-	idx    string
+	schema   schema
+	typ      reflect.Type
+	client   valkey.Client
+	prefix   string
+	idx      string
+	stream   string
+	err      error // the error of the checkSlots, returned by writes and lookups
+	upgrades []Upgrade
+	maxLen   int64
+	// softDelete enables SoftRemove and excludes the marked entities from Search and Aggregate, see WithSoftDelete.
+	softDelete bool
 }
 
 // NewEntity returns an empty entity and will have the `valkey:",key"` field be set with ULID automatically.
@@ -66,7 +74,47 @@ func (r *JSONRepository[T]) FetchCache(ctx context.Context, id string, ttl time.
 	return v, err
 }
 
+// FetchMulti batches multiple JSONRepository.Fetch at once
+func (r *JSONRepository[T]) FetchMulti(ctx context.Context, ids ...string) ([]*T, []error) {
+	cmds := make(valkey.Commands, len(ids))
+	for i, id := range ids {
+		cmds[i] = r.client.B().JsonGet().Key(key(r.prefix, id)).Path(".").Build()
+	}
+	return r.fromResps(r.client.DoMulti(ctx, cmds...))
+}
+
+// FetchMultiCache batches multiple JSONRepository.FetchCache at once
+func (r *JSONRepository[T]) FetchMultiCache(ctx context.Context, ttl time.Duration, ids ...string) ([]*T, []error) {
+	cmds := make([]valkey.CacheableTTL, len(ids))
+	for i, id := range ids {
+		cmds[i] = valkey.CT(r.client.B().JsonGet().Key(key(r.prefix, id)).Path(".").Cache(), ttl)
+	}
+	return r.fromResps(r.client.DoMultiCache(ctx, cmds...))
+}
+
+func (r *JSONRepository[T]) fromResps(resps []valkey.ValkeyResult) ([]*T, []error) {
+	s := make([]*T, len(resps))
+	errs := make([]error, len(resps))
+	for i, resp := range resps {
+		record, err := resp.ToString()
+		if err == nil {
+			s[i], err = r.decode(record)
+		}
+		errs[i] = err
+	}
+	return s, errs
+}
+
 func (r *JSONRepository[T]) decode(record string) (*T, error) {
+	if hasTopLevelKey(record, deletedField) {
+		return nil, ErrRecordDeleted
+	}
+	if len(r.upgrades) > 0 {
+		var err error
+		if record, _, err = upgradeJSON(r.upgrades, record); err != nil {
+			return nil, err
+		}
+	}
 	var v T
 	if err := json.Unmarshal([]byte(record), &v); err != nil {
 		return nil, err
@@ -74,7 +122,42 @@ func (r *JSONRepository[T]) decode(record string) (*T, error) {
 	return &v, nil
 }
 
-func (r *JSONRepository[T]) toExec(entity *T) (verf reflect.Value, exec valkey.LuaExec) {
+// hasTopLevelKey reports whether the JSON object has the key at its top level by scanning the record once without decoding it.
+// Nested keys and string values equal to the key are not matched.
+func hasTopLevelKey(record, key string) bool {
+	if !strings.Contains(record, key) {
+		return false
+	}
+	depth := 0
+	for i := 0; i < len(record); i++ {
+		switch record[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case '"':
+			j := i + 1
+			for ; j < len(record) && record[j] != '"'; j++ {
+				if record[j] == '\\' {
+					j++
+				}
+			}
+			if depth == 1 && j < len(record) && record[i+1:j] == key {
+				k := j + 1
+				for k < len(record) && (record[k] == ' ' || record[k] == '\t' || record[k] == '\n' || record[k] == '\r') {
+					k++
+				}
+				if k < len(record) && record[k] == ':' {
+					return true
+				}
+			}
+			i = j
+		}
+	}
+	return false
+}
+
+func (r *JSONRepository[T]) toExec(entity *T, ttl time.Duration) (verf reflect.Value, exec valkey.LuaExec) {
 	val := reflect.ValueOf(entity).Elem()
 	verf = val.Field(r.schema.ver.idx)
 	extVal := int64(0)
@@ -83,43 +166,67 @@ func (r *JSONRepository[T]) toExec(entity *T) (verf reflect.Value, exec valkey.L
 			extVal = ext.UnixMilli()
 		}
 	}
-	exec.Keys = []string{key(r.prefix, val.Field(r.schema.key.idx).String())}
+	if ttl > 0 {
+		extVal = time.Now().Add(ttl).UnixMilli()
+	}
+	id := val.Field(r.schema.key.idx).String()
+	exec.Keys = []string{key(r.prefix, id)}
+	doc := valkey.JSON(entity)
+	if len(r.upgrades) > 0 {
+		doc = withSchemaField(doc, len(r.upgrades))
+	}
+	exec.Args = []string{r.schema.ver.name, strconv.FormatInt(verf.Int(), 10), doc, ""}
 	if extVal != 0 {
-		exec.Args = []string{r.schema.ver.name, strconv.FormatInt(verf.Int(), 10), valkey.JSON(entity), strconv.FormatInt(extVal, 10)}
-	} else {
-		exec.Args = []string{r.schema.ver.name, strconv.FormatInt(verf.Int(), 10), valkey.JSON(entity)}
+		exec.Args[3] = strconv.FormatInt(extVal, 10)
+	}
+	exec.Keys = append(exec.Keys, lookupKeys(r.prefix, r.schema.lookups)...)
+	exec.Args = append(exec.Args, lookupArgs(id, r.schema.lookups, val, false)...)
+	if r.stream != "" {
+		exec.Keys = append(exec.Keys, r.stream)
+		exec.Args = append(exec.Args, changeArgs(id, r.schema.ver.name, r.maxLen)...)
 	}
 	return
 }
 
 // Save the entity under the valkey key of `{prefix}:{id}`.
 // It also uses the `valkey:",ver"` field and lua script to perform optimistic locking and prevent lost update.
+// It returns ErrRecordDeleted if the entity is marked as deleted by SoftRemove, which should be removed by Remove before saving it again.
 func (r *JSONRepository[T]) Save(ctx context.Context, entity *T) (err error) {
-	valf, exec := r.toExec(entity)
-	str, err := jsonSaveScript.Exec(ctx, r.client, exec.Keys, exec.Args).ToString()
-	if valkey.IsValkeyNil(err) {
-		return ErrVersionMismatch
+	return r.SaveWithTTL(ctx, entity, 0)
+}
+
+// SaveWithTTL is like Save, but the entity expires after the ttl, which overrides the `valkey:",exat"` field.
+// A non-positive ttl means no override.
+func (r *JSONRepository[T]) SaveWithTTL(ctx context.Context, entity *T, ttl time.Duration) (err error) {
+	if r.err != nil {
+		return r.err
 	}
+	valf, exec := r.toExec(entity, ttl)
+	str, err := jsonSaveScript.Exec(ctx, r.client, exec.Keys, exec.Args).ToString()
 	if err == nil {
 		ver, _ := strconv.ParseInt(str, 10, 64)
 		valf.SetInt(ver)
 	}
-	return err
+	return saveErr(err)
 }
 
 // SaveMulti batches multiple HashRepository.Save at once
 func (r *JSONRepository[T]) SaveMulti(ctx context.Context, entities ...*T) []error {
 	errs := make([]error, len(entities))
+	if r.err != nil {
+		for i := range errs {
+			errs[i] = r.err
+		}
+		return errs
+	}
 	valf := make([]reflect.Value, len(entities))
 	exec := make([]valkey.LuaExec, len(entities))
 	for i, entity := range entities {
-		valf[i], exec[i] = r.toExec(entity)
+		valf[i], exec[i] = r.toExec(entity, 0)
 	}
 	for i, resp := range jsonSaveScript.ExecMulti(ctx, r.client, exec...) {
 		if str, err := resp.ToString(); err != nil {
-			if errs[i] = err; valkey.IsValkeyNil(err) {
-				errs[i] = ErrVersionMismatch
-			}
+			errs[i] = saveErr(err)
 		} else {
 			ver, _ := strconv.ParseInt(str, 10, 64)
 			valf[i].SetInt(ver)
@@ -129,8 +236,121 @@ func (r *JSONRepository[T]) SaveMulti(ctx context.Context, entities ...*T) []err
 }
 
 // Remove the entity under the valkey key of `{prefix}:{id}`.
+// If the schema has `valkey:",lookup"` fields, the entity is also removed from their secondary indexes atomically.
+// If the WithChangeStream is used, a remove event is also added to the stream atomically.
 func (r *JSONRepository[T]) Remove(ctx context.Context, id string) error {
-	return r.client.Do(ctx, r.client.B().Del().Key(key(r.prefix, id)).Build()).Error()
+	if len(r.schema.lookups) == 0 && r.stream == "" {
+		return r.client.Do(ctx, r.client.B().Del().Key(key(r.prefix, id)).Build()).Error()
+	}
+	return r.remove(ctx, id, "")
+}
+
+// SoftRemove marks the entity under the valkey key of `{prefix}:{id}` as deleted instead of deleting it.
+// The marked entity is excluded from Fetch, FetchCache, FetchMulti, FetchMultiCache, FindBy, FindByRange, Search and Aggregate,
+// and it is removed from the secondary indexes. If the WithChangeStream is used, a softremove event is added to the stream.
+// It returns ErrSoftDeleteDisabled if the repository is not created with WithSoftDelete.
+func (r *JSONRepository[T]) SoftRemove(ctx context.Context, id string) error {
+	if !r.softDelete {
+		return ErrSoftDeleteDisabled
+	}
+	return r.remove(ctx, id, strconv.FormatInt(time.Now().UnixMilli(), 10))
+}
+
+func (r *JSONRepository[T]) remove(ctx context.Context, id string, deleted string) error {
+	if r.err != nil {
+		return r.err
+	}
+	keys := append([]string{key(r.prefix, id)}, lookupKeys(r.prefix, r.schema.lookups)...)
+	args := append([]string{deleted}, lookupArgs(id, r.schema.lookups, reflect.Value{}, false)...)
+	if r.stream != "" {
+		keys = append(keys, r.stream)
+		args = append(args, changeArgs(id, r.schema.ver.name, r.maxLen)...)
+	}
+	return jsonRemoveScript.Exec(ctx, r.client, keys, args).Error()
+}
+
+// Migrate eagerly upgrades the entities under `{prefix}:*` whose schema versions are older than the one of WithMigrations, by SCAN in batches.
+// An entity changed concurrently is skipped, and it will still be upgraded lazily when fetched. It returns the number of upgraded entities.
+// The secondary indexes of the `valkey:",lookup"` fields are updated atomically, and if the WithChangeStream is used,
+// a migrate event is also added to the stream.
+func (r *JSONRepository[T]) Migrate(ctx context.Context) (n int64, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	err = scanKeys(ctx, r.client, r.prefix, "ReJSON-RL", func(keys []string) error {
+		cmds := make(valkey.Commands, len(keys))
+		for i, k := range keys {
+			cmds[i] = r.client.B().JsonGet().Key(k).Path(".").Build()
+		}
+		exec := make([]valkey.LuaExec, 0, len(keys))
+		for i, resp := range r.client.DoMulti(ctx, cmds...) {
+			doc, err := resp.ToString()
+			if valkey.IsValkeyNil(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			upgraded, ok, err := upgradeJSON(r.upgrades, doc)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			var ver map[string]json.RawMessage
+			if err = json.Unmarshal([]byte(doc), &ver); err != nil {
+				return err
+			}
+			entity := reflect.Value{}
+			if _, deleted := ver[deletedField]; !deleted && len(r.schema.lookups) > 0 {
+				e, err := r.decode(upgraded)
+				if err != nil {
+					return err
+				}
+				entity = reflect.ValueOf(e).Elem()
+			}
+			id := strings.TrimPrefix(keys[i], r.prefix+":")
+			ks := append([]string{keys[i]}, lookupKeys(r.prefix, r.schema.lookups)...)
+			args := append([]string{r.schema.ver.name, string(ver[r.schema.ver.name]), upgraded}, lookupArgs(id, r.schema.lookups, entity, false)...)
+			if r.stream != "" {
+				ks = append(ks, r.stream)
+				args = append(args, changeArgs(id, r.schema.ver.name, r.maxLen)...)
+			}
+			exec = append(exec, valkey.LuaExec{Keys: ks, Args: args})
+		}
+		if len(exec) == 0 {
+			return nil
+		}
+		for _, resp := range jsonMigrateScript.ExecMulti(ctx, r.client, exec...) {
+			m, err := resp.AsInt64()
+			if err != nil {
+				return err
+			}
+			n += m
+		}
+		return nil
+	})
+	return n, err
+}
+
+// FindBy finds the entities whose `valkey:",lookup"` field equals the value from the secondary index maintained by the repository.
+// It doesn't require the RediSearch module. It panics if the field is not tagged with `valkey:",lookup"`.
+func (r *JSONRepository[T]) FindBy(ctx context.Context, field string, value any) ([]*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	cmd, trim := lookupCmd(r.client.B(), r.schema, r.prefix, field, value, 0, 0)
+	return findBy(ctx, r.client, cmd, trim, r.FetchMulti)
+}
+
+// FindByRange finds the entities whose numeric `valkey:",lookup"` field is within [min, max], ordered by the field.
+func (r *JSONRepository[T]) FindByRange(ctx context.Context, field string, min, max float64) ([]*T, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	cmd, trim := lookupCmd(r.client.B(), r.schema, r.prefix, field, nil, min, max)
+	return findBy(ctx, r.client, cmd, trim, r.FetchMulti)
 }
 
 // AlterIndex uses FT.ALTER from the RediSearch module to alter index under the name `jsonidx:{prefix}`
@@ -143,7 +363,17 @@ func (r *JSONRepository[T]) AlterIndex(ctx context.Context, cmdFn func(alter FtA
 // You can use the cmdFn parameter to mutate the index construction command,
 // and note that the field name should be specified with JSON path syntax; otherwise, the index may not work as expected.
 func (r *JSONRepository[T]) CreateIndex(ctx context.Context, cmdFn func(schema FtCreateSchema) valkey.Completed) error {
-	return r.client.Do(ctx, cmdFn(r.client.B().FtCreate().Index(r.idx).OnJson().Prefix(1).Prefix(r.prefix+":").Schema())).Error()
+	schema := r.client.B().FtCreate().Index(r.idx).OnJson().Prefix(1).Prefix(r.prefix + ":").Schema()
+	if r.softDelete {
+		schema = withDeletedField(schema, true)
+	}
+	return r.client.Do(ctx, cmdFn(schema)).Error()
+}
+
+// CreateIndexFromSchema creates the index under the name `jsonidx:{prefix}` from the fields tagged with `valkey:",index=..."`.
+// If the index already exists, the fields missing from its FT.INFO attributes are added by FT.ALTER.
+func (r *JSONRepository[T]) CreateIndexFromSchema(ctx context.Context) error {
+	return createIndexFromSchema(ctx, r.client, r.idx, r.prefix, true, r.softDelete, r.schema)
 }
 
 // CreateAndAliasIndex creates a new index, aliases it, and drops the old index if needed.
@@ -193,7 +423,11 @@ func (r *JSONRepository[T]) CreateAndAliasIndex(ctx context.Context, cmdFn func(
 		OnJson().
 		Prefix(1).
 		Prefix(r.prefix + ":")
-	if err := r.client.Do(ctx, cmdFn(createCmd.Schema())).Error(); err != nil {
+	schema := createCmd.Schema()
+	if r.softDelete {
+		schema = withDeletedField(schema, true)
+	}
+	if err := r.client.Do(ctx, cmdFn(schema)).Error(); err != nil {
 		return fmt.Errorf("failed to create index %s: %w", newIndex, err)
 	}
 
@@ -230,25 +464,38 @@ func (r *JSONRepository[T]) DropIndex(ctx context.Context) error {
 // 2. the search result, and note that its length might be smaller than the first return value.
 // 3. error if any
 // You can use the cmdFn parameter to mutate the search command.
+// If the WithSoftDelete is used, the entities marked by SoftRemove are excluded by the query,
+// so the index must have the `__deleted` NUMERIC field.
 func (r *JSONRepository[T]) Search(ctx context.Context, cmdFn func(search FtSearchIndex) valkey.Completed) (n int64, s []*T, err error) {
-	n, resp, err := r.client.Do(ctx, cmdFn(r.client.B().FtSearch().Index(r.idx))).AsFtSearch()
+	cmd := cmdFn(r.client.B().FtSearch().Index(r.idx))
+	if r.softDelete {
+		cmd = excludeDeleted(cmd)
+	}
+	n, resp, err := r.client.Do(ctx, cmd).AsFtSearch()
 	if err == nil {
-		s = make([]*T, len(resp))
-		for i, v := range resp {
+		s = make([]*T, 0, len(resp))
+		for _, v := range resp {
 			doc := v.Doc["$"]
 			doc = strings.TrimPrefix(doc, "[") // supports dialect 3
 			doc = strings.TrimSuffix(doc, "]")
-			if s[i], err = r.decode(doc); err != nil {
+			e, err := r.decode(doc)
+			if err != nil {
 				return 0, nil, err
 			}
+			s = append(s, e)
 		}
 	}
 	return n, s, err
 }
 
 // Aggregate performs the FT.AGGREGATE and returns a *AggregateCursor for accessing the results
+// If the WithSoftDelete is used, the entities marked by SoftRemove are excluded by the query.
 func (r *JSONRepository[T]) Aggregate(ctx context.Context, cmdFn func(agg FtAggregateIndex) valkey.Completed) (cursor *AggregateCursor, err error) {
-	cid, total, resp, err := r.client.Do(ctx, cmdFn(r.client.B().FtAggregate().Index(r.idx))).AsFtAggregateCursor()
+	cmd := cmdFn(r.client.B().FtAggregate().Index(r.idx))
+	if r.softDelete {
+		cmd = excludeDeleted(cmd)
+	}
+	cid, total, resp, err := r.client.Do(ctx, cmd).AsFtAggregateCursor()
 	if err != nil {
 		return nil, err
 	}
@@ -260,14 +507,83 @@ func (r *JSONRepository[T]) IndexName() string {
 	return r.idx
 }
 
-var jsonSaveScript = valkey.NewLuaScript(`
+// jsonField reads the top level field of the JSON record in the form of a hash field value for the lookupScript.
+const jsonField = `
+local function jsonField(f)
+  local r = redis.call('JSON.GET',KEYS[1],'$.'..f)
+  if not r then return nil end
+  local v = cjson.decode(r)[1]
+  if v == nil or v == cjson.null then return nil end
+  if type(v) == 'boolean' then return v and 't' or 'f' end
+  return tostring(v)
+end
+`
+
+// jsonChanged returns the sorted names of the top level fields that differ between the old and the new JSON documents, except the ver field.
+const jsonChanged = `
+local function same(a, b)
+  if type(a) ~= type(b) then return false end
+  if type(a) ~= 'table' then return a == b end
+  for k, v in pairs(a) do if not same(v, b[k]) then return false end end
+  for k in pairs(b) do if a[k] == nil then return false end end
+  return true
+end
+local function jsonChanged(old, new, ver)
+  local o, n, changed = {}, cjson.decode(new), {}
+  if old then o = cjson.decode(old)[1] end
+  for k, v in pairs(n) do if k ~= ver and not same(v, o[k]) then table.insert(changed, k) end end
+  for k in pairs(o) do if k ~= ver and n[k] == nil then table.insert(changed, k) end end
+  table.sort(changed)
+  return changed
+end
+`
+
+// jsonSaveScript saves the JSON with optimistic locking. Its ARGV are the ver field path, the ver, the JSON document,
+// the expiry timestamp in milliseconds or an empty string, the lookup section of the lookupScript, and then the change section of the changeScript.
+// It replies the deletedReply error without saving if the JSON is marked as deleted by SoftRemove.
+var jsonSaveScript = valkey.NewLuaScript(lookupScript + changeScript + jsonField + jsonChanged + `
 local v = redis.call('JSON.GET',KEYS[1],ARGV[1])
 if (not v or v == ARGV[2])
 then
+  if v and #redis.call('JSON.TYPE',KEYS[1],'$.` + deletedField + `') > 0 then return redis.error_reply('` + deletedReply + `') end
+  local apply, c = lookup(5, jsonField)
+  local changed = {}
+  if ARGV[c] then changed = jsonChanged(redis.call('JSON.GET',KEYS[1],'$'), ARGV[3], ARGV[1]) end
   redis.call('JSON.SET',KEYS[1],'$',ARGV[3])
   local v = redis.call('JSON.NUMINCRBY',KEYS[1],ARGV[1],1)
-  if #ARGV == 4 then redis.call('PEXPIREAT',KEYS[1],ARGV[4]) end
+  if ARGV[4] ~= '' then redis.call('PEXPIREAT',KEYS[1],ARGV[4]) end
+  apply()
+  change(c, 'save', v, changed)
   return v
 end
 return nil
 `)
+
+// jsonRemoveScript deletes the JSON, or marks it as deleted if ARGV[1] is not empty.
+// The following ARGV are the lookup section of the lookupScript and then the change section of the changeScript.
+var jsonRemoveScript = valkey.NewLuaScript(lookupScript + changeScript + jsonField + `
+local apply, c = lookup(2, jsonField)
+local ver = ARGV[c] and redis.call('JSON.GET',KEYS[1],ARGV[c+2])
+local n, op = 0, 'remove'
+if ARGV[1] == '' then
+  n = redis.call('DEL',KEYS[1])
+elseif redis.call('EXISTS',KEYS[1]) == 1 and redis.call('JSON.SET',KEYS[1],'$.` + deletedField + `',ARGV[1],'NX') then
+  n, op = 1, 'softremove'
+end
+if ARGV[1] == '' or n > 0 then apply() end
+if n > 0 then change(c, op, ver or '0', {}) end
+return n
+`)
+
+// jsonMigrateScript replaces the JSON with the upgraded ARGV[3] if its ver field still equals ARGV[2].
+// The following ARGV are the lookup section of the lookupScript and then the change section of the changeScript.
+var jsonMigrateScript = valkey.NewLuaScript(lookupScript + changeScript + jsonField + jsonChanged + `
+if (redis.call('JSON.GET',KEYS[1],ARGV[1]) or '') ~= ARGV[2] then return 0 end
+local apply, c = lookup(4, jsonField)
+local changed = {}
+if ARGV[c] then changed = jsonChanged(redis.call('JSON.GET',KEYS[1],'$'), ARGV[3], ARGV[1]) end
+redis.call('JSON.SET',KEYS[1],'$',ARGV[3])
+apply()
+change(c, 'migrate', ARGV[2], changed)
+return 1
+`)
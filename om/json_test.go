@@ -55,6 +55,25 @@ func TestNewJsonRepositoryMismatch(t *testing.T) {
 }
 
 //gocyclo:ignore
+func TestHasTopLevelKey(t *testing.T) {
+	for _, c := range []struct {
+		record string
+		want   bool
+	}{
+		{record: `{"a":1,"__deleted":2}`, want: true},
+		{record: `{"__deleted" : 2,"a":1}`, want: true},
+		{record: `{"a":{"__deleted":2}}`},
+		{record: `{"a":[{"__deleted":2}]}`},
+		{record: `{"a":"__deleted"}`},
+		{record: `{"a":"\"__deleted\":1"}`},
+		{record: `{"a":"x"}`},
+	} {
+		if v := hasTopLevelKey(c.record, deletedField); v != c.want {
+			t.Fatalf("unexpected %v for %s", v, c.record)
+		}
+	}
+}
+
 func TestNewJSONRepository(t *testing.T) {
 	ctx := context.Background()
 
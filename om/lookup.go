@@ -0,0 +1,176 @@
+package om
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+// ErrLookupCrossSlot indicates that the repository has `valkey:",lookup"` fields in a valkey cluster, but its prefix has no hash tag.
+// The secondary indexes are updated in the same Lua script as the entity, so they must be in the same slot as all the entities.
+var ErrLookupCrossSlot = errors.New("`valkey:\",lookup\"` fields require a hash tagged prefix, such as {prefix}, in a valkey cluster")
+
+// LookupRepository is a Repository that also finds entities by the secondary indexes of the `valkey:",lookup"` fields.
+// Both HashRepository and JSONRepository implement it.
+type LookupRepository[T any] interface {
+	Repository[T]
+	FindBy(ctx context.Context, field string, value any) ([]*T, error)
+	FindByRange(ctx context.Context, field string, min, max float64) ([]*T, error)
+}
+
+// lookupKey is the key of the secondary index of the `valkey:",lookup"` field, which is a sorted set named `omidx:{prefix}:{field}`.
+// A string or bool field is indexed by members in the form of `{len(value)}:{value}:{id}` with score 0, which are ranged by BYLEX,
+// and a numeric field is indexed by the id members with the value as the score.
+// The key has the same hash tag as the entities if the prefix has one.
+func lookupKey(prefix, name string) string {
+	return "omidx:" + prefix + ":" + name
+}
+
+// lookupKeys generates the KEYS of the secondary indexes for the lookup section of the save and remove scripts.
+func lookupKeys(prefix string, fields []*field) []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = lookupKey(prefix, f.name)
+	}
+	return keys
+}
+
+// lookupMember is the prefix of the members of the string or bool value in the secondary index.
+func lookupMember(value string) string {
+	return strconv.Itoa(len(value)) + ":" + value + ":"
+}
+
+// hashTag returns the hash tag of the key, which determines the slot of the key in a valkey cluster.
+func hashTag(key string) string {
+	if s := strings.IndexByte(key, '{'); s >= 0 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			return key[s+1 : s+1+e]
+		}
+	}
+	return ""
+}
+
+// lookupArgs generates the lookup section of the save and remove scripts for the entity, whose KEYS are generated by the lookupKeys.
+// If the entity is invalid, all the fields are removed from their indexes.
+// A nil pointer field is removed from its index, or left untouched if keepNil is true,
+// which is the case of the HashRepository because HSET doesn't delete the field from the hash either.
+func lookupArgs(id string, fields []*field, entity reflect.Value, keepNil bool) []string {
+	args := make([]string, 2, 2+len(fields)*3)
+	args[0], args[1] = id, strconv.Itoa(len(fields))
+	for _, f := range fields {
+		typ := "s"
+		if k := f.kind(); k != reflect.String && k != reflect.Bool {
+			typ = "z"
+		}
+		if !entity.IsValid() {
+			args = append(args, f.name, typ+"-", "")
+			continue
+		}
+		v := entity.Field(f.idx)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if keepNil {
+					args = append(args, f.name, typ+"=", "")
+				} else {
+					args = append(args, f.name, typ+"-", "")
+				}
+				continue
+			}
+			v = v.Elem()
+		}
+		args = append(args, f.name, typ+"+", scalarToString(v))
+	}
+	return args
+}
+
+// lookupCmd builds the command to find the records whose lookup field equals the value, or is within [min, max] if the value is nil.
+// The ids are the members of the reply without the first trim bytes.
+// It panics if the field is not tagged with `valkey:",lookup"`, or if the value doesn't match the field type.
+func lookupCmd(b valkey.Builder, s schema, prefix, name string, value any, min, max float64) (cmd valkey.Completed, trim int) {
+	f, ok := s.fields[name]
+	if !ok || !f.lookup {
+		panic(fmt.Sprintf("field %q is not tagged with `valkey:\",lookup\"`", name))
+	}
+	k := f.kind()
+	if k == reflect.String || k == reflect.Bool {
+		var v string
+		switch value := value.(type) {
+		case string:
+			v = value
+		case bool:
+			v = scalarToString(reflect.ValueOf(value))
+		default:
+			panic(fmt.Sprintf("unsupported value type %T for field %q", value, name))
+		}
+		m := lookupMember(v)
+		// members starting with m are within [m, m with its last ':' replaced by ';')
+		return b.Zrange().Key(lookupKey(prefix, name)).Min("[" + m).Max("(" + m[:len(m)-1] + ";").Bylex().Build(), len(m)
+	}
+	if value != nil {
+		rv := reflect.ValueOf(value)
+		if !isNumeric(rv.Kind()) {
+			panic(fmt.Sprintf("unsupported value type %T for field %q", value, name))
+		}
+		min = rv.Convert(reflect.TypeOf(float64(0))).Float()
+		max = min
+	}
+	return b.Zrange().Key(lookupKey(prefix, name)).Min(formatNum(min)).Max(formatNum(max)).Byscore().Build(), 0
+}
+
+// findBy finds the records by the ids from the secondary index. The ids whose records no longer exist are skipped.
+func findBy[T any](ctx context.Context, client valkey.Client, cmd valkey.Completed, trim int, fetch func(ctx context.Context, ids ...string) ([]*T, []error)) ([]*T, error) {
+	ids, err := client.Do(ctx, cmd).AsStrSlice()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	for i := range ids {
+		ids[i] = ids[i][trim:]
+	}
+	records, errs := fetch(ctx, ids...)
+	s := records[:0]
+	for i, err := range errs {
+		if IsRecordNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, records[i])
+	}
+	return s, nil
+}
+
+// lookupScript is shared by the save and remove scripts to maintain the secondary indexes atomically.
+// The lookup section of ARGV starts at i with the record id, the number of fields m,
+// and then the triples of field name, operation and value. The operation is one of s+, s-, s=, z+, z- and z=,
+// where = keeps the index untouched. The KEYS of the indexes start at KEYS[2] in the same order as the fields.
+// It reads the old values of string indexed fields by the get function before the write,
+// and returns a function that applies the changes after the write, together with the ARGV index next to the section.
+const lookupScript = `
+local function lookup(i, get)
+  local id, m = ARGV[i], tonumber(ARGV[i+1])
+  local ops = {}
+  for j = 0, m-1 do
+    local a = i+2+j*3
+    local op = {k = KEYS[2+j], f = ARGV[a], t = string.sub(ARGV[a+1],1,1), o = string.sub(ARGV[a+1],2,2), v = ARGV[a+2]}
+    if op.t == 's' and op.o ~= '=' then op.old = get(op.f) end
+    table.insert(ops, op)
+  end
+  local function member(v) return #v..':'..v..':'..id end
+  return function()
+    for _, op in ipairs(ops) do
+      if op.t == 'z' then
+        if op.o == '+' then redis.call('ZADD',op.k,op.v,id) elseif op.o == '-' then redis.call('ZREM',op.k,id) end
+      elseif op.o ~= '=' then
+        if op.old and (op.old ~= op.v or op.o == '-') then redis.call('ZREM',op.k,member(op.old)) end
+        if op.o == '+' then redis.call('ZADD',op.k,0,member(op.v)) end
+      end
+    end
+  end, i+2+m*3
+end
+`
@@ -0,0 +1,159 @@
+package om
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+type LookupStruct struct {
+	Key   string  `json:"key" valkey:",key"`
+	Ver   int64   `json:"ver" valkey:",ver"`
+	Name  *string `json:"name" valkey:",lookup"`
+	Age   int64   `json:"age" valkey:",lookup"`
+	Admin bool    `json:"admin" valkey:",lookup"`
+}
+
+type LookupInvalid struct {
+	Key  string   `valkey:",key"`
+	Ver  int64    `valkey:",ver"`
+	Tags []string `valkey:",lookup"`
+}
+
+func TestLookupArgs(t *testing.T) {
+	s := newSchema(reflect.TypeOf(LookupStruct{}))
+	if v := lookupKeys("{p}", s.lookups); !reflect.DeepEqual(v, []string{"omidx:{p}:name", "omidx:{p}:age", "omidx:{p}:admin"}) {
+		t.Fatalf("unexpected keys %v", v)
+	}
+	name := "a"
+	e := LookupStruct{Key: "1", Name: &name, Age: 3, Admin: true}
+	if v := lookupArgs("1", s.lookups, reflect.ValueOf(e), true); !reflect.DeepEqual(v, []string{
+		"1", "3", "name", "s+", "a", "age", "z+", "3", "admin", "s+", "t",
+	}) {
+		t.Fatalf("unexpected args %v", v)
+	}
+	e.Name = nil
+	if v := lookupArgs("1", s.lookups, reflect.ValueOf(e), true); !reflect.DeepEqual(v, []string{
+		"1", "3", "name", "s=", "", "age", "z+", "3", "admin", "s+", "t",
+	}) {
+		t.Fatalf("unexpected args %v", v)
+	}
+	if v := lookupArgs("1", s.lookups, reflect.ValueOf(e), false); !reflect.DeepEqual(v, []string{
+		"1", "3", "name", "s-", "", "age", "z+", "3", "admin", "s+", "t",
+	}) {
+		t.Fatalf("unexpected args %v", v)
+	}
+	if v := lookupArgs("1", s.lookups, reflect.Value{}, false); !reflect.DeepEqual(v, []string{
+		"1", "3", "name", "s-", "", "age", "z-", "", "admin", "s-", "",
+	}) {
+		t.Fatalf("unexpected args %v", v)
+	}
+}
+
+func TestLookupCmd(t *testing.T) {
+	s := newSchema(reflect.TypeOf(LookupStruct{}))
+	b := cmds.NewBuilder(cmds.NoSlot)
+	for _, c := range []struct {
+		value    any
+		name     string
+		want     []string
+		trim     int
+		min, max float64
+	}{
+		{name: "name", value: "a", want: []string{"ZRANGE", "omidx:p:name", "[1:a:", "(1:a;", "BYLEX"}, trim: 4},
+		{name: "name", value: "ab:c", want: []string{"ZRANGE", "omidx:p:name", "[4:ab:c:", "(4:ab:c;", "BYLEX"}, trim: 7},
+		{name: "admin", value: false, want: []string{"ZRANGE", "omidx:p:admin", "[1:f:", "(1:f;", "BYLEX"}, trim: 4},
+		{name: "age", value: 3, want: []string{"ZRANGE", "omidx:p:age", "3", "3", "BYSCORE"}},
+		{name: "age", min: 1, max: 2.5, want: []string{"ZRANGE", "omidx:p:age", "1", "2.5", "BYSCORE"}},
+	} {
+		cmd, trim := lookupCmd(b, s, "p", c.name, c.value, c.min, c.max)
+		if v := cmd.Commands(); !reflect.DeepEqual(v, c.want) || trim != c.trim {
+			t.Fatalf("unexpected command %v %v", v, trim)
+		}
+	}
+	for _, c := range []struct {
+		fn  func()
+		msg string
+	}{
+		{fn: func() { lookupCmd(b, s, "p", "Key", "a", 0, 0) }, msg: "is not tagged with"},
+		{fn: func() { lookupCmd(b, s, "p", "name", 1, 0, 0) }, msg: "unsupported value type"},
+		{fn: func() { lookupCmd(b, s, "p", "age", "a", 0, 0) }, msg: "unsupported value type"},
+		{fn: func() { newSchema(reflect.TypeOf(LookupInvalid{})) }, msg: "should be a string, bool or number"},
+	} {
+		if v := recovered(c.fn); !strings.Contains(v, c.msg) {
+			t.Fatalf("unexpected msg %v", v)
+		}
+	}
+}
+
+func TestHashTag(t *testing.T) {
+	for _, c := range []struct {
+		key, tag string
+	}{
+		{key: "p", tag: ""},
+		{key: "{p}", tag: "p"},
+		{key: "omidx:{p}:name", tag: "p"},
+		{key: "a{}b{c}", tag: ""},
+		{key: "a{b", tag: ""},
+	} {
+		if v := hashTag(c.key); v != c.tag {
+			t.Fatalf("unexpected tag %q of %q", v, c.key)
+		}
+	}
+}
+
+func TestFindBy(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, repo := range []LookupRepository[LookupStruct]{
+		NewHashRepository("hashlookup", LookupStruct{}, client).(LookupRepository[LookupStruct]),
+		NewJSONRepository("jsonlookup", LookupStruct{}, client).(LookupRepository[LookupStruct]),
+	} {
+		a, b := "a", "b"
+		e1, e2 := repo.NewEntity(), repo.NewEntity()
+		e1.Name, e1.Age, e1.Admin = &a, 10, true
+		e2.Name, e2.Age = &a, 20
+		for _, err := range repo.SaveMulti(ctx, e1, e2) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if records, err := repo.FindBy(ctx, "name", "a"); err != nil || len(records) != 2 {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+
+		e1.Name = &b
+		if err := repo.Save(ctx, e1); err != nil {
+			t.Fatal(err)
+		}
+		if records, err := repo.FindBy(ctx, "name", "a"); err != nil || len(records) != 1 || !reflect.DeepEqual(records[0], e2) {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+		if records, err := repo.FindBy(ctx, "name", "b"); err != nil || len(records) != 1 || !reflect.DeepEqual(records[0], e1) {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+		if records, err := repo.FindBy(ctx, "admin", true); err != nil || len(records) != 1 || !reflect.DeepEqual(records[0], e1) {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+		if records, err := repo.FindByRange(ctx, "age", 0, 100); err != nil || len(records) != 2 || records[0].Age != 10 || records[1].Age != 20 {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+
+		if err := repo.Remove(ctx, e1.Key); err != nil {
+			t.Fatal(err)
+		}
+		if records, err := repo.FindBy(ctx, "name", "b"); err != nil || len(records) != 0 {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+		if records, err := repo.FindBy(ctx, "age", 10); err != nil || len(records) != 0 {
+			t.Fatalf("unexpected result %v %v", records, err)
+		}
+	}
+}
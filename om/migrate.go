@@ -0,0 +1,156 @@
+package om
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+// schemaField is the field storing the schema version of the entity, which is the number of upgrades passed to WithMigrations.
+const schemaField = "__schema"
+
+// MigrateRepository is a Repository that also upgrades the stored entities eagerly with the upgrades of WithMigrations.
+// Both HashRepository and JSONRepository implement it.
+type MigrateRepository[T any] interface {
+	Repository[T]
+	Migrate(ctx context.Context) (n int64, err error)
+}
+
+// Upgrade upgrades a stored record from its schema version to the next one in place, such as renaming or filling fields.
+// The values of a hash record are strings, and the values of a JSON record are decoded by encoding/json with json.Number.
+type Upgrade func(record map[string]any) error
+
+// WithMigrations makes every saved entity carry its schema version, which is the number of the upgrades,
+// and the upgrades[i] upgrades a stored record from version i to version i+1. Records without a version are version 0.
+// Older records are upgraded lazily in memory when fetched or searched, and they are persisted with the current version on the next save,
+// or eagerly by the Migrate method of the repository.
+func WithMigrations(upgrades ...Upgrade) RepositoryOption {
+	return func(r Repository[any]) {
+		switch repo := r.(type) {
+		case *HashRepository[any]:
+			repo.upgrades = upgrades
+		case *JSONRepository[any]:
+			repo.upgrades = upgrades
+		}
+	}
+}
+
+// upgrade applies the upgrades[from:] to the record, and then sets the record to the current version.
+func upgrade(upgrades []Upgrade, from int64, record map[string]any) error {
+	delete(record, schemaField)
+	for i := from; i < int64(len(upgrades)); i++ {
+		if err := upgrades[i](record); err != nil {
+			return fmt.Errorf("failed to upgrade record from schema version %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// upgradeHash upgrades the hash record if its version is older. It returns false if the record is already up to date.
+func upgradeHash(upgrades []Upgrade, fields map[string]string) (map[string]string, bool, error) {
+	from, _ := strconv.ParseInt(fields[schemaField], 10, 64)
+	if from >= int64(len(upgrades)) {
+		return fields, false, nil
+	}
+	record := make(map[string]any, len(fields))
+	for k, v := range fields {
+		record[k] = v
+	}
+	if err := upgrade(upgrades, from, record); err != nil {
+		return nil, false, err
+	}
+	fields = make(map[string]string, len(record)+1)
+	for k, v := range record {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			fields[k] = v
+		default:
+			fields[k] = fmt.Sprint(v)
+		}
+	}
+	fields[schemaField] = strconv.Itoa(len(upgrades))
+	return fields, true, nil
+}
+
+// upgradeJSON upgrades the JSON record if its version is older. It returns false if the record is already up to date.
+func upgradeJSON(upgrades []Upgrade, doc string) (string, bool, error) {
+	var version struct {
+		Schema int64 `json:"__schema"`
+	}
+	if err := json.Unmarshal([]byte(doc), &version); err != nil || version.Schema >= int64(len(upgrades)) {
+		return doc, false, err
+	}
+	var record map[string]any
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&record); err != nil {
+		return "", false, err
+	}
+	if err := upgrade(upgrades, version.Schema, record); err != nil {
+		return "", false, err
+	}
+	record[schemaField] = len(upgrades)
+	bs, err := json.Marshal(record)
+	return string(bs), true, err
+}
+
+// withSchemaField adds the schema version to the JSON object encoded from the entity.
+func withSchemaField(doc string, version int) string {
+	field := `{"` + schemaField + `":` + strconv.Itoa(version)
+	if doc == "{}" {
+		return field + "}"
+	}
+	return field + "," + doc[1:]
+}
+
+// scanKeys scans the keys under `{prefix}:*` of the type in batches on every primary node.
+func scanKeys(ctx context.Context, client valkey.Client, prefix, typ string, fn func(keys []string) error) error {
+	nodes := map[string]valkey.Client{"": client}
+	if client.Mode() == valkey.ClientModeCluster {
+		nodes = client.Nodes()
+	}
+	for _, node := range nodes {
+		if client.Mode() == valkey.ClientModeCluster {
+			role, err := node.Do(ctx, node.B().Role().Build()).ToArray()
+			if err != nil {
+				return err
+			}
+			if r, _ := role[0].ToString(); r != "master" {
+				continue
+			}
+		}
+		var cursor uint64
+		for {
+			entry, err := node.Do(ctx, node.B().Scan().Cursor(cursor).Match(escapeGlob(prefix)+":*").Count(100).Type(typ).Build()).AsScanEntry()
+			if err != nil {
+				return err
+			}
+			if len(entry.Elements) > 0 {
+				if err = fn(entry.Elements); err != nil {
+					return err
+				}
+			}
+			if cursor = entry.Cursor; cursor == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func escapeGlob(pattern string) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(pattern[i])
+	}
+	return sb.String()
+}
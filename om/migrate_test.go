@@ -0,0 +1,185 @@
+package om
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type MigrateV1 struct {
+	Key  string `json:"key" valkey:",key"`
+	Ver  int64  `json:"ver" valkey:",ver"`
+	Name string `json:"name"`
+}
+
+type MigrateV2 struct {
+	Key      string `json:"key" valkey:",key"`
+	Ver      int64  `json:"ver" valkey:",ver"`
+	FullName string `json:"full_name"`
+	Age      int64  `json:"age"`
+}
+
+var migrateV2 = []Upgrade{
+	func(record map[string]any) error {
+		record["full_name"] = record["name"]
+		delete(record, "name")
+		return nil
+	},
+	func(record map[string]any) error {
+		record["age"] = 18
+		return nil
+	},
+}
+
+func TestUpgrade(t *testing.T) {
+	fields, ok, err := upgradeHash(migrateV2, map[string]string{"key": "1", "ver": "1", "name": "a"})
+	if err != nil || !ok || !reflect.DeepEqual(fields, map[string]string{"key": "1", "ver": "1", "full_name": "a", "age": "18", "__schema": "2"}) {
+		t.Fatalf("unexpected upgrade %v %v %v", fields, ok, err)
+	}
+	fields, ok, err = upgradeHash(migrateV2, map[string]string{"key": "1", "__schema": "1", "full_name": "a"})
+	if err != nil || !ok || !reflect.DeepEqual(fields, map[string]string{"key": "1", "full_name": "a", "age": "18", "__schema": "2"}) {
+		t.Fatalf("unexpected upgrade %v %v %v", fields, ok, err)
+	}
+	if _, ok, err = upgradeHash(migrateV2, map[string]string{"__schema": "2"}); ok || err != nil {
+		t.Fatalf("unexpected upgrade %v %v", ok, err)
+	}
+
+	doc, ok, err := upgradeJSON(migrateV2, `{"key":"1","ver":12345678901234567,"name":"a"}`)
+	if err != nil || !ok || doc != `{"__schema":2,"age":18,"full_name":"a","key":"1","ver":12345678901234567}` {
+		t.Fatalf("unexpected upgrade %v %v %v", doc, ok, err)
+	}
+	if _, ok, err = upgradeJSON(migrateV2, `{"__schema":2}`); ok || err != nil {
+		t.Fatalf("unexpected upgrade %v %v", ok, err)
+	}
+	if _, _, err = upgradeJSON(migrateV2, `{`); err == nil {
+		t.Fatalf("upgrade not failed as expected")
+	}
+
+	fail := errors.New("fail")
+	if _, _, err = upgradeHash([]Upgrade{func(map[string]any) error { return fail }}, nil); !errors.Is(err, fail) {
+		t.Fatalf("unexpected err %v", err)
+	}
+
+	if v := withSchemaField("{}", 2); v != `{"__schema":2}` {
+		t.Fatalf("unexpected doc %v", v)
+	}
+	if v := withSchemaField(`{"a":1}`, 2); !json.Valid([]byte(v)) || v != `{"__schema":2,"a":1}` {
+		t.Fatalf("unexpected doc %v", v)
+	}
+	if v := escapeGlob("a*b?[c]\\"); v != `a\*b\?\[c\]\\` {
+		t.Fatalf("unexpected pattern %v", v)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, c := range []struct {
+		v1 Repository[MigrateV1]
+		v2 MigrateRepository[MigrateV2]
+	}{
+		{v1: NewHashRepository("hashmigrate", MigrateV1{}, client), v2: NewHashRepository("hashmigrate", MigrateV2{}, client, WithMigrations(migrateV2...)).(MigrateRepository[MigrateV2])},
+		{v1: NewJSONRepository("jsonmigrate", MigrateV1{}, client), v2: NewJSONRepository("jsonmigrate", MigrateV2{}, client, WithMigrations(migrateV2...)).(MigrateRepository[MigrateV2])},
+	} {
+		ids := make([]string, 150)
+		for i := range ids {
+			e := c.v1.NewEntity()
+			e.Name = "a"
+			if err := c.v1.Save(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+			ids[i] = e.Key
+		}
+
+		e, err := c.v2.Fetch(ctx, ids[0])
+		if err != nil || e.FullName != "a" || e.Age != 18 || e.Ver != 1 {
+			t.Fatalf("unexpected lazy upgrade %v %v", e, err)
+		}
+		if n, err := c.v2.Migrate(ctx); err != nil || n != int64(len(ids)) {
+			t.Fatalf("unexpected migrate %v %v", n, err)
+		}
+		if n, err := c.v2.Migrate(ctx); err != nil || n != 0 {
+			t.Fatalf("unexpected migrate %v %v", n, err)
+		}
+		records, errs := c.v2.FetchMulti(ctx, ids...)
+		for i, err := range errs {
+			if err != nil || records[i].FullName != "a" || records[i].Age != 18 {
+				t.Fatalf("unexpected record %v %v", records[i], err)
+			}
+		}
+		if err = c.v2.Save(ctx, records[0]); err != nil {
+			t.Fatal(err)
+		}
+		if v1, err := c.v1.Fetch(ctx, ids[1]); err != nil || v1.Name != "" {
+			t.Fatalf("unexpected record %v %v", v1, err)
+		}
+	}
+}
+
+type MigrateLookup struct {
+	Key      string `json:"key" valkey:",key"`
+	Ver      int64  `json:"ver" valkey:",ver"`
+	FullName string `json:"full_name" valkey:",lookup"`
+}
+
+func TestMigrateLookupAndChange(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, c := range []struct {
+		v1     Repository[MigrateV1]
+		v2     Repository[MigrateLookup]
+		stream string
+	}{
+		{
+			v1:     NewHashRepository("{hashmigratelookup}", MigrateV1{}, client, WithSoftDelete()),
+			v2:     NewHashRepository("{hashmigratelookup}", MigrateLookup{}, client, WithMigrations(migrateV2[0]), WithChangeStream("{hashmigratelookup}:changes", 0)),
+			stream: "{hashmigratelookup}:changes",
+		},
+		{
+			v1:     NewJSONRepository("{jsonmigratelookup}", MigrateV1{}, client, WithSoftDelete()),
+			v2:     NewJSONRepository("{jsonmigratelookup}", MigrateLookup{}, client, WithMigrations(migrateV2[0]), WithChangeStream("{jsonmigratelookup}:changes", 0)),
+			stream: "{jsonmigratelookup}:changes",
+		},
+	} {
+		e, d := c.v1.NewEntity(), c.v1.NewEntity()
+		e.Name, d.Name = "a", "a"
+		for _, err := range c.v1.SaveMulti(ctx, e, d) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := c.v1.SoftRemove(ctx, d.Key); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := c.v2.(MigrateRepository[MigrateLookup]).Migrate(ctx); err != nil || n != 2 {
+			t.Fatalf("unexpected migrate %v %v", n, err)
+		}
+		records, err := c.v2.(LookupRepository[MigrateLookup]).FindBy(ctx, "full_name", "a")
+		if err != nil || len(records) != 1 || records[0].Key != e.Key {
+			t.Fatalf("unexpected records %v %v", records, err)
+		}
+
+		entries, err := client.Do(ctx, client.B().Xrange().Key(c.stream).Start("-").End("+").Build()).AsXRange()
+		if err != nil || len(entries) != 2 {
+			t.Fatalf("unexpected entries %v %v", entries, err)
+		}
+		for _, entry := range entries {
+			if entry.FieldValues["op"] != "migrate" || entry.FieldValues["ver"] != "1" {
+				t.Fatalf("unexpected entry %v", entry.FieldValues)
+			}
+			if entry.FieldValues["id"] == e.Key && entry.FieldValues["fields"] != `["__schema","full_name","name"]` {
+				t.Fatalf("unexpected entry %v", entry.FieldValues)
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package om
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+// Query is a typed FT.SEARCH query builder generated from the schema of T.
+// Field names can be either the Go struct field names or their stored names, and values are escaped automatically.
+// Referring to an unknown field, or using a condition that doesn't match the field type, panics.
+//
+//	om.Q[Example]().Where("Age").Between(18, 30).And("Tags").In("a", "b").SortBy("Age")
+type Query[T any] struct {
+	typ    reflect.Type
+	schema schema
+	expr   string
+	conj   string
+	sortBy string
+	offset int64
+	num    int64
+	desc   bool
+	limit  bool
+	hasOr  bool
+}
+
+// Condition is a pending condition on a field of the Query. It is completed by one of its predicate methods.
+type Condition[T any] struct {
+	q     *Query[T]
+	field *field
+	not   bool
+}
+
+// Q creates a Query for the entity type T.
+func Q[T any]() *Query[T] {
+	var v T
+	typ := reflect.TypeOf(v)
+	return &Query[T]{typ: typ, schema: newSchema(typ)}
+}
+
+// Where starts a condition on the field. It is the same as And.
+func (q *Query[T]) Where(field string) *Condition[T] {
+	return q.And(field)
+}
+
+// And starts a condition on the field, which is required together with previous conditions.
+func (q *Query[T]) And(field string) *Condition[T] {
+	q.conj = " "
+	return &Condition[T]{q: q, field: q.lookup(field)}
+}
+
+// Or starts a condition on the field, which is an alternative to previous conditions.
+func (q *Query[T]) Or(field string) *Condition[T] {
+	q.conj = " | "
+	return &Condition[T]{q: q, field: q.lookup(field)}
+}
+
+// SortBy sorts the results by the field in ascending order. The field should be indexed with SORTABLE.
+func (q *Query[T]) SortBy(field string) *Query[T] {
+	q.sortBy, q.desc = q.lookup(field).name, false
+	return q
+}
+
+// SortByDesc sorts the results by the field in descending order. The field should be indexed with SORTABLE.
+func (q *Query[T]) SortByDesc(field string) *Query[T] {
+	q.sortBy, q.desc = q.lookup(field).name, true
+	return q
+}
+
+// Limit pages the results by skipping the first offset results and returning at most num results.
+func (q *Query[T]) Limit(offset, num int64) *Query[T] {
+	q.offset, q.num, q.limit = offset, num, true
+	return q
+}
+
+// String returns the query string of the FT.SEARCH.
+func (q *Query[T]) String() string {
+	if q.expr == "" {
+		return "*"
+	}
+	return q.expr
+}
+
+// Build builds the FT.SEARCH command. It can be passed to Repository.Search directly.
+func (q *Query[T]) Build(search FtSearchIndex) valkey.Completed {
+	var tail interface {
+		Limit() cmds.FtSearchLimitLimit
+		Build() cmds.Completed
+	}
+	query := search.Query(q.String())
+	switch {
+	case q.sortBy != "" && q.desc:
+		tail = query.Sortby(q.sortBy).Desc()
+	case q.sortBy != "":
+		tail = query.Sortby(q.sortBy).Asc()
+	default:
+		tail = query
+	}
+	if q.limit {
+		return tail.Limit().OffsetNum(q.offset, q.num).Build()
+	}
+	return tail.Build()
+}
+
+// Find executes the query against the repository. It returns the total count of matched results and a page of them.
+func (q *Query[T]) Find(ctx context.Context, repo Repository[T]) (int64, []*T, error) {
+	return repo.Search(ctx, q.Build)
+}
+
+func (q *Query[T]) lookup(name string) *field {
+	if f, ok := q.schema.fields[name]; ok {
+		return f
+	}
+	if sf, ok := q.typ.FieldByName(name); ok {
+		if f, ok := q.schema.fields[parse(sf).name]; ok {
+			return f
+		}
+	}
+	panic(fmt.Sprintf("schema %q has no field %q", q.typ, name))
+}
+
+func (q *Query[T]) add(clause string) *Query[T] {
+	switch {
+	case q.expr == "":
+		q.expr = clause
+	case q.conj == " | ":
+		q.expr = "(" + q.expr + ") | (" + clause + ")"
+		q.hasOr = true
+	case q.hasOr:
+		q.expr = "(" + q.expr + ") " + clause
+		q.hasOr = false
+	default:
+		q.expr = q.expr + " " + clause
+	}
+	return q
+}
+
+// Not negates the condition.
+func (c *Condition[T]) Not() *Condition[T] {
+	c.not = !c.not
+	return c
+}
+
+// Eq matches the field equal to the value. String values are matched as a tag, or as an exact phrase
+// if the field is tagged with `valkey:",index=text"`, and numeric values are matched as a numeric range.
+func (c *Condition[T]) Eq(value any) *Query[T] {
+	switch v := value.(type) {
+	case string:
+		if c.field.index != nil && c.field.index.typ == "TEXT" {
+			return c.clause("\"" + escapeQuery(v) + "\"")
+		}
+		return c.In(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		n := reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0))).Float()
+		return c.Between(n, n)
+	}
+	panic(fmt.Sprintf("unsupported value type %T for field %q", value, c.field.name))
+}
+
+// In matches the tag field equal to any of the values.
+func (c *Condition[T]) In(values ...string) *Query[T] {
+	c.expect("TAG", reflect.String)
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = escapeQuery(v)
+	}
+	return c.clause("{" + strings.Join(escaped, " | ") + "}")
+}
+
+// Match matches the text field containing all the words of the text.
+func (c *Condition[T]) Match(text string) *Query[T] {
+	c.expect("TEXT", reflect.String)
+	words := strings.Fields(text)
+	for i, w := range words {
+		words[i] = escapeQuery(w)
+	}
+	return c.clause("(" + strings.Join(words, " ") + ")")
+}
+
+// Between matches the numeric field within [min, max].
+func (c *Condition[T]) Between(min, max float64) *Query[T] {
+	return c.numeric(formatNum(min), formatNum(max))
+}
+
+// Gt matches the numeric field greater than the value.
+func (c *Condition[T]) Gt(value float64) *Query[T] {
+	return c.numeric("("+formatNum(value), "+inf")
+}
+
+// Gte matches the numeric field greater than or equal to the value.
+func (c *Condition[T]) Gte(value float64) *Query[T] {
+	return c.numeric(formatNum(value), "+inf")
+}
+
+// Lt matches the numeric field less than the value.
+func (c *Condition[T]) Lt(value float64) *Query[T] {
+	return c.numeric("-inf", "("+formatNum(value))
+}
+
+// Lte matches the numeric field less than or equal to the value.
+func (c *Condition[T]) Lte(value float64) *Query[T] {
+	return c.numeric("-inf", formatNum(value))
+}
+
+// Within matches the geo field within the radius of the point. The unit should be one of m, km, mi and ft.
+func (c *Condition[T]) Within(lon, lat, radius float64, unit string) *Query[T] {
+	c.expect("GEO", reflect.String)
+	switch unit {
+	case "m", "km", "mi", "ft":
+	default:
+		panic(fmt.Sprintf("unsupported geo unit %q", unit))
+	}
+	return c.clause("[" + formatNum(lon) + " " + formatNum(lat) + " " + formatNum(radius) + " " + unit + "]")
+}
+
+func (c *Condition[T]) numeric(min, max string) *Query[T] {
+	c.expect("NUMERIC", reflect.Int64)
+	return c.clause("[" + min + " " + max + "]")
+}
+
+func (c *Condition[T]) clause(v string) *Query[T] {
+	clause := "@" + c.field.name + ":" + v
+	if c.not {
+		clause = "-" + clause
+	}
+	return c.q.add(clause)
+}
+
+// expect panics if the field is tagged with another index type, or if it is not tagged and its Go kind doesn't match.
+func (c *Condition[T]) expect(typ string, kind reflect.Kind) {
+	if c.field.index != nil {
+		if c.field.index.typ != typ {
+			panic(fmt.Sprintf("field %q is indexed as %s, not %s", c.field.name, c.field.index.typ, typ))
+		}
+		return
+	}
+	if k := c.field.kind(); k != kind && !(kind == reflect.Int64 && isNumeric(k)) {
+		panic(fmt.Sprintf("field %q of type %s can't be queried as %s", c.field.name, c.field.typ, typ))
+	}
+}
+
+func formatNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// escapeQuery escapes all the punctuations and whitespaces of the value with backslashes,
+// so that it is always treated as a literal in the query syntax.
+func escapeQuery(v string) string {
+	sb := strings.Builder{}
+	sb.Grow(len(v))
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c < 0x80 && !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package om
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+type q1 struct {
+	Key  string  `json:"key" valkey:",key"`
+	Ver  int64   `json:"ver" valkey:",ver"`
+	Name string  `json:"name" valkey:",index=text"`
+	Tags string  `json:"tags" valkey:",index=tag"`
+	Age  int64   `json:"age" valkey:",index=numeric,sortable"`
+	Loc  string  `json:"loc" valkey:",index=geo"`
+	Cat  string  `json:"cat"`
+	Rate float64 `json:"rate"`
+}
+
+func TestQuery(t *testing.T) {
+	build := func(q *Query[q1]) []string {
+		cmd := q.Build(cmds.NewBuilder(cmds.NoSlot).FtSearch().Index("idx"))
+		return cmd.Commands()
+	}
+	for _, c := range []struct {
+		q    *Query[q1]
+		want []string
+	}{
+		{q: Q[q1](), want: []string{"FT.SEARCH", "idx", "*"}},
+		{
+			q:    Q[q1]().Where("Age").Between(18, 30).And("Tags").In("a", "b").SortBy("Age"),
+			want: []string{"FT.SEARCH", "idx", "@age:[18 30] @tags:{a | b}", "SORTBY", "age", "ASC"},
+		},
+		{
+			q:    Q[q1]().Where("age").Gt(1.5).Or("Cat").Eq("x-y").And("Rate").Lte(3).SortByDesc("age").Limit(10, 20),
+			want: []string{"FT.SEARCH", "idx", "((@age:[(1.5 +inf]) | (@cat:{x\\-y})) @rate:[-inf 3]", "SORTBY", "age", "DESC", "LIMIT", "10", "20"},
+		},
+		{
+			q:    Q[q1]().Where("Name").Match("hello wor.ld").And("Name").Not().Eq("a b").Limit(0, 5),
+			want: []string{"FT.SEARCH", "idx", "@name:(hello wor\\.ld) -@name:\"a\\ b\"", "LIMIT", "0", "5"},
+		},
+		{
+			q:    Q[q1]().Where("Loc").Within(-122.4, 37.7, 5, "km").And("Age").Eq(20).And("Rate").Gte(1).And("Age").Lt(65),
+			want: []string{"FT.SEARCH", "idx", "@loc:[-122.4 37.7 5 km] @age:[20 20] @rate:[1 +inf] @age:[-inf (65]"},
+		},
+	} {
+		if got := build(c.q); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("unexpected command %q, want %q", got, c.want)
+		}
+	}
+}
+
+func TestQueryPanic(t *testing.T) {
+	for _, c := range []struct {
+		fn  func()
+		msg string
+	}{
+		{fn: func() { Q[q1]().Where("Nope") }, msg: "has no field"},
+		{fn: func() { Q[q1]().Where("Tags").Between(1, 2) }, msg: "is indexed as TAG"},
+		{fn: func() { Q[q1]().Where("Rate").In("a") }, msg: "can't be queried as TAG"},
+		{fn: func() { Q[q1]().Where("Age").Eq(true) }, msg: "unsupported value type"},
+		{fn: func() { Q[q1]().Where("Loc").Within(0, 0, 1, "yd") }, msg: "unsupported geo unit"},
+	} {
+		if v := recovered(c.fn); !strings.Contains(v, c.msg) {
+			t.Fatalf("unexpected msg %v", v)
+		}
+	}
+}
@@ -27,11 +27,48 @@ var (
 	ErrVersionMismatch = errors.New("object version mismatched, please retry")
 	// ErrEmptyHashRecord indicates the requested hash entity is not found.
 	ErrEmptyHashRecord = errors.New("hash object not found")
+	// ErrRecordDeleted indicates the requested entity is marked as deleted by SoftRemove.
+	ErrRecordDeleted = errors.New("object soft deleted")
+	// ErrSoftDeleteDisabled indicates that SoftRemove is called on a repository created without WithSoftDelete.
+	ErrSoftDeleteDisabled = errors.New("soft delete is not enabled, please use WithSoftDelete")
 )
 
+// deletedField is the field marking the entity as deleted by SoftRemove.
+const deletedField = "__deleted"
+
+// deletedReply is the error replied by the save scripts if the entity is marked as deleted by SoftRemove.
+const deletedReply = "OMDELETED the object is soft deleted"
+
+// saveErr converts the error replied by the save scripts.
+func saveErr(err error) error {
+	if valkey.IsValkeyNil(err) {
+		return ErrVersionMismatch
+	}
+	if ret, ok := valkey.IsValkeyErr(err); ok && ret.Error() == deletedReply {
+		return ErrRecordDeleted
+	}
+	return err
+}
+
+// checkSlots checks that the lookup indexes and the change stream written together with the entities in the same Lua script
+// are in the same slot as all the entities in a valkey cluster, which is only possible if they share the hash tag of the prefix.
+func checkSlots(client valkey.Client, s schema, prefix, stream string) error {
+	if (len(s.lookups) == 0 && stream == "") || client.Mode() != valkey.ClientModeCluster {
+		return nil
+	}
+	tag := hashTag(prefix)
+	if len(s.lookups) != 0 && tag == "" {
+		return ErrLookupCrossSlot
+	}
+	if stream != "" && (tag == "" || hashTag(stream) != tag) {
+		return ErrChangeStreamCrossSlot
+	}
+	return nil
+}
+
 // IsRecordNotFound checks if the error is indicating the requested entity is not found.
 func IsRecordNotFound(err error) bool {
-	return valkey.IsValkeyNil(err) || err == ErrEmptyHashRecord
+	return valkey.IsValkeyNil(err) || err == ErrEmptyHashRecord || err == ErrRecordDeleted
 }
 
 // Repository is backed by HashRepository or JSONRepository
@@ -39,12 +76,17 @@ type Repository[T any] interface {
 	NewEntity() (entity *T)
 	Fetch(ctx context.Context, id string) (*T, error)
 	FetchCache(ctx context.Context, id string, ttl time.Duration) (v *T, err error)
+	FetchMulti(ctx context.Context, ids ...string) ([]*T, []error)
+	FetchMultiCache(ctx context.Context, ttl time.Duration, ids ...string) ([]*T, []error)
 	Search(ctx context.Context, cmdFn func(search FtSearchIndex) valkey.Completed) (int64, []*T, error)
 	Aggregate(ctx context.Context, cmdFn func(agg FtAggregateIndex) valkey.Completed) (*AggregateCursor, error)
 	Save(ctx context.Context, entity *T) (err error)
+	SaveWithTTL(ctx context.Context, entity *T, ttl time.Duration) (err error)
 	SaveMulti(ctx context.Context, entity ...*T) (errs []error)
 	Remove(ctx context.Context, id string) error
+	SoftRemove(ctx context.Context, id string) error
 	CreateIndex(ctx context.Context, cmdFn func(schema FtCreateSchema) valkey.Completed) error
+	CreateIndexFromSchema(ctx context.Context) error
 	CreateAndAliasIndex(ctx context.Context, cmdFn func(schema FtCreateSchema) valkey.Completed) error
 	AlterIndex(ctx context.Context, cmdFn func(alter FtAlterIndex) valkey.Completed) error
 	DropIndex(ctx context.Context) error
@@ -53,6 +95,20 @@ type Repository[T any] interface {
 
 type RepositoryOption func(Repository[any])
 
+// WithSoftDelete enables SoftRemove. The `__deleted` NUMERIC field marking the removed entities is added to the indexes
+// created by CreateIndex, CreateAndAliasIndex and CreateIndexFromSchema, and Search and Aggregate exclude the marked entities
+// by their queries. Therefore, an existing index must have the field before the option is used.
+func WithSoftDelete() RepositoryOption {
+	return func(r Repository[any]) {
+		switch repo := r.(type) {
+		case *HashRepository[any]:
+			repo.softDelete = true
+		case *JSONRepository[any]:
+			repo.softDelete = true
+		}
+	}
+}
+
 func WithIndexName(name string) RepositoryOption {
 	return func(r Repository[any]) {
 		switch repo := r.(type) {
@@ -1,7 +1,11 @@
 package om
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/valkey-io/valkey-go"
 )
@@ -32,3 +36,143 @@ func TestWithIndexName(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchMultiAndSoftRemove(t *testing.T) {
+	ctx := context.Background()
+
+	client := setup(t)
+	client.Do(ctx, client.B().Flushall().Build())
+	defer client.Close()
+
+	for _, repo := range []Repository[TestStruct]{
+		NewHashRepository("hashsoft", TestStruct{}, client),
+		NewJSONRepository("jsonsoft", TestStruct{}, client),
+	} {
+		if err := repo.SoftRemove(ctx, "any"); err != ErrSoftDeleteDisabled {
+			t.Fatalf("unexpected err %v", err)
+		}
+	}
+
+	for _, repo := range []Repository[TestStruct]{
+		NewHashRepository("hashsoft", TestStruct{}, client, WithSoftDelete()),
+		NewJSONRepository("jsonsoft", TestStruct{}, client, WithSoftDelete()),
+	} {
+		field := "Ver"
+		if _, ok := repo.(*JSONRepository[TestStruct]); ok {
+			field = "$.Ver"
+		}
+		if err := repo.CreateIndex(ctx, func(schema FtCreateSchema) valkey.Completed {
+			return schema.FieldName(field).As("Ver").Numeric().Build()
+		}); err != nil {
+			t.Fatal(err)
+		}
+		e1, e2 := repo.NewEntity(), repo.NewEntity()
+		for _, err := range repo.SaveMulti(ctx, e1, e2) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := repo.SaveWithTTL(ctx, e2, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		for _, fetch := range []func(ids ...string) ([]*TestStruct, []error){
+			func(ids ...string) ([]*TestStruct, []error) { return repo.FetchMulti(ctx, ids...) },
+			func(ids ...string) ([]*TestStruct, []error) { return repo.FetchMultiCache(ctx, time.Minute, ids...) },
+		} {
+			records, errs := fetch(e1.Key, e2.Key, "missing")
+			if errs[0] != nil || errs[1] != nil || !IsRecordNotFound(errs[2]) {
+				t.Fatalf("unexpected errs %v", errs)
+			}
+			if !reflect.DeepEqual(records[0], e1) || !reflect.DeepEqual(records[1], e2) || records[2] != nil {
+				t.Fatalf("unexpected records %v", records)
+			}
+		}
+
+		if err := repo.SoftRemove(ctx, e1.Key); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Fetch(ctx, e1.Key); err != ErrRecordDeleted || !IsRecordNotFound(err) {
+			t.Fatalf("unexpected err %v", err)
+		}
+		if _, errs := repo.FetchMulti(ctx, e1.Key); errs[0] != ErrRecordDeleted {
+			t.Fatalf("unexpected err %v", errs[0])
+		}
+		n, records, err := repo.Search(ctx, func(search FtSearchIndex) valkey.Completed {
+			return search.Query("*").Limit().OffsetNum(0, 1).Build()
+		})
+		if err != nil || n != 1 || len(records) != 1 || !reflect.DeepEqual(records[0], e2) {
+			t.Fatalf("unexpected search result %v %v %v", n, records, err)
+		}
+		if err := repo.DropIndex(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Save(ctx, e1); err != ErrRecordDeleted {
+			t.Fatalf("unexpected err %v", err)
+		}
+		if errs := repo.SaveMulti(ctx, e1); errs[0] != ErrRecordDeleted {
+			t.Fatalf("unexpected err %v", errs[0])
+		}
+		if _, err := repo.Fetch(ctx, e1.Key); err != ErrRecordDeleted {
+			t.Fatalf("unexpected err %v", err)
+		}
+	}
+}
+
+func TestSaveErr(t *testing.T) {
+	if err := saveErr(valkey.Nil); err != ErrVersionMismatch {
+		t.Fatalf("unexpected err %v", err)
+	}
+	other := errors.New("other")
+	if err := saveErr(other); err != other {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := saveErr(nil); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+type modeClient struct {
+	valkey.Client
+	mode valkey.ClientMode
+}
+
+func (c *modeClient) Mode() valkey.ClientMode {
+	return c.mode
+}
+
+func TestCheckSlots(t *testing.T) {
+	lookups := newSchema(reflect.TypeOf(LookupStruct{}))
+	plain := newSchema(reflect.TypeOf(TestStruct{}))
+	cluster := &modeClient{mode: valkey.ClientModeCluster}
+	for _, c := range []struct {
+		client valkey.Client
+		err    error
+		prefix string
+		stream string
+		s      schema
+	}{
+		{client: cluster, s: lookups, prefix: "p", err: ErrLookupCrossSlot},
+		{client: cluster, s: lookups, prefix: "{p}"},
+		{client: cluster, s: plain, prefix: "p"},
+		{client: cluster, s: plain, prefix: "p", stream: "{p}:changes", err: ErrChangeStreamCrossSlot},
+		{client: cluster, s: plain, prefix: "{p}", stream: "changes", err: ErrChangeStreamCrossSlot},
+		{client: cluster, s: plain, prefix: "{p}", stream: "{q}:changes", err: ErrChangeStreamCrossSlot},
+		{client: cluster, s: lookups, prefix: "{p}", stream: "{p}:changes"},
+		{client: &modeClient{mode: valkey.ClientModeStandalone}, s: lookups, prefix: "p", stream: "changes"},
+	} {
+		if err := checkSlots(c.client, c.s, c.prefix, c.stream); err != c.err {
+			t.Fatalf("unexpected err %v for %q", err, c.prefix)
+		}
+	}
+	repo := NewHashRepository("p", LookupStruct{}, cluster).(LookupRepository[LookupStruct])
+	if err := repo.Save(context.Background(), repo.NewEntity()); err != ErrLookupCrossSlot {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if _, err := repo.FindBy(context.Background(), "name", "a"); err != ErrLookupCrossSlot {
+		t.Fatalf("unexpected err %v", err)
+	}
+	json := NewJSONRepository("{p}", TestStruct{}, cluster, WithChangeStream("changes", 0))
+	if err := json.Remove(context.Background(), "1"); err != ErrChangeStreamCrossSlot {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
@@ -3,26 +3,51 @@ package om
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
 )
 
 const ignoreField = "-"
 
 type schema struct {
-	key    *field
-	ver    *field
-	ext    *field
-	fields map[string]*field
+	key     *field
+	ver     *field
+	ext     *field
+	fields  map[string]*field
+	lookups []*field
 }
 
 type field struct {
-	typ   reflect.Type
-	name  string
-	idx   int
-	isKey bool
-	isVer bool
-	isExt bool
+	typ    reflect.Type
+	name   string
+	index  *index
+	enc    string
+	sep    string
+	idx    int
+	lookup bool
+	isKey  bool
+	isVer  bool
+	isExt  bool
+}
+
+// encodings of a field in the HashRepository, which can be chosen by the `valkey:",enc=..."` tag.
+const (
+	encJSON  = "json"  // the field is stored as a JSON string.
+	encFlat  = "flat"  // the nested struct field is flattened into hash fields named in the form of `name.subname`.
+	encDelim = "delim" // the slice or map field is stored as a delimited string, such as `a,b` or `k1=v1,k2=v2`.
+)
+
+// index is the search index definition of a field parsed from the `valkey:",index=..."` tag.
+type index struct {
+	typ      string
+	algo     string
+	metric   string
+	dim      int
+	sortable bool
 }
 
 func newSchema(t reflect.Type) schema {
@@ -44,6 +69,23 @@ func newSchema(t reflect.Type) schema {
 		f.idx = i
 		s.fields[f.name] = &f
 
+		if f.index != nil {
+			if err := f.index.validate(sf.Type); err != nil {
+				panic(fmt.Sprintf("field %s in schema %q has an invalid `valkey:\",index\"` tag: %v", sf.Name, t, err))
+			}
+		}
+
+		if err := f.validateEnc(); err != nil {
+			panic(fmt.Sprintf("field %s in schema %q has an invalid `valkey:\",enc\"` tag: %v", sf.Name, t, err))
+		}
+
+		if f.lookup {
+			if k := f.kind(); !isScalar(k) {
+				panic(fmt.Sprintf("field with tag `valkey:\",lookup\"` in schema %q should be a string, bool or number", t))
+			}
+			s.lookups = append(s.lookups, &f)
+		}
+
 		if f.isKey {
 			if sf.Type.Kind() != reflect.String {
 				panic(fmt.Sprintf("field with tag `valkey:\",key\"` in schema %q should be a string", t))
@@ -84,13 +126,248 @@ func parse(f reflect.StructField) (field field) {
 	}
 
 	v, _ = f.Tag.Lookup("valkey")
-	field.isKey = strings.Contains(v, ",key")
-	field.isVer = strings.Contains(v, ",ver")
-	field.isExt = strings.Contains(v, ",exat")
+	opts := splitTag(v)
+	for _, opt := range opts[1:] {
+		switch {
+		case opt == "key":
+			field.isKey = true
+		case opt == "ver":
+			field.isVer = true
+		case opt == "exat":
+			field.isExt = true
+		case opt == "lookup":
+			field.lookup = true
+		case strings.HasPrefix(opt, "index="):
+			field.index = parseIndex(strings.TrimPrefix(opt, "index="))
+		case strings.HasPrefix(opt, "enc="):
+			field.enc, field.sep = parseEnc(strings.TrimPrefix(opt, "enc="))
+		}
+	}
+	if field.index != nil {
+		for _, opt := range opts[1:] {
+			if opt == "sortable" {
+				field.index.sortable = true
+			}
+		}
+	}
 	field.typ = f.Type
 	return field
 }
 
+// splitTag splits the tag by commas, except the commas inside parentheses, such as the ones in `index=vector(768,COSINE)`.
+func splitTag(tag string) (opts []string) {
+	depth, start := 0, 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				opts = append(opts, strings.TrimSpace(tag[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(opts, strings.TrimSpace(tag[start:]))
+}
+
+func parseIndex(v string) *index {
+	typ, args, _ := strings.Cut(v, "(")
+	idx := &index{typ: strings.ToUpper(typ)}
+	if idx.typ == "VECTOR" {
+		idx.dim = -1
+		params := strings.Split(strings.TrimSuffix(args, ")"), ",")
+		if dim, err := strconv.Atoi(strings.TrimSpace(params[0])); err == nil {
+			idx.dim = dim
+		}
+		if len(params) > 1 {
+			idx.metric = strings.ToUpper(strings.TrimSpace(params[1]))
+		}
+		idx.algo = "HNSW"
+		if len(params) > 2 {
+			idx.algo = strings.ToUpper(strings.TrimSpace(params[2]))
+		}
+	}
+	return idx
+}
+
+// parseEnc parses the encoding and its optional separator, such as `delim(|)`. The default separator is a comma.
+func parseEnc(v string) (enc, sep string) {
+	enc, sep, _ = strings.Cut(v, "(")
+	enc = strings.ToLower(enc)
+	if enc == encDelim {
+		if sep = strings.TrimSuffix(sep, ")"); sep == "" {
+			sep = ","
+		}
+	}
+	return enc, sep
+}
+
+func (f *field) validateEnc() error {
+	t := f.typ
+	switch f.enc {
+	case "", encJSON:
+		return nil
+	case encFlat:
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("flat field should be a struct or a pointer to struct")
+		}
+		return nil
+	case encDelim:
+		switch {
+		case t.Kind() == reflect.Slice && isScalar(t.Elem().Kind()):
+		case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && isScalar(t.Elem().Kind()):
+		default:
+			return fmt.Errorf("delim field should be a slice of scalars or a map from string to scalars")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown encoding %q", f.enc)
+}
+
+// kind returns the kind of the field, or the kind of its element if the field is a pointer.
+func (f *field) kind() reflect.Kind {
+	if f.typ.Kind() == reflect.Ptr {
+		return f.typ.Elem().Kind()
+	}
+	return f.typ.Kind()
+}
+
+func isScalar(k reflect.Kind) bool {
+	return k == reflect.String || k == reflect.Bool || isNumeric(k)
+}
+
+func isNumeric(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uint64 || k == reflect.Float32 || k == reflect.Float64
+}
+
+func (i *index) validate(t reflect.Type) error {
+	switch i.typ {
+	case "TAG", "TEXT", "NUMERIC", "GEO":
+		return nil
+	case "VECTOR":
+		if t.Kind() != reflect.Slice || (t.Elem().Kind() != reflect.Float32 && t.Elem().Kind() != reflect.Float64) {
+			return fmt.Errorf("vector field should be a []float32 or []float64")
+		}
+		if i.dim <= 0 {
+			return fmt.Errorf("vector dimension should be a positive integer")
+		}
+		switch i.metric {
+		case "L2", "IP", "COSINE":
+		default:
+			return fmt.Errorf("vector distance metric should be one of L2, IP and COSINE")
+		}
+		switch i.algo {
+		case "HNSW", "FLAT":
+		default:
+			return fmt.Errorf("vector algorithm should be one of HNSW and FLAT")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown index type %q", i.typ)
+}
+
+// indexFields returns the indexed fields in the order of their declaration.
+func (s schema) indexFields() (fields []*field) {
+	for _, f := range s.fields {
+		if f.index != nil {
+			fields = append(fields, f)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].idx < fields[j].idx })
+	return fields
+}
+
+// schemaArgs generates the arguments after the SCHEMA ADD tokens of FT.ALTER for the field.
+// The JSON field is referred by the `$.name` path and aliased to its name.
+func (f *field) schemaArgs(onJSON bool) (args []string) {
+	if onJSON {
+		args = append(args, "$."+f.name, "AS", f.name)
+	} else {
+		args = append(args, f.name)
+	}
+	args = append(args, f.index.typ)
+	if f.index.typ == "VECTOR" {
+		args = append(args, f.index.algo, "6")
+		args = append(args, f.vectorArgs()...)
+	}
+	if f.hasSeparator(onJSON) {
+		args = append(args, "SEPARATOR", f.sep)
+	}
+	if f.index.sortable {
+		args = append(args, "SORTABLE")
+	}
+	return args
+}
+
+type (
+	// ftCreateField is the FT.CREATE command builder after a field definition.
+	ftCreateField interface {
+		FieldName(fieldName string) cmds.FtCreateFieldFieldName
+		Build() cmds.Completed
+	}
+	// ftCreateFieldType is the FT.CREATE command builder before the type of a field.
+	ftCreateFieldType interface {
+		Text() cmds.FtCreateFieldFieldTypeText
+		Tag() cmds.FtCreateFieldFieldTypeTag
+		Numeric() cmds.FtCreateFieldFieldTypeNumeric
+		Geo() cmds.FtCreateFieldFieldTypeGeo
+		Vector(algo string, nargs int64, args ...string) cmds.FtCreateFieldFieldTypeVector
+	}
+	ftCreateFieldSortable interface {
+		ftCreateField
+		Sortable() cmds.FtCreateFieldOptionSortableSortable
+	}
+)
+
+// define adds the field to the FT.CREATE command with the typed builder. The fieldName is the FieldName of the previous builder.
+func (f *field) define(fieldName func(string) cmds.FtCreateFieldFieldName, onJSON bool) ftCreateField {
+	var t ftCreateFieldType
+	if onJSON {
+		t = fieldName("$." + f.name).As(f.name)
+	} else {
+		t = fieldName(f.name)
+	}
+	var c ftCreateFieldSortable
+	switch f.index.typ {
+	case "TEXT":
+		c = t.Text()
+	case "NUMERIC":
+		c = t.Numeric()
+	case "GEO":
+		c = t.Geo()
+	case "VECTOR":
+		c = t.Vector(f.index.algo, 6, f.vectorArgs()...)
+	default:
+		if tag := t.Tag(); f.hasSeparator(onJSON) {
+			c = tag.Separator(f.sep)
+		} else {
+			c = tag
+		}
+	}
+	if f.index.sortable {
+		return c.Sortable()
+	}
+	return c
+}
+
+func (f *field) vectorArgs() []string {
+	typ := "FLOAT32"
+	if f.typ.Elem().Kind() == reflect.Float64 {
+		typ = "FLOAT64"
+	}
+	return []string{"TYPE", typ, "DIM", strconv.Itoa(f.index.dim), "DISTANCE_METRIC", f.index.metric}
+}
+
+func (f *field) hasSeparator(onJSON bool) bool {
+	return f.index.typ == "TAG" && f.enc == encDelim && f.sep != "," && !onJSON
+}
+
 func key(prefix, id string) (key string) {
 	sb := strings.Builder{}
 	sb.Grow(len(prefix) + len(id) + 1)
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
 )
 
 type s1 struct {
@@ -84,3 +86,141 @@ func recovered(fn func()) (msg string) {
 	fn()
 	return msg
 }
+
+type s6 struct {
+	Key  string    `valkey:",key"`
+	Ver  int64     `valkey:",ver"`
+	Name string    `json:"name" valkey:",index=text,sortable"`
+	Tags string    `json:"tags" valkey:",index=tag"`
+	Age  int64     `json:"age" valkey:",sortable,index=numeric"`
+	Loc  string    `json:"loc" valkey:",index=geo"`
+	Vec  []float32 `json:"vec" valkey:",index=vector(3,cosine)"`
+	Vec2 []float64 `json:"vec2" valkey:",index=vector(2,L2,flat)"`
+	Skip string    `json:"skip" valkey:",sortable"`
+}
+
+type s7 struct {
+	Key string `valkey:",key"`
+	Ver int64  `valkey:",ver"`
+	Vec string `valkey:",index=vector(3,COSINE)"`
+}
+
+type s8 struct {
+	Key string    `valkey:",key"`
+	Ver int64     `valkey:",ver"`
+	Vec []float32 `valkey:",index=vector(0,COSINE)"`
+}
+
+type s9 struct {
+	Key string `valkey:",key"`
+	Ver int64  `valkey:",ver"`
+	F   string `valkey:",index=unknown"`
+}
+
+func TestSchemaIndex(t *testing.T) {
+	s := newSchema(reflect.TypeOf(s6{}))
+	fields := s.indexFields()
+	if len(fields) != 6 {
+		t.Fatalf("unexpected indexed fields %v", len(fields))
+	}
+	for _, c := range []struct {
+		hash []string
+		json []string
+	}{
+		{hash: []string{"name", "TEXT", "SORTABLE"}, json: []string{"$.name", "AS", "name", "TEXT", "SORTABLE"}},
+		{hash: []string{"tags", "TAG"}, json: []string{"$.tags", "AS", "tags", "TAG"}},
+		{hash: []string{"age", "NUMERIC", "SORTABLE"}, json: []string{"$.age", "AS", "age", "NUMERIC", "SORTABLE"}},
+		{hash: []string{"loc", "GEO"}, json: []string{"$.loc", "AS", "loc", "GEO"}},
+		{
+			hash: []string{"vec", "VECTOR", "HNSW", "6", "TYPE", "FLOAT32", "DIM", "3", "DISTANCE_METRIC", "COSINE"},
+			json: []string{"$.vec", "AS", "vec", "VECTOR", "HNSW", "6", "TYPE", "FLOAT32", "DIM", "3", "DISTANCE_METRIC", "COSINE"},
+		},
+		{
+			hash: []string{"vec2", "VECTOR", "FLAT", "6", "TYPE", "FLOAT64", "DIM", "2", "DISTANCE_METRIC", "L2"},
+			json: []string{"$.vec2", "AS", "vec2", "VECTOR", "FLAT", "6", "TYPE", "FLOAT64", "DIM", "2", "DISTANCE_METRIC", "L2"},
+		},
+	} {
+		f := fields[0]
+		fields = fields[1:]
+		if v := f.schemaArgs(false); !reflect.DeepEqual(v, c.hash) {
+			t.Fatalf("unexpected hash schema %v", v)
+		}
+		if v := f.schemaArgs(true); !reflect.DeepEqual(v, c.json) {
+			t.Fatalf("unexpected json schema %v", v)
+		}
+		for _, onJSON := range []bool{false, true} {
+			expected := append([]string{"FT.CREATE", "idx", "SCHEMA"}, f.schemaArgs(onJSON)...)
+			cmd := f.define(cmds.NewBuilder(cmds.NoSlot).FtCreate().Index("idx").Schema().FieldName, onJSON).Build()
+			if v := cmd.Commands(); !reflect.DeepEqual(v, expected) {
+				t.Fatalf("unexpected typed schema %v", v)
+			}
+		}
+	}
+	if s.key.index != nil || s.ver.index != nil || !s.key.isKey || !s.ver.isVer {
+		t.Fatalf("unexpected key and ver fields")
+	}
+
+	for _, c := range []struct {
+		typ any
+		msg string
+	}{
+		{typ: s7{}, msg: "should be a []float32 or []float64"},
+		{typ: s8{}, msg: "dimension should be a positive integer"},
+		{typ: s9{}, msg: "unknown index type"},
+	} {
+		if v := recovered(func() {
+			newSchema(reflect.TypeOf(c.typ))
+		}); !strings.Contains(v, c.msg) {
+			t.Fatalf("unexpected msg %v", v)
+		}
+	}
+}
+
+type s10 struct {
+	Key string `valkey:",key"`
+	Ver int64  `valkey:",ver"`
+	F   string `valkey:",enc=flat"`
+}
+
+type s11 struct {
+	Key string            `valkey:",key"`
+	Ver int64             `valkey:",ver"`
+	F   map[int]string    `valkey:",enc=delim"`
+	G   map[string]string `valkey:",enc=unknown"`
+}
+
+type s12 struct {
+	Key  string   `valkey:",key"`
+	Ver  int64    `valkey:",ver"`
+	Tags []string `json:"tags" valkey:",index=tag,enc=delim(|)"`
+}
+
+func TestSchemaEnc(t *testing.T) {
+	s := newSchema(reflect.TypeOf(s12{}))
+	if f := s.fields["tags"]; f.enc != encDelim || f.sep != "|" {
+		t.Fatalf("unexpected enc %v %v", f.enc, f.sep)
+	}
+	if v := s.fields["tags"].schemaArgs(false); !reflect.DeepEqual(v, []string{"tags", "TAG", "SEPARATOR", "|"}) {
+		t.Fatalf("unexpected hash schema %v", v)
+	}
+	cmd := s.fields["tags"].define(cmds.NewBuilder(cmds.NoSlot).FtCreate().Index("idx").Schema().FieldName, false).Build()
+	if v := cmd.Commands(); !reflect.DeepEqual(v, []string{"FT.CREATE", "idx", "SCHEMA", "tags", "TAG", "SEPARATOR", "|"}) {
+		t.Fatalf("unexpected typed schema %v", v)
+	}
+	if enc, sep := parseEnc("delim"); enc != encDelim || sep != "," {
+		t.Fatalf("unexpected enc %v %v", enc, sep)
+	}
+	for _, c := range []struct {
+		typ any
+		msg string
+	}{
+		{typ: s10{}, msg: "flat field should be a struct"},
+		{typ: s11{}, msg: "delim field should be a slice of scalars or a map from string to scalars"},
+	} {
+		if v := recovered(func() {
+			newSchema(reflect.TypeOf(c.typ))
+		}); !strings.Contains(v, c.msg) {
+			t.Fatalf("unexpected msg %v", v)
+		}
+	}
+}
@@ -66,7 +66,8 @@ type pipe struct {
 	clhks           atomic.Value // closed hook, invoked after the conn is closed
 	queue           queue
 	cache           CacheStore
-	pshks           atomic.Pointer[pshks] // pubsub hook, registered by the SetPubSubHooks
+	pshks           atomic.Pointer[pshks]  // pubsub hook, registered by the SetPubSubHooks
+	unmeter         atomic.Pointer[func()] // returned by the MetricsHooks.OnPipeOpen
 	error           atomic.Pointer[errs]
 	r               *bufio.Reader
 	w               *bufio.Writer
@@ -76,8 +77,9 @@ type pipe struct {
 	nsubs           *subs // pubsub  message subscriptions
 	psubs           *subs // pubsub pmessage subscriptions
 	r2p             *r2p
-	pingTimer       *time.Timer // timer for background ping
-	lftmTimer       *time.Timer // lifetime timer
+	mtrc            *meteredConn // set if the conn is wrapped for the MetricsHooks
+	pingTimer       *time.Timer  // timer for background ping
+	lftmTimer       *time.Timer  // lifetime timer
 	info            map[string]ValkeyMessage
 	timeout         time.Duration
 	pinggap         time.Duration
@@ -121,6 +123,7 @@ func _newPipe(ctx context.Context, connFn func(context.Context) (net.Conn, error
 		r2ps:  r2ps,
 		optIn: isOptIn(option.ClientTrackingOptions),
 	}
+	p.mtrc, _ = conn.(*meteredConn)
 	if !nobg {
 		p.queue = newRing(option.RingScaleEachConn)
 		p.nsubs = newSubs()
@@ -325,6 +328,9 @@ func _newPipe(ctx context.Context, connFn func(context.Context) (net.Conn, error
 		}
 	}
 	if !nobg {
+		if p.mtrc != nil {
+			p.mtrc.openPipe(p)
+		}
 		if p.onInvalidations != nil || option.AlwaysPipelining {
 			p.background()
 		}
@@ -353,10 +359,14 @@ func (p *pipe) _exit(err error) {
 	atomic.CompareAndSwapInt32(&p.state, 1, 2) // stop accepting new requests
 	_ = p.conn.Close()                         // force both read & write goroutine to exit
 	p.StopTimer()
+	p.closeMetrics()
 	p.clhks.Load().(func(error))(err)
 }
 
 func disableNoDelay(conn net.Conn) {
+	if c, ok := conn.(*meteredConn); ok {
+		conn = c.Conn
+	}
 	if c, ok := conn.(*tls.Conn); ok {
 		conn = c.NetConn()
 	}
@@ -444,6 +454,7 @@ func (p *pipe) _backgroundWrite() (err error) {
 
 		flushDelay = p.maxFlushDelay
 		flushStart = time.Time{}
+		flushCmds  = 0
 	)
 
 	for err == nil {
@@ -455,6 +466,10 @@ func (p *pipe) _backgroundWrite() (err error) {
 				if err = p.w.Flush(); err != nil {
 					break
 				}
+				if p.mtrc != nil {
+					p.mtrc.flushed(flushCmds)
+				}
+				flushCmds = 0
 			}
 			ones[0], multi, ch = p.queue.WaitForWrite()
 			if flushDelay != 0 && p.loadWaits() > 1 { // do not delay for sequential usage
@@ -473,6 +488,7 @@ func (p *pipe) _backgroundWrite() (err error) {
 		if ch != nil && multi == nil {
 			multi = ones
 		}
+		flushCmds += len(multi)
 		for _, cmd := range multi {
 			err = writeCmd(p.w, cmd.Commands())
 			if cmd.IsUnsub() { // See https://github.com/redis/rueidis/pull/691
@@ -1667,6 +1683,21 @@ func (p *pipe) Close() {
 	if p.r2p != nil {
 		p.r2p.Close()
 	}
+	p.closeMetrics()
+}
+
+func (p *pipe) closeMetrics() {
+	if fn := p.unmeter.Swap(nil); fn != nil {
+		(*fn)()
+	}
+}
+
+func (p *pipe) stats() PipeStats {
+	s := PipeStats{InFlight: int(p.loadWaits())}
+	if r, ok := p.queue.(*ring); ok {
+		s.Queued = r.Queued()
+	}
+	return s
 }
 
 func (p *pipe) StopTimer() bool {
@@ -32,6 +32,9 @@ type pool struct {
 	cond    *sync.Cond
 	timer   *time.Timer
 	make    func(ctx context.Context) wire
+	hooks   *MetricsHooks
+	unmeter func()
+	addr    string
 	list    []wire
 	cleanup time.Duration
 	size    int
@@ -41,6 +44,21 @@ type pool struct {
 	timerOn bool
 }
 
+// meter registers the pool to the OnPoolOpen hook and reports the waiting time of Acquire to the OnPoolWait hook.
+func (p *pool) meter(addr string, hooks *MetricsHooks) {
+	p.addr = addr
+	p.hooks = hooks
+	if hooks.OnPoolOpen != nil {
+		p.unmeter = hooks.OnPoolOpen(addr, p.stats)
+	}
+}
+
+func (p *pool) stats() PoolStats {
+	p.cond.L.Lock()
+	defer p.cond.L.Unlock()
+	return PoolStats{Size: p.size, Idle: len(p.list)}
+}
+
 func (p *pool) Acquire(ctx context.Context) (v wire) {
 	p.cond.L.Lock()
 
@@ -57,10 +75,18 @@ func (p *pool) Acquire(ctx context.Context) (v wire) {
 		}()
 	}
 
+	var waitStart time.Time
 retry:
 	for len(p.list) == 0 && p.size == p.cap && !p.down && ctx.Err() == nil {
+		if waitStart.IsZero() && p.hooks != nil && p.hooks.OnPoolWait != nil {
+			waitStart = time.Now()
+		}
 		p.cond.Wait()
 	}
+	if !waitStart.IsZero() {
+		p.hooks.OnPoolWait(p.addr, time.Since(waitStart))
+		waitStart = time.Time{}
+	}
 
 	if ctx.Err() != nil {
 		deadPipe := deadFn()
@@ -119,8 +145,13 @@ func (p *pool) Close() {
 	for _, w := range p.list {
 		w.Close()
 	}
+	unmeter := p.unmeter
+	p.unmeter = nil
 	p.cond.L.Unlock()
 	p.cond.Broadcast()
+	if unmeter != nil {
+		unmeter()
+	}
 }
 
 func (p *pool) startTimerIfNeeded() {
@@ -0,0 +1,344 @@
+package valkey
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+// WithKeyPrefix returns a Client that transparently namespaces keys with the prefix:
+//   - Every key argument built by the command builder is prefixed, such as the keys of GET, MSET, EVAL and XREAD.
+//   - Patterns of KEYS and SCAN MATCH are prefixed. SCAN without MATCH only iterates keys with the prefix.
+//   - The prefix is stripped from keys in the replies of KEYS, SCAN, RANDOMKEY, BLPOP, BRPOP, BZPOPMIN, BZPOPMAX,
+//     LMPOP, BLMPOP, ZMPOP, BZMPOP, XREAD and XREADGROUP, including the ones replied by EXEC.
+//     The replies of DoStream and DoMultiStream are written to the io.Writer as they are, so their keys keep the prefix.
+//
+// Since the key slot is computed from the first {hashtag} of a key, keys sharing a {hashtag} still share the same slot
+// in a valkey cluster as long as the prefix has no braces. A prefix with a {hashtag} puts all keys into the same slot instead.
+// Pub/Sub channels, including shard channels, are not namespaced.
+// Use the ClientOption.KeyPrefix to also strip the prefix from the keys passed to the ClientOption.OnInvalidations.
+func WithKeyPrefix(client Client, prefix string) Client {
+	return &prefixClient{client: client, p: newKeyPrefix(prefix)}
+}
+
+type replyKind uint8
+
+const (
+	replyAsIs    replyKind = iota
+	replyKeys              // an array of keys
+	replyScan              // a cursor followed by an array of keys
+	replyKey               // a key
+	replyFirst             // an array starting with a key
+	replyStreams           // a map or an array of key and entries pairs
+	replyExec              // an array of replies of the queued commands
+)
+
+// reply describes how to strip the prefix from the reply of a command.
+type reply struct {
+	queued []replyKind // the reply kinds of the commands queued by the MULTI if the kind is replyExec
+	kind   replyKind
+}
+
+type keyPrefix struct {
+	prefix  string
+	pattern string // the prefix escaped for glob-style patterns
+}
+
+func newKeyPrefix(prefix string) *keyPrefix {
+	if !strings.ContainsAny(prefix, "*?[]\\") {
+		return &keyPrefix{prefix: prefix, pattern: prefix}
+	}
+	var sb strings.Builder
+	for i := 0; i < len(prefix); i++ {
+		switch prefix[i] {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(prefix[i])
+	}
+	return &keyPrefix{prefix: prefix, pattern: sb.String()}
+}
+
+func (p *keyPrefix) key(key string) string {
+	return p.prefix + key
+}
+
+// completed prefixes the keys and the patterns of the cmd and returns the kind of its reply.
+// The kind must be taken before sending the cmd, since the cmd is recycled afterward.
+func (p *keyPrefix) completed(cmd Completed) (Completed, replyKind) {
+	commands := cmd.Commands()
+	if len(commands) == 0 {
+		return cmd, replyAsIs
+	}
+	switch strings.ToUpper(commands[0]) {
+	case "SSUBSCRIBE", "SUNSUBSCRIBE", "SPUBLISH":
+		return cmd, replyAsIs
+	case "KEYS":
+		if len(commands) == 2 {
+			return cmds.ReplaceCompletedArgs(cmd, []string{commands[0], p.pattern + commands[1]}), replyKeys
+		}
+		return cmd, replyKeys
+	case "SCAN":
+		args := append(make([]string, 0, len(commands)+2), commands...)
+		match := false
+		for i := 2; i+1 < len(args); i += 2 {
+			if strings.EqualFold(args[i], "MATCH") {
+				args[i+1] = p.pattern + args[i+1]
+				match = true
+			}
+		}
+		if !match {
+			args = append(args, "MATCH", p.pattern+"*")
+		}
+		return cmds.ReplaceCompletedArgs(cmd, args), replyScan
+	case "RANDOMKEY":
+		return cmd, replyKey
+	case "BLPOP", "BRPOP", "BZPOPMIN", "BZPOPMAX", "LMPOP", "BLMPOP", "ZMPOP", "BZMPOP":
+		return cmds.RewriteCompletedKeys(cmd, p.key), replyFirst
+	case "XREAD", "XREADGROUP":
+		return cmds.RewriteCompletedKeys(cmd, p.key), replyStreams
+	case "EXEC":
+		return cmd, replyExec
+	}
+	return cmds.RewriteCompletedKeys(cmd, p.key), replyAsIs
+}
+
+func (p *keyPrefix) cacheable(cmd Cacheable) Cacheable {
+	return cmds.RewriteCacheableKeys(cmd, p.key)
+}
+
+// multi prefixes the multi and returns how to strip their replies, including the replies of EXEC.
+func (p *keyPrefix) multi(multi []Completed) ([]Completed, []reply) {
+	rewritten := make([]Completed, len(multi))
+	replies := make([]reply, len(multi))
+	var tx transaction
+	for i, cmd := range multi {
+		rewritten[i], replies[i] = tx.track(p.completed(cmd))
+	}
+	return rewritten, replies
+}
+
+// transaction tracks the reply kinds of the commands queued by a MULTI.
+type transaction struct {
+	queued []replyKind
+	multi  bool
+}
+
+func (t *transaction) track(cmd Completed, kind replyKind) (Completed, reply) {
+	commands := cmd.Commands()
+	switch {
+	case len(commands) == 1 && strings.EqualFold(commands[0], "MULTI"):
+		t.multi, t.queued = true, nil
+	case len(commands) == 1 && strings.EqualFold(commands[0], "DISCARD"):
+		t.multi, t.queued = false, nil
+	case kind == replyExec:
+		queued := t.queued
+		t.multi, t.queued = false, nil
+		return cmd, reply{kind: replyExec, queued: queued}
+	case t.multi:
+		t.queued = append(t.queued, kind)
+		return cmd, reply{}
+	}
+	return cmd, reply{kind: kind}
+}
+
+func (p *keyPrefix) strip(r reply, resp ValkeyResult) ValkeyResult {
+	if r.kind != replyAsIs && resp.err == nil {
+		p.stripMessage(r.kind, r.queued, &resp.val)
+	}
+	return resp
+}
+
+func (p *keyPrefix) stripMulti(replies []reply, resps []ValkeyResult) []ValkeyResult {
+	for i := range resps {
+		if i < len(replies) {
+			resps[i] = p.strip(replies[i], resps[i])
+		}
+	}
+	return resps
+}
+
+func (p *keyPrefix) stripMessage(kind replyKind, queued []replyKind, m *ValkeyMessage) {
+	if m.Error() != nil {
+		return
+	}
+	switch kind {
+	case replyKeys:
+		p.stripKeys(m.values())
+	case replyScan:
+		if values := m.values(); len(values) == 2 {
+			p.stripKeys(values[1].values())
+		}
+	case replyKey:
+		p.stripKey(m)
+	case replyFirst:
+		if values := m.values(); len(values) > 0 {
+			p.stripKey(&values[0])
+		}
+	case replyStreams:
+		values := m.values()
+		if m.IsMap() {
+			for i := 0; i < len(values); i += 2 {
+				p.stripKey(&values[i])
+			}
+			return
+		}
+		for _, v := range values {
+			if kv := v.values(); len(kv) > 0 {
+				p.stripKey(&kv[0])
+			}
+		}
+	case replyExec:
+		values := m.values()
+		for i := range values {
+			if i < len(queued) {
+				p.stripMessage(queued[i], nil, &values[i])
+			}
+		}
+	}
+}
+
+func (p *keyPrefix) stripKeys(values []ValkeyMessage) {
+	for i := range values {
+		p.stripKey(&values[i])
+	}
+}
+
+func (p *keyPrefix) stripKey(m *ValkeyMessage) {
+	if m.array != nil {
+		return
+	}
+	if s := m.string(); strings.HasPrefix(s, p.prefix) {
+		*m = strmsg(m.typ, s[len(p.prefix):])
+	}
+}
+
+// invalidations strips the prefix from the keys before passing them to the fn.
+func (p *keyPrefix) invalidations(fn func([]ValkeyMessage)) func([]ValkeyMessage) {
+	return func(keys []ValkeyMessage) {
+		if keys != nil {
+			stripped := make([]ValkeyMessage, len(keys))
+			copy(stripped, keys)
+			p.stripKeys(stripped)
+			keys = stripped
+		}
+		fn(keys)
+	}
+}
+
+type prefixClient struct {
+	client Client
+	p      *keyPrefix
+}
+
+func (c *prefixClient) B() Builder {
+	return c.client.B()
+}
+
+func (c *prefixClient) Do(ctx context.Context, cmd Completed) ValkeyResult {
+	cmd, kind := c.p.completed(cmd)
+	return c.p.strip(reply{kind: kind}, c.client.Do(ctx, cmd))
+}
+
+func (c *prefixClient) DoMulti(ctx context.Context, multi ...Completed) []ValkeyResult {
+	multi, replies := c.p.multi(multi)
+	return c.p.stripMulti(replies, c.client.DoMulti(ctx, multi...))
+}
+
+func (c *prefixClient) DoCache(ctx context.Context, cmd Cacheable, ttl time.Duration) ValkeyResult {
+	return c.client.DoCache(ctx, c.p.cacheable(cmd), ttl)
+}
+
+func (c *prefixClient) DoMultiCache(ctx context.Context, multi ...CacheableTTL) []ValkeyResult {
+	rewritten := make([]CacheableTTL, len(multi))
+	for i, ct := range multi {
+		rewritten[i] = CT(c.p.cacheable(ct.Cmd), ct.TTL)
+	}
+	return c.client.DoMultiCache(ctx, rewritten...)
+}
+
+func (c *prefixClient) DoStream(ctx context.Context, cmd Completed) ValkeyResultStream {
+	cmd, _ = c.p.completed(cmd)
+	return c.client.DoStream(ctx, cmd)
+}
+
+func (c *prefixClient) DoMultiStream(ctx context.Context, multi ...Completed) MultiValkeyResultStream {
+	multi, _ = c.p.multi(multi)
+	return c.client.DoMultiStream(ctx, multi...)
+}
+
+func (c *prefixClient) Receive(ctx context.Context, subscribe Completed, fn func(msg PubSubMessage)) error {
+	return c.client.Receive(ctx, subscribe, fn)
+}
+
+func (c *prefixClient) Dedicated(fn func(DedicatedClient) error) (err error) {
+	return c.client.Dedicated(func(client DedicatedClient) error {
+		return fn(&prefixDedicated{client: client, p: c.p})
+	})
+}
+
+func (c *prefixClient) Dedicate() (DedicatedClient, func()) {
+	client, cancel := c.client.Dedicate()
+	return &prefixDedicated{client: client, p: c.p}, cancel
+}
+
+func (c *prefixClient) Nodes() map[string]Client {
+	nodes := c.client.Nodes()
+	prefixed := make(map[string]Client, len(nodes))
+	for addr, client := range nodes {
+		prefixed[addr] = &prefixClient{client: client, p: c.p}
+	}
+	return prefixed
+}
+
+func (c *prefixClient) Mode() ClientMode {
+	return c.client.Mode()
+}
+
+func (c *prefixClient) Close() {
+	c.client.Close()
+}
+
+type prefixDedicated struct {
+	client DedicatedClient
+	p      *keyPrefix
+	tx     transaction // tracks MULTI across calls, since EXEC can be sent separately on a dedicated connection
+	mu     sync.Mutex
+}
+
+func (d *prefixDedicated) B() Builder {
+	return d.client.B()
+}
+
+func (d *prefixDedicated) Do(ctx context.Context, cmd Completed) ValkeyResult {
+	d.mu.Lock()
+	cmd, r := d.tx.track(d.p.completed(cmd))
+	d.mu.Unlock()
+	return d.p.strip(r, d.client.Do(ctx, cmd))
+}
+
+func (d *prefixDedicated) DoMulti(ctx context.Context, multi ...Completed) []ValkeyResult {
+	rewritten := make([]Completed, len(multi))
+	replies := make([]reply, len(multi))
+	d.mu.Lock()
+	for i, cmd := range multi {
+		rewritten[i], replies[i] = d.tx.track(d.p.completed(cmd))
+	}
+	d.mu.Unlock()
+	return d.p.stripMulti(replies, d.client.DoMulti(ctx, rewritten...))
+}
+
+func (d *prefixDedicated) Receive(ctx context.Context, subscribe Completed, fn func(msg PubSubMessage)) error {
+	return d.client.Receive(ctx, subscribe, fn)
+}
+
+func (d *prefixDedicated) SetPubSubHooks(hooks PubSubHooks) <-chan error {
+	return d.client.SetPubSubHooks(hooks)
+}
+
+func (d *prefixDedicated) Close() {
+	d.client.Close()
+}
@@ -0,0 +1,281 @@
+package valkey
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+func stringsmsg(typ byte, values ...string) ValkeyMessage {
+	msgs := make([]ValkeyMessage, len(values))
+	for i, v := range values {
+		msgs[i] = strmsg('$', v)
+	}
+	return slicemsg(typ, msgs)
+}
+
+func prefixReplies() map[string]ValkeyMessage {
+	return map[string]ValkeyMessage{
+		"GET t:{u}a":                    strmsg('$', "t:{u}a"),
+		"KEYS t:a*":                     stringsmsg('*', "t:a1", "t:a2"),
+		"KEYS t:\\*a*":                  stringsmsg('*', "t:*a1", "t:*a2"),
+		"SCAN 0 MATCH t:*":              slicemsg('*', []ValkeyMessage{strmsg('$', "0"), stringsmsg('*', "t:a", "b")}),
+		"SCAN 0 MATCH t:a* COUNT 10":    slicemsg('*', []ValkeyMessage{strmsg('$', "0"), stringsmsg('*', "t:a")}),
+		"RANDOMKEY":                     strmsg('$', "t:a"),
+		"BLPOP t:a t:b 0":               stringsmsg('*', "t:b", "v"),
+		"XREAD STREAMS t:s 0":           slicemsg('%', []ValkeyMessage{strmsg('$', "t:s"), slicemsg('*', nil)}),
+		"XREAD COUNT 1 STREAMS t:s 0":   slicemsg('*', []ValkeyMessage{slicemsg('*', []ValkeyMessage{strmsg('$', "t:s"), slicemsg('*', nil)})}),
+		"MULTI":                         strmsg('+', "OK"),
+		"SET t:a v":                     strmsg('+', "QUEUED"),
+		"KEYS t:*":                      strmsg('+', "QUEUED"),
+		"EXEC":                          slicemsg('*', []ValkeyMessage{strmsg('+', "OK"), stringsmsg('*', "t:a")}),
+		"SPUBLISH ch m":                 {typ: ':', intlen: 1},
+		"GET t:err":                     strmsg('-', "t:err"),
+		"ZMPOP 1 t:z MIN":               slicemsg('*', []ValkeyMessage{strmsg('$', "t:z"), slicemsg('*', nil)}),
+		"MGET t:a t:b":                  stringsmsg('*', "t:1", "t:2"),
+		"SSUBSCRIBE ch":                 {typ: ':', intlen: 1},
+		"EVAL return 1 2 t:{a}1 t:{a}2": {typ: ':', intlen: 1},
+	}
+}
+
+func newPrefixTestClient(t *testing.T, sent *[]string) *singleClient {
+	replies := prefixReplies()
+	reply := func(cmd []string) ValkeyResult {
+		joined := strings.Join(cmd, " ")
+		*sent = append(*sent, joined)
+		if msg, ok := replies[joined]; ok {
+			return newResult(msg, nil)
+		}
+		t.Fatalf("unexpected command %q", joined)
+		return ValkeyResult{}
+	}
+	m := &mockConn{
+		DoFn: func(cmd Completed) ValkeyResult {
+			return reply(cmd.Commands())
+		},
+		DoCacheFn: func(cmd Cacheable, ttl time.Duration) ValkeyResult {
+			return reply(cmd.Commands())
+		},
+		DoMultiFn: func(multi ...Completed) *valkeyresults {
+			resps := make([]ValkeyResult, len(multi))
+			for i, cmd := range multi {
+				resps[i] = reply(cmd.Commands())
+			}
+			return &valkeyresults{s: resps}
+		},
+		DoMultiCacheFn: func(multi ...CacheableTTL) *valkeyresults {
+			resps := make([]ValkeyResult, len(multi))
+			for i, ct := range multi {
+				resps[i] = reply(ct.Cmd.Commands())
+			}
+			return &valkeyresults{s: resps}
+		},
+		AcquireFn: func() wire {
+			return &mockWire{
+				DoFn: func(cmd Completed) ValkeyResult {
+					return reply(cmd.Commands())
+				},
+				DoMultiFn: func(multi ...Completed) *valkeyresults {
+					resps := make([]ValkeyResult, len(multi))
+					for i, cmd := range multi {
+						resps[i] = reply(cmd.Commands())
+					}
+					return &valkeyresults{s: resps}
+				},
+			}
+		},
+		ReceiveFn: func(ctx context.Context, subscribe Completed, fn func(message PubSubMessage)) error {
+			reply(subscribe.Commands())
+			return nil
+		},
+	}
+	return newSingleClientWithConn(m, cmds.NewBuilder(cmds.NoSlot), true, false, newRetryer(defaultRetryDelayFn), false)
+}
+
+func valuesOf(t *testing.T, resp ValkeyResult) []string {
+	t.Helper()
+	values, err := resp.AsStrSlice()
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	return values
+}
+
+func TestWithKeyPrefix(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var sent []string
+	client := WithKeyPrefix(newPrefixTestClient(t, &sent), "t:")
+	ctx := context.Background()
+
+	if v, err := client.Do(ctx, client.B().Get().Key("{u}a").Build()).ToString(); err != nil || v != "t:{u}a" {
+		t.Fatalf("values in replies should not be stripped %v %v", v, err)
+	}
+	if v := valuesOf(t, client.Do(ctx, client.B().Keys().Pattern("a*").Build())); !reflect.DeepEqual(v, []string{"a1", "a2"}) {
+		t.Fatalf("unexpected keys %v", v)
+	}
+	if e, err := client.Do(ctx, client.B().Scan().Cursor(0).Build()).AsScanEntry(); err != nil || !reflect.DeepEqual(e.Elements, []string{"a", "b"}) {
+		t.Fatalf("unexpected scan %v %v", e, err)
+	}
+	if e, err := client.Do(ctx, client.B().Scan().Cursor(0).Match("a*").Count(10).Build()).AsScanEntry(); err != nil || !reflect.DeepEqual(e.Elements, []string{"a"}) {
+		t.Fatalf("unexpected scan %v %v", e, err)
+	}
+	if v, err := client.Do(ctx, client.B().Randomkey().Build()).ToString(); err != nil || v != "a" {
+		t.Fatalf("unexpected key %v %v", v, err)
+	}
+	if v := valuesOf(t, client.Do(ctx, client.B().Blpop().Key("a", "b").Timeout(0).Build())); !reflect.DeepEqual(v, []string{"b", "v"}) {
+		t.Fatalf("unexpected pop %v", v)
+	}
+	if v, err := client.Do(ctx, client.B().Zmpop().Numkeys(1).Key("z").Min().Build()).AsZMPop(); err != nil || v.Key != "z" {
+		t.Fatalf("unexpected pop %v %v", v, err)
+	}
+	if v, err := client.Do(ctx, client.B().Xread().Streams().Key("s").Id("0").Build()).AsXRead(); err != nil || len(v) != 1 || v["s"] == nil {
+		t.Fatalf("unexpected streams %v %v", v, err)
+	}
+	if v, err := client.Do(ctx, client.B().Xread().Count(1).Streams().Key("s").Id("0").Build()).AsXRead(); err != nil || len(v) != 1 || v["s"] == nil {
+		t.Fatalf("unexpected streams %v %v", v, err)
+	}
+	if err := client.Do(ctx, client.B().Get().Key("err").Build()).Error(); err == nil || err.Error() != "t:err" {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := client.Do(ctx, client.B().Spublish().Channel("ch").Message("m").Build()).Error(); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := client.Do(ctx, client.B().Eval().Script("return 1").Numkeys(2).Key("{a}1", "{a}2").Build()).Error(); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+
+	resps := client.DoMulti(ctx,
+		client.B().Multi().Build(),
+		client.B().Set().Key("a").Value("v").Build(),
+		client.B().Keys().Pattern("*").Build(),
+		client.B().Exec().Build(),
+		client.B().Randomkey().Build(),
+	)
+	if v, err := resps[4].ToString(); err != nil || v != "a" {
+		t.Fatalf("unexpected key %v %v", v, err)
+	}
+	exec, err := resps[3].ToArray()
+	if err != nil || len(exec) != 2 {
+		t.Fatalf("unexpected exec %v %v", exec, err)
+	}
+	if v, err := exec[1].AsStrSlice(); err != nil || !reflect.DeepEqual(v, []string{"a"}) {
+		t.Fatalf("unexpected keys in exec %v %v", v, err)
+	}
+
+	if v := valuesOf(t, client.DoCache(ctx, client.B().Mget().Key("a", "b").Cache(), time.Second)); !reflect.DeepEqual(v, []string{"t:1", "t:2"}) {
+		t.Fatalf("unexpected values %v", v)
+	}
+	for _, resp := range client.DoMultiCache(ctx, CT(client.B().Get().Key("{u}a").Cache(), time.Second)) {
+		if v, err := resp.ToString(); err != nil || v != "t:{u}a" {
+			t.Fatalf("unexpected value %v %v", v, err)
+		}
+	}
+	if err := client.Receive(ctx, client.B().Ssubscribe().Channel("ch").Build(), func(msg PubSubMessage) {}); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+
+	expected := []string{
+		"GET t:{u}a", "KEYS t:a*",
+		"SCAN 0 MATCH t:*", "SCAN 0 MATCH t:a* COUNT 10", "RANDOMKEY", "BLPOP t:a t:b 0", "ZMPOP 1 t:z MIN",
+		"XREAD STREAMS t:s 0", "XREAD COUNT 1 STREAMS t:s 0", "GET t:err", "SPUBLISH ch m", "EVAL return 1 2 t:{a}1 t:{a}2",
+		"MULTI", "SET t:a v", "KEYS t:*", "EXEC", "RANDOMKEY",
+		"MGET t:a t:b", "GET t:{u}a", "SSUBSCRIBE ch",
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Fatalf("unexpected sent commands %q", sent)
+	}
+}
+
+func TestWithKeyPrefixEscape(t *testing.T) {
+	var sent []string
+	client := WithKeyPrefix(newPrefixTestClient(t, &sent), "t:*")
+	if v := valuesOf(t, client.Do(context.Background(), client.B().Keys().Pattern("a*").Build())); !reflect.DeepEqual(v, []string{"a1", "a2"}) {
+		t.Fatalf("unexpected keys %v", v)
+	}
+	if p := newKeyPrefix(`a*?[b]\`); p.pattern != `a\*\?\[b\]\\` {
+		t.Fatalf("unexpected pattern %v", p.pattern)
+	}
+}
+
+func TestWithKeyPrefixHashTag(t *testing.T) {
+	p := newKeyPrefix("t:")
+	b := cmds.NewBuilder(cmds.InitSlot)
+	cmd, _ := p.completed(b.Mset().KeyValue().KeyValue("{u}a", "1").KeyValue("{u}b", "2").Build())
+	if cmd.Slot() != cmds.Slot("u") {
+		t.Fatalf("keys with a hashtag should stay in the same slot")
+	}
+	if !reflect.DeepEqual(cmd.Commands(), []string{"MSET", "t:{u}a", "1", "t:{u}b", "2"}) {
+		t.Fatalf("unexpected commands %v", cmd.Commands())
+	}
+	cmd, _ = p.completed(b.Get().Key("a").Build())
+	if cmd.Slot() != cmds.Slot("t:a") {
+		t.Fatalf("unexpected slot %v", cmd.Slot())
+	}
+	cmd, _ = newKeyPrefix("{t}:").completed(b.Get().Key("{u}a").Build())
+	if cmd.Slot() != cmds.Slot("t") {
+		t.Fatalf("unexpected slot %v", cmd.Slot())
+	}
+}
+
+func TestWithKeyPrefixDedicated(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var sent []string
+	client := WithKeyPrefix(newPrefixTestClient(t, &sent), "t:")
+	ctx := context.Background()
+
+	check := func(c DedicatedClient) {
+		c.Do(ctx, c.B().Multi().Build())
+		c.Do(ctx, c.B().Set().Key("a").Value("v").Build())
+		c.Do(ctx, c.B().Keys().Pattern("*").Build())
+		exec, err := c.Do(ctx, c.B().Exec().Build()).ToArray()
+		if err != nil || len(exec) != 2 {
+			t.Fatalf("unexpected exec %v %v", exec, err)
+		}
+		if v, err := exec[1].AsStrSlice(); err != nil || !reflect.DeepEqual(v, []string{"a"}) {
+			t.Fatalf("unexpected keys in exec %v %v", v, err)
+		}
+		resps := c.DoMulti(ctx, c.B().Randomkey().Build(), c.B().Exec().Build())
+		if v, err := resps[0].ToString(); err != nil || v != "a" {
+			t.Fatalf("unexpected key %v %v", v, err)
+		}
+		if v, err := resps[1].ToArray(); err != nil || len(v) != 2 {
+			t.Fatalf("unexpected exec %v %v", v, err)
+		}
+	}
+	if err := client.Dedicated(func(c DedicatedClient) error {
+		check(c)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	c, cancel := client.Dedicate()
+	check(c)
+	cancel()
+}
+
+func TestWithKeyPrefixInvalidations(t *testing.T) {
+	var got []ValkeyMessage
+	fn := newKeyPrefix("t:").invalidations(func(keys []ValkeyMessage) { got = keys })
+	keys := []ValkeyMessage{strmsg('$', "t:a"), strmsg('$', "b")}
+	fn(keys)
+	if len(got) != 2 || got[0].string() != "a" || got[1].string() != "b" {
+		t.Fatalf("unexpected invalidations %v", got)
+	}
+	if keys[0].string() != "t:a" {
+		t.Fatalf("original invalidations should not be modified")
+	}
+	if fn(nil); got != nil {
+		t.Fatalf("nil invalidations should be passed as is")
+	}
+}
+
+func TestNewClientKeyPrefix(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	if _, err := NewClient(ClientOption{KeyPrefix: "t:"}); err != ErrNoAddr {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
@@ -88,15 +88,14 @@ func (r *ring) PutMulti(m []Completed, resps []ValkeyResult) chan ValkeyResult {
 
 // NextWriteCmd should be only called by one dedicated thread
 func (r *ring) NextWriteCmd() (one Completed, multi []Completed, ch chan ValkeyResult) {
-	r.read1++
-	p := r.read1 & r.mask
+	p := atomic.AddUint32(&r.read1, 1) & r.mask
 	n := &r.store[p]
 	n.c1.L.Lock()
 	if n.mark == 1 {
 		one, multi, ch = n.one, n.multi, n.ch
 		n.mark = 2
 	} else {
-		r.read1--
+		atomic.AddUint32(&r.read1, ^uint32(0))
 	}
 	n.c1.L.Unlock()
 	return
@@ -104,8 +103,7 @@ func (r *ring) NextWriteCmd() (one Completed, multi []Completed, ch chan ValkeyR
 
 // WaitForWrite should be only called by one dedicated thread
 func (r *ring) WaitForWrite() (one Completed, multi []Completed, ch chan ValkeyResult) {
-	r.read1++
-	p := r.read1 & r.mask
+	p := atomic.AddUint32(&r.read1, 1) & r.mask
 	n := &r.store[p]
 	n.c1.L.Lock()
 	for n.mark != 1 {
@@ -119,6 +117,14 @@ func (r *ring) WaitForWrite() (one Completed, multi []Completed, ch chan ValkeyR
 	return
 }
 
+// Queued returns the number of commands waiting to be written. It is safe to be called concurrently.
+func (r *ring) Queued() int {
+	if d := int32(atomic.LoadUint32(&r.write) - atomic.LoadUint32(&r.read1)); d > 0 {
+		return int(d)
+	}
+	return 0
+}
+
 // NextResultCh should be only called by one dedicated thread
 func (r *ring) NextResultCh() (one Completed, multi []Completed, ch chan ValkeyResult, resps []ValkeyResult, cond *sync.Cond) {
 	r.read2++
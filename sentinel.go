@@ -79,8 +79,10 @@ func (c *sentinelClient) B() Builder {
 
 func (c *sentinelClient) Do(ctx context.Context, cmd Completed) (resp ValkeyResult) {
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
 retry:
 	cc := c.pick(cmd)
+	trace.node(cc.Addr())
 	resp = cc.Do(ctx, cmd)
 	if err := resp.Error(); err != nil {
 		if err == errConnExpired {
@@ -89,6 +91,7 @@ retry:
 		if c.retry && cmd.IsReadOnly() && c.isRetryable(err, ctx) {
 			if c.retryHandler.WaitOrSkipRetry(ctx, attempts, cmd, err) {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -106,8 +109,10 @@ func (c *sentinelClient) DoMulti(ctx context.Context, multi ...Completed) []Valk
 
 	attempts := 1
 	sendToReplica := c.sendAllToReplica(multi)
+	trace := CommandTraceFromContext(ctx)
 retry:
 	cc := c.pickMulti(sendToReplica)
+	trace.node(cc.Addr())
 	resps := cc.DoMulti(ctx, multi...)
 	if c.hasLftm {
 		var ml []Completed
@@ -145,6 +150,7 @@ retry:
 				if shouldRetry {
 					resultsp.Put(resps)
 					attempts++
+					trace.retry()
 					goto retry
 				}
 			}
@@ -160,8 +166,10 @@ retry:
 
 func (c *sentinelClient) DoCache(ctx context.Context, cmd Cacheable, ttl time.Duration) (resp ValkeyResult) {
 	attempts := 1
+	trace := CommandTraceFromContext(ctx)
 retry:
 	cc := c.pick(Completed(cmd))
+	trace.node(cc.Addr())
 	resp = cc.DoCache(ctx, cmd, ttl)
 	if err := resp.Error(); err != nil {
 		if err == errConnExpired {
@@ -170,6 +178,7 @@ retry:
 		if c.retry && c.isRetryable(err, ctx) {
 			if c.retryHandler.WaitOrSkipRetry(ctx, attempts, Completed(cmd), err) {
 				attempts++
+				trace.retry()
 				goto retry
 			}
 		}
@@ -177,6 +186,7 @@ retry:
 	if err := resp.NonValkeyError(); err == nil || err == ErrDoCacheAborted {
 		cmds.PutCacheable(cmd)
 	}
+	trace.cached(resp)
 	return resp
 }
 
@@ -187,8 +197,10 @@ func (c *sentinelClient) DoMultiCache(ctx context.Context, multi ...CacheableTTL
 	attempts := 1
 
 	sendToReplica := c.sendAllToReplicaCache(multi)
+	trace := CommandTraceFromContext(ctx)
 retry:
 	cc := c.pickMulti(sendToReplica)
+	trace.node(cc.Addr())
 	resps := cc.DoMultiCache(ctx, multi...)
 	if c.hasLftm {
 		var ml []CacheableTTL
@@ -215,6 +227,7 @@ retry:
 				if shouldRetry {
 					resultsp.Put(resps)
 					attempts++
+					trace.retry()
 					goto retry
 				}
 			}
@@ -225,6 +238,7 @@ retry:
 			cmds.PutCacheable(cmd.Cmd)
 		}
 	}
+	trace.cached(resps.s...)
 	return resps.s
 }
 
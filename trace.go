@@ -0,0 +1,136 @@
+package valkey
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+type traceCtxKey int
+
+const commandTraceKey traceCtxKey = 0
+
+// commandTraced is set by the first WithCommandTrace, so that the clients skip looking up the context until then.
+var commandTraced uint32
+
+// CommandTrace records how the client served the commands of a Do, DoCache, DoMulti or DoMultiCache call.
+// It is filled in by the client when attached to the context with WithCommandTrace and is safe to read
+// after the call returns.
+type CommandTrace struct {
+	addrs     []string
+	redirects int
+	retries   int
+	cacheHits int
+	mu        sync.Mutex
+}
+
+// WithCommandTrace attaches a new CommandTrace to the provided context and returns both.
+// Pass the returned context to the client to let it record the nodes, redirects, retries and
+// client-side cache hits of the call. A CommandTrace should not be shared among concurrent calls.
+func WithCommandTrace(ctx context.Context) (context.Context, *CommandTrace) {
+	t := &CommandTrace{}
+	if atomic.LoadUint32(&commandTraced) == 0 {
+		atomic.StoreUint32(&commandTraced, 1)
+	}
+	return context.WithValue(ctx, commandTraceKey, t), t
+}
+
+// CommandTraceFromContext returns the CommandTrace attached by WithCommandTrace, or nil.
+func CommandTraceFromContext(ctx context.Context) *CommandTrace {
+	if atomic.LoadUint32(&commandTraced) == 0 {
+		return nil
+	}
+	if v := ctx.Value(commandTraceKey); v != nil {
+		return v.(*CommandTrace)
+	}
+	return nil
+}
+
+// Addr returns the address of the node that served the command last, or an empty string.
+func (t *CommandTrace) Addr() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.addrs) == 0 {
+		return ""
+	}
+	return t.addrs[len(t.addrs)-1]
+}
+
+// Addrs returns the distinct addresses of the nodes involved, ending with the node that served the command last.
+func (t *CommandTrace) Addrs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.addrs...)
+}
+
+// Redirects returns the number of MOVED and ASK redirects followed.
+func (t *CommandTrace) Redirects() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.redirects
+}
+
+// Retries returns the number of retry attempts made after the first one.
+func (t *CommandTrace) Retries() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.retries
+}
+
+// CacheHits returns the number of replies served from the client-side cache.
+func (t *CommandTrace) CacheHits() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cacheHits
+}
+
+func (t *CommandTrace) node(addr string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	for i, a := range t.addrs {
+		if a == addr {
+			// keep the last served node at the end
+			copy(t.addrs[i:], t.addrs[i+1:])
+			t.addrs[len(t.addrs)-1] = addr
+			t.mu.Unlock()
+			return
+		}
+	}
+	t.addrs = append(t.addrs, addr)
+	t.mu.Unlock()
+}
+
+func (t *CommandTrace) redirect(n int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.redirects += n
+	t.mu.Unlock()
+}
+
+func (t *CommandTrace) retry() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.retries++
+	t.mu.Unlock()
+}
+
+func (t *CommandTrace) cached(resps ...ValkeyResult) {
+	if t == nil {
+		return
+	}
+	n := 0
+	for _, resp := range resps {
+		if resp.IsCacheHit() {
+			n++
+		}
+	}
+	t.mu.Lock()
+	t.cacheHits += n
+	t.mu.Unlock()
+}
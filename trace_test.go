@@ -0,0 +1,127 @@
+package valkey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+func TestCommandTraceNil(t *testing.T) {
+	if CommandTraceFromContext(context.Background()) != nil {
+		t.Fatalf("unexpected trace")
+	}
+	var trace *CommandTrace
+	trace.node("a")
+	trace.redirect(1)
+	trace.retry()
+	trace.cached(ValkeyResult{})
+}
+
+func TestCommandTraceNode(t *testing.T) {
+	_, trace := WithCommandTrace(context.Background())
+	if trace.Addr() != "" {
+		t.Fatalf("unexpected addr %v", trace.Addr())
+	}
+	trace.node("a")
+	trace.node("b")
+	trace.node("a")
+	if trace.Addr() != "a" {
+		t.Fatalf("unexpected addr %v", trace.Addr())
+	}
+	if addrs := trace.Addrs(); len(addrs) != 2 || addrs[0] != "b" || addrs[1] != "a" {
+		t.Fatalf("unexpected addrs %v", addrs)
+	}
+}
+
+func TestCommandTraceSingleClient(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var count int64
+	m := &mockConn{
+		AddrFn: func() string { return "127.0.0.1:6379" },
+		DoFn: func(cmd Completed) ValkeyResult {
+			if atomic.AddInt64(&count, 1) <= 2 {
+				return newErrResult(errors.New("network error"))
+			}
+			return newResult(strmsg('+', "b"), nil)
+		},
+		DoCacheFn: func(cmd Cacheable, ttl time.Duration) ValkeyResult {
+			msg := strmsg('+', "b")
+			msg.attrs = cacheMark
+			return newResult(msg, nil)
+		},
+	}
+	client := newSingleClientWithConn(m, cmds.NewBuilder(cmds.NoSlot), true, false, newRetryer(func(int, Completed, error) time.Duration { return 0 }), false)
+
+	ctx, trace := WithCommandTrace(context.Background())
+	if v, err := client.Do(ctx, client.B().Get().Key("a").Build()).ToString(); err != nil || v != "b" {
+		t.Fatalf("unexpected resp %v %v", v, err)
+	}
+	if trace.Addr() != "127.0.0.1:6379" || trace.Retries() != 2 || trace.Redirects() != 0 || trace.CacheHits() != 0 {
+		t.Fatalf("unexpected trace %v %v %v %v", trace.Addr(), trace.Retries(), trace.Redirects(), trace.CacheHits())
+	}
+
+	ctx, trace = WithCommandTrace(context.Background())
+	if v, err := client.DoCache(ctx, client.B().Get().Key("a").Cache(), time.Second).ToString(); err != nil || v != "b" {
+		t.Fatalf("unexpected resp %v %v", v, err)
+	}
+	if trace.Addr() != "127.0.0.1:6379" || trace.Retries() != 0 || trace.CacheHits() != 1 {
+		t.Fatalf("unexpected trace %v %v %v", trace.Addr(), trace.Retries(), trace.CacheHits())
+	}
+}
+
+func TestCommandTraceClusterClient(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	var count int64
+	client, err := newClusterClient(
+		&ClientOption{InitAddress: []string{":0"}},
+		func(dst string, opt *ClientOption) conn {
+			return &mockConn{
+				AddrFn: func() string { return dst },
+				DoFn: func(cmd Completed) ValkeyResult {
+					if strings.Join(cmd.Commands(), " ") == "CLUSTER SLOTS" {
+						return slotsMultiResp
+					}
+					if atomic.AddInt64(&count, 1) <= 1 {
+						return newResult(strmsg('-', "MOVED 0 :2"), nil)
+					}
+					return newResult(strmsg('+', "b"), nil)
+				},
+				DoMultiFn: func(multi ...Completed) *valkeyresults {
+					resps := make([]ValkeyResult, len(multi))
+					for i := range resps {
+						resps[i] = newResult(strmsg('+', "b"), nil)
+					}
+					return &valkeyresults{s: resps}
+				},
+			}
+		},
+		newRetryer(defaultRetryDelayFn),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	defer client.Close()
+
+	ctx, trace := WithCommandTrace(context.Background())
+	if v, err := client.Do(ctx, client.B().Get().Key("a").Build()).ToString(); err != nil || v != "b" {
+		t.Fatalf("unexpected resp %v %v", v, err)
+	}
+	if trace.Addr() != ":2" || trace.Redirects() != 1 || trace.Retries() != 0 || len(trace.Addrs()) != 2 {
+		t.Fatalf("unexpected trace %v %v %v %v", trace.Addrs(), trace.Addr(), trace.Redirects(), trace.Retries())
+	}
+
+	ctx, trace = WithCommandTrace(context.Background())
+	for _, resp := range client.DoMulti(ctx, client.B().Get().Key("a").Build(), client.B().Get().Key("b").Build()) {
+		if v, err := resp.ToString(); err != nil || v != "b" {
+			t.Fatalf("unexpected resp %v %v", v, err)
+		}
+	}
+	if len(trace.Addrs()) != 2 || trace.Redirects() != 0 { // the slot of "a" has been moved to :2
+		t.Fatalf("unexpected trace %v %v", trace.Addrs(), trace.Redirects())
+	}
+}
@@ -113,6 +113,11 @@ type ClientOption struct {
 	// Sentinel options, including MasterSet and Auth options
 	Sentinel SentinelOption
 
+	// MetricsHooks are called to report the internal metrics of connections and pipelines,
+	// such as in-flight commands, ring queue depth, pool usage, bytes read/written and redirects.
+	// Hooks must be fast; otherwise other valkey commands will be blocked.
+	MetricsHooks MetricsHooks
+
 	// TCP & TLS
 	// Dialer can be used to customize how valkey connect to a valkey instance via TCP, including
 	// - Timeout, the default is DefaultDialTimeout
@@ -143,6 +148,10 @@ type ClientOption struct {
 	// The default is []string{"OPTIN"}
 	ClientTrackingOptions []string
 
+	// KeyPrefix namespaces the client by prepending the prefix to every key of commands and stripping it from keys in replies
+	// and in the OnInvalidations. See WithKeyPrefix for details.
+	KeyPrefix string
+
 	// Standalone is the option for the standalone client.
 	Standalone StandaloneOption
 
@@ -410,6 +419,17 @@ type AuthCredentials struct {
 // It will first try to connect as a cluster client. If the len(ClientOption.InitAddress) == 1 and
 // the address does not enable cluster mode, the NewClient() will use single client instead.
 func NewClient(option ClientOption) (client Client, err error) {
+	if option.KeyPrefix != "" {
+		p := newKeyPrefix(option.KeyPrefix)
+		if option.OnInvalidations != nil {
+			option.OnInvalidations = p.invalidations(option.OnInvalidations)
+		}
+		option.KeyPrefix = ""
+		if client, err = NewClient(option); err != nil {
+			return nil, err
+		}
+		return &prefixClient{client: client, p: p}, nil
+	}
 	if option.ReadBufferEachConn < 32 { // the buffer should be able to hold an int64 string at least
 		option.ReadBufferEachConn = DefaultReadBuffer
 	}
@@ -498,13 +518,17 @@ func dial(ctx context.Context, dst string, opt *ClientOption) (conn net.Conn, er
 	if opt.DialFn != nil {
 		return opt.DialFn(dst, &opt.Dialer, opt.TLSConfig)
 	}
-	if opt.TLSConfig != nil {
-		dialer := tls.Dialer{NetDialer: &opt.Dialer, Config: opt.TLSConfig}
-		conn, err = dialer.DialContext(ctx, "tcp", dst)
-	} else {
-		conn, err = opt.Dialer.DialContext(ctx, "tcp", dst)
+	return DefaultDialCtxFn(ctx, dst, &opt.Dialer, opt.TLSConfig)
+}
+
+// DefaultDialCtxFn dials the dst by TCP, or by TLS if the cfg is not nil. It is used by the client when neither
+// ClientOption.DialCtxFn nor ClientOption.DialFn is set, and can be wrapped by a custom DialCtxFn to observe the connections.
+func DefaultDialCtxFn(ctx context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (conn net.Conn, err error) {
+	if cfg != nil {
+		td := tls.Dialer{NetDialer: dialer, Config: cfg}
+		return td.DialContext(ctx, "tcp", dst)
 	}
-	return conn, err
+	return dialer.DialContext(ctx, "tcp", dst)
 }
 
 const valkeyErrMsgCommandNotAllow = "command is not allowed"
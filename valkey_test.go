@@ -498,6 +498,25 @@ func TestNewClientMaxMultiplex(t *testing.T) {
 	}
 }
 
+func TestDefaultDialCtxFn(t *testing.T) {
+	defer ShouldNotLeak(SetupLeakDetection())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	conn, err := DefaultDialCtxFn(context.Background(), ln.Addr().String(), &net.Dialer{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = DefaultDialCtxFn(ctx, ln.Addr().String(), &net.Dialer{}, &tls.Config{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
 func TestSingleClientMultiplex(t *testing.T) {
 	defer ShouldNotLeak(SetupLeakDetection())
 	option := ClientOption{}
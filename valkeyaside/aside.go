@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -19,11 +20,19 @@ type ClientOption struct {
 	ClientBuilder func(option valkey.ClientOption) (valkey.Client, error)
 	ClientOption  valkey.ClientOption
 	ClientTTL     time.Duration // TTL for the client marker, refreshed every 1/2 TTL. Defaults to 10s. The marker allows other clients to know if this client is still alive.
-	UseLuaLock    bool
+	// StaleTTL enables stale-while-revalidate. Values are kept for an extra StaleTTL after their TTL,
+	// and during that grace window they are still returned while one client refreshes them in the background.
+	// It relies on the client side caching to learn the remaining TTL of values.
+	StaleTTL time.Duration
+	// NegativeTTL enables negative caching. When the loader returns ErrNotFound, or a key is omitted from
+	// the result of a GetMulti loader, the not found result is cached for NegativeTTL instead of the full TTL.
+	NegativeTTL time.Duration
+	UseLuaLock  bool
 }
 
 type CacheAsideClient interface {
 	Get(ctx context.Context, ttl time.Duration, key string, fn func(ctx context.Context, key string) (val string, err error)) (val string, err error)
+	GetMulti(ctx context.Context, ttl time.Duration, keys []string, fn func(ctx context.Context, keys []string) (val map[string]string, err error)) (val map[string]string, err error)
 	Del(ctx context.Context, key string) error
 	Client() valkey.Client
 	Close()
@@ -34,9 +43,12 @@ func NewClient(option ClientOption) (cc CacheAsideClient, err error) {
 		option.ClientTTL = 10 * time.Second
 	}
 	ca := &Client{
-		waits:      make(map[string]chan struct{}),
-		ttl:        option.ClientTTL,
-		useLuaLock: option.UseLuaLock,
+		waits:       make(map[string]chan struct{}),
+		refreshes:   make(map[string]struct{}),
+		ttl:         option.ClientTTL,
+		staleTTL:    option.StaleTTL,
+		negativeTTL: option.NegativeTTL,
+		useLuaLock:  option.UseLuaLock,
 	}
 	option.ClientOption.OnInvalidations = ca.onInvalidation
 	if option.ClientBuilder != nil {
@@ -52,14 +64,17 @@ func NewClient(option ClientOption) (cc CacheAsideClient, err error) {
 }
 
 type Client struct {
-	client     valkey.Client
-	ctx        context.Context
-	waits      map[string]chan struct{}
-	cancel     context.CancelFunc
-	id         string
-	ttl        time.Duration
-	mu         sync.Mutex
-	useLuaLock bool
+	client      valkey.Client
+	ctx         context.Context
+	waits       map[string]chan struct{}
+	refreshes   map[string]struct{}
+	cancel      context.CancelFunc
+	id          string
+	ttl         time.Duration
+	staleTTL    time.Duration
+	negativeTTL time.Duration
+	mu          sync.Mutex
+	useLuaLock  bool
 }
 
 func (c *Client) onInvalidation(messages []valkey.ValkeyMessage) {
@@ -150,8 +165,9 @@ func (c *Client) Get(ctx context.Context, ttl time.Duration, key string, fn func
 
 retry:
 	wait := c.register(key)
-	resp := c.client.DoCache(ctx, c.client.B().Get().Key(key).Cache(), ttl)
+	resp := c.client.DoCache(ctx, c.client.B().Get().Key(key).Cache(), ttl+c.staleTTL)
 	val, err := resp.ToString()
+	cached := err == nil
 
 	if valkey.IsValkeyNil(err) && fn != nil { // cache miss, prepare to populate the value by fn()
 		var id string
@@ -164,7 +180,10 @@ retry:
 
 			if valkey.IsValkeyNil(err) { // successfully set client id on the key as a lock
 				if val, err = fn(ctx, key); err == nil {
-					err = setkey.Exec(ctx, c.client, []string{key}, []string{id, val, strconv.FormatInt(ttl.Milliseconds(), 10)}).Error()
+					err = setkey.Exec(ctx, c.client, []string{key}, []string{id, val, strconv.FormatInt((ttl + c.staleTTL).Milliseconds(), 10)}).Error()
+				} else if c.negativeTTL > 0 && errors.Is(err, ErrNotFound) {
+					val = notFoundMarker
+					err = setkey.Exec(ctx, c.client, []string{key}, []string{id, val, strconv.FormatInt(c.negativeTTL.Milliseconds(), 10)}).Error()
 				}
 				if err != nil { // failed to populate the value, release the lock.
 					delkey.Exec(context.Background(), c.client, []string{key}, []string{id})
@@ -197,9 +216,316 @@ retry:
 		}
 	}
 
+	if val == notFoundMarker {
+		return "", ErrNotFound
+	}
+
+	if cached && fn != nil && c.isStale(resp) {
+		go c.revalidate(ttl, []string{key}, []string{val}, func(ctx context.Context, keys []string) (map[string]string, error) {
+			val, err := fn(ctx, keys[0])
+			if errors.Is(err, ErrNotFound) {
+				return nil, nil
+			} else if err != nil {
+				return nil, err
+			}
+			return map[string]string{keys[0]: val}, nil
+		})
+	}
+
 	return val, err
 }
 
+// isStale reports whether the cached value is within its stale-while-revalidate grace window.
+func (c *Client) isStale(resp valkey.ValkeyResult) bool {
+	if c.staleTTL <= 0 {
+		return false
+	}
+	pttl := resp.CachePTTL()
+	return pttl >= 0 && pttl <= c.staleTTL.Milliseconds()
+}
+
+// revalidate refreshes stale values in the background. Only the client holding the refresh lock of a key
+// calls fn for it, and a value is replaced only if it is still the same stale value.
+func (c *Client) revalidate(ttl time.Duration, keys, stales []string, fn func(ctx context.Context, keys []string) (val map[string]string, err error)) {
+	c.mu.Lock()
+	n := 0
+	for i, key := range keys {
+		if _, ok := c.refreshes[key]; !ok {
+			c.refreshes[key] = struct{}{}
+			keys[n], stales[n] = keys[i], stales[i]
+			n++
+		}
+	}
+	c.mu.Unlock()
+	keys, stales = keys[:n], stales[:n]
+	if len(keys) == 0 {
+		return
+	}
+	defer func() {
+		c.mu.Lock()
+		for _, key := range keys {
+			delete(c.refreshes, key)
+		}
+		c.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(c.ctx, ttl)
+	defer cancel()
+
+	id, err := c.keepalive()
+	if err != nil {
+		return
+	}
+	cmds := make(valkey.Commands, len(keys))
+	for i, key := range keys {
+		cmds[i] = c.client.B().Set().Key(RefreshLockPrefix + key).Value(id).Nx().Px(ttl).Build()
+	}
+	var locked, lockedStales []string
+	for i, resp := range c.client.DoMulti(ctx, cmds...) {
+		if resp.Error() == nil {
+			locked = append(locked, keys[i])
+			lockedStales = append(lockedStales, stales[i])
+		}
+	}
+	if len(locked) == 0 {
+		return
+	}
+	defer func() {
+		multi := make([]valkey.LuaExec, len(locked))
+		for i, key := range locked {
+			multi[i] = valkey.LuaExec{Keys: []string{RefreshLockPrefix + key}, Args: []string{id}}
+		}
+		delkey.ExecMulti(context.Background(), c.client, multi...)
+	}()
+
+	vals, err := fn(ctx, locked)
+	if err != nil {
+		return
+	}
+	var sets, dels []valkey.LuaExec
+	for i, key := range locked {
+		if val, ok := vals[key]; ok {
+			sets = append(sets, valkey.LuaExec{Keys: []string{key}, Args: []string{lockedStales[i], val, strconv.FormatInt((ttl + c.staleTTL).Milliseconds(), 10)}})
+		} else if c.negativeTTL > 0 {
+			sets = append(sets, valkey.LuaExec{Keys: []string{key}, Args: []string{lockedStales[i], notFoundMarker, strconv.FormatInt(c.negativeTTL.Milliseconds(), 10)}})
+		} else {
+			dels = append(dels, valkey.LuaExec{Keys: []string{key}, Args: []string{lockedStales[i]}})
+		}
+	}
+	if len(sets) > 0 {
+		setkey.ExecMulti(ctx, c.client, sets...)
+	}
+	if len(dels) > 0 {
+		delkey.ExecMulti(ctx, c.client, dels...)
+	}
+}
+
+// GetMulti is the batched version of Get. It fetches all keys by one DoMultiCache, locks only the missing keys,
+// and calls fn once with all the keys it locked. Keys that are neither cached nor returned by fn are omitted
+// from the result, and their locks are released.
+func (c *Client) GetMulti(ctx context.Context, ttl time.Duration, keys []string, fn func(ctx context.Context, keys []string) (val map[string]string, err error)) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, ttl)
+	defer cancel()
+
+	ret := make(map[string]string, len(keys))
+	for len(keys) > 0 {
+		waits := make([]chan struct{}, len(keys))
+		cmds := make([]valkey.CacheableTTL, len(keys))
+		for i, key := range keys {
+			waits[i] = c.register(key)
+			cmds[i] = valkey.CT(c.client.B().Get().Key(key).Cache(), ttl+c.staleTTL)
+		}
+
+		var missing, pending, placeholders, staleKeys, staleVals []string
+		var missingWaits, pendingWaits []chan struct{}
+		for i, resp := range c.client.DoMultiCache(ctx, cmds...) {
+			val, err := resp.ToString()
+			if valkey.IsValkeyNil(err) {
+				missing = append(missing, keys[i])
+				missingWaits = append(missingWaits, waits[i])
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(val, PlaceholderPrefix) {
+				pending = append(pending, keys[i])
+				pendingWaits = append(pendingWaits, waits[i])
+				placeholders = append(placeholders, val)
+				continue
+			}
+			if val == notFoundMarker {
+				continue
+			}
+			if fn != nil && c.isStale(resp) {
+				staleKeys = append(staleKeys, keys[i])
+				staleVals = append(staleVals, val)
+			}
+			ret[keys[i]] = val
+		}
+
+		if len(missing) > 0 && fn != nil { // cache miss, prepare to populate the values by fn()
+			id, err := c.keepalive() // acquire client id
+			if err != nil {
+				return nil, err
+			}
+			locked := make([]string, 0, len(missing))
+			for i, resp := range c.acquireLocks(ctx, id, ttl, missing) {
+				val, err := resp.ToString()
+				if valkey.IsValkeyNil(err) { // successfully set client id on the key as a lock
+					locked = append(locked, missing[i])
+					continue
+				} else if err != nil {
+					c.releaseLocks(id, locked)
+					return nil, err
+				}
+				if strings.HasPrefix(val, PlaceholderPrefix) {
+					pending = append(pending, missing[i])
+					pendingWaits = append(pendingWaits, missingWaits[i])
+					placeholders = append(placeholders, val)
+					continue
+				}
+				if val != notFoundMarker {
+					ret[missing[i]] = val
+				}
+			}
+			if len(locked) > 0 {
+				if err = c.populate(ctx, id, ttl, locked, fn, ret); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if len(staleKeys) > 0 {
+			go c.revalidate(ttl, staleKeys, staleVals, fn)
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		gone := false
+		cmds = cmds[:0]
+		for _, ph := range placeholders {
+			cmds = append(cmds, valkey.CT(c.client.B().Get().Key(ph).Cache(), c.ttl))
+		}
+		phs := make([]chan struct{}, len(placeholders))
+		for i, ph := range placeholders {
+			phs[i] = c.register(ph)
+		}
+		for i, resp := range c.client.DoMultiCache(ctx, cmds...) {
+			if err := resp.Error(); valkey.IsValkeyNil(err) {
+				// the client who held the lock has gone, release the lock.
+				delkey.Exec(context.Background(), c.client, []string{pending[i]}, []string{placeholders[i]})
+				gone = true
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if !gone {
+			// wake up on any progress, since other pending keys can be filled before the first one.
+			if err := waitAny(ctx, append(phs, pendingWaits...)); err != nil {
+				return nil, err
+			}
+		}
+		keys = pending
+	}
+	return ret, nil
+}
+
+// waitAny blocks until any of the chs is closed or the ctx is done.
+func waitAny(ctx context.Context, chs []chan struct{}) error {
+	if len(chs) == 1 {
+		select {
+		case <-chs[0]:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	woken := make(chan struct{}, 1)
+	done := make(chan struct{})
+	defer close(done)
+	for _, ch := range chs {
+		go func(ch chan struct{}) {
+			select {
+			case <-ch:
+				select {
+				case woken <- struct{}{}:
+				default:
+				}
+			case <-done:
+			}
+		}(ch)
+	}
+	select {
+	case <-woken:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) acquireLocks(ctx context.Context, id string, ttl time.Duration, keys []string) []valkey.ValkeyResult {
+	if c.useLuaLock {
+		multi := make([]valkey.LuaExec, len(keys))
+		for i, key := range keys {
+			multi[i] = valkey.LuaExec{Keys: []string{key}, Args: []string{id, strconv.FormatInt(ttl.Milliseconds(), 10)}}
+		}
+		return acquireLock.ExecMulti(ctx, c.client, multi...)
+	}
+	cmds := make(valkey.Commands, len(keys))
+	for i, key := range keys {
+		cmds[i] = c.client.B().Set().Key(key).Value(id).Nx().Get().Px(ttl).Build()
+	}
+	return c.client.DoMulti(ctx, cmds...)
+}
+
+// populate calls fn with the locked keys and stores its result into both Valkey and ret.
+// Keys not returned by fn are cached as not found if the NegativeTTL is set. Otherwise, their locks are released.
+func (c *Client) populate(ctx context.Context, id string, ttl time.Duration, locked []string, fn func(ctx context.Context, keys []string) (val map[string]string, err error), ret map[string]string) error {
+	vals, err := fn(ctx, locked)
+	if err != nil { // failed to populate the values, release the locks.
+		c.releaseLocks(id, locked)
+		return err
+	}
+	var absent []string
+	multi := make([]valkey.LuaExec, 0, len(locked))
+	for _, key := range locked {
+		if val, ok := vals[key]; ok {
+			multi = append(multi, valkey.LuaExec{Keys: []string{key}, Args: []string{id, val, strconv.FormatInt((ttl + c.staleTTL).Milliseconds(), 10)}})
+		} else if c.negativeTTL > 0 {
+			multi = append(multi, valkey.LuaExec{Keys: []string{key}, Args: []string{id, notFoundMarker, strconv.FormatInt(c.negativeTTL.Milliseconds(), 10)}})
+		} else {
+			absent = append(absent, key)
+		}
+	}
+	c.releaseLocks(id, absent)
+	if len(multi) == 0 {
+		return nil
+	}
+	for i, resp := range setkey.ExecMulti(ctx, c.client, multi...) {
+		if err = resp.Error(); err != nil {
+			c.releaseLocks(id, locked)
+			return err
+		}
+		if val, ok := vals[multi[i].Keys[0]]; ok {
+			ret[multi[i].Keys[0]] = val
+		}
+	}
+	return nil
+}
+
+func (c *Client) releaseLocks(id string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	multi := make([]valkey.LuaExec, len(keys))
+	for i, key := range keys {
+		multi[i] = valkey.LuaExec{Keys: []string{key}, Args: []string{id}}
+	}
+	delkey.ExecMulti(context.Background(), c.client, multi...)
+}
+
 func (c *Client) Del(ctx context.Context, key string) error {
 	return c.client.Do(ctx, c.client.B().Del().Key(key).Build()).Error()
 }
@@ -222,6 +548,15 @@ func (c *Client) Close() {
 
 const PlaceholderPrefix = "valkeyid:"
 
+// RefreshLockPrefix is the prefix of the keys used to elect the client refreshing a stale value.
+const RefreshLockPrefix = "valkeyrefresh:"
+
+// ErrNotFound can be returned by the loader of Get to indicate that the value doesn't exist.
+// It is cached for the NegativeTTL if set, and returned by Get until the negative cache expires.
+var ErrNotFound = errors.New("valkeyaside: not found")
+
+const notFoundMarker = "valkeynotfound:"
+
 var (
 	delkey      = valkey.NewLuaScript(`if redis.call("GET",KEYS[1]) == ARGV[1] then return redis.call("DEL",KEYS[1]) else return 0 end`)
 	setkey      = valkey.NewLuaScript(`if redis.call("GET",KEYS[1]) == ARGV[1] then return redis.call("SET",KEYS[1],ARGV[2],"PX",ARGV[3]) else return 0 end`)
@@ -2,6 +2,7 @@ package valkeyaside
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"strconv"
 	"sync"
@@ -512,3 +513,251 @@ func TestMultipleClientLL(t *testing.T) {
 		}
 	}
 }
+
+func TestGetMulti(t *testing.T) {
+	for _, mk := range []func(t *testing.T, addr []string) CacheAsideClient{makeClient, makeClientWithLuaLock} {
+		client := mk(t, addr)
+		keys := []string{strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int())}
+		if err := client.Client().Do(context.Background(), client.Client().B().Set().Key(keys[0]).Value("0").Build()).Error(); err != nil {
+			t.Fatal(err)
+		}
+		calls := 0
+		fn := func(ctx context.Context, missing []string) (map[string]string, error) {
+			calls++
+			if len(missing) != 2 || missing[0] != keys[1] || missing[1] != keys[2] {
+				t.Fatalf("unexpected missing keys %v", missing)
+			}
+			return map[string]string{keys[1]: "1"}, nil // keys[2] is not found
+		}
+		for i := 0; i < 2; i++ {
+			val, err := client.GetMulti(context.Background(), time.Millisecond*500, keys, fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(val) != 2 || val[keys[0]] != "0" || val[keys[1]] != "1" {
+				t.Fatalf("unexpected values %v", val)
+			}
+			fn = func(ctx context.Context, missing []string) (map[string]string, error) {
+				if len(missing) != 1 || missing[0] != keys[2] {
+					t.Fatalf("unexpected missing keys %v", missing)
+				}
+				return nil, nil
+			}
+		}
+		if calls != 1 {
+			t.Fatalf("unexpected calls %v", calls)
+		}
+		// the lock of the not found key should be released
+		if err := client.Client().Do(context.Background(), client.Client().B().Get().Key(keys[2]).Build()).Error(); !valkey.IsValkeyNil(err) {
+			t.Fatal(err)
+		}
+		val, err := client.GetMulti(context.Background(), time.Millisecond*500, keys, nil)
+		if err != nil || len(val) != 2 {
+			t.Fatalf("unexpected values %v %v", val, err)
+		}
+		client.Close()
+	}
+}
+
+func TestGetMultiErr(t *testing.T) {
+	client := makeClient(t, addr)
+	defer client.Close()
+	keys := []string{strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int())}
+	_, err := client.GetMulti(context.Background(), time.Millisecond*500, keys, func(ctx context.Context, keys []string) (map[string]string, error) {
+		return nil, errors.New("loader")
+	})
+	if err == nil || err.Error() != "loader" {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if err := client.Client().Do(context.Background(), client.Client().B().Get().Key(key).Build()).Error(); !valkey.IsValkeyNil(err) {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGetMultiMultipleClient(t *testing.T) {
+	clients := make([]CacheAsideClient, 10)
+	for i := 0; i < len(clients); i++ {
+		clients[i] = makeClient(t, addr)
+	}
+	defer func() {
+		for _, client := range clients {
+			client.Close()
+		}
+	}()
+	keys := []string{strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int())}
+	sum := int64(0)
+	var wg sync.WaitGroup
+	wg.Add(len(clients))
+	for _, c := range clients {
+		go func(c CacheAsideClient) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				v, err := c.GetMulti(context.Background(), time.Second, keys, func(ctx context.Context, keys []string) (map[string]string, error) {
+					ret := make(map[string]string, len(keys))
+					for _, k := range keys {
+						atomic.AddInt64(&sum, 1)
+						ret[k] = k
+					}
+					return ret, nil
+				})
+				if err != nil || len(v) != len(keys) {
+					t.Error(v, err)
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	if atomic.LoadInt64(&sum) != int64(len(keys)) {
+		t.Fatalf("unexpected sum")
+	}
+}
+
+func TestWaitAny(t *testing.T) {
+	chs := []chan struct{}{make(chan struct{}), make(chan struct{}), make(chan struct{})}
+	go close(chs[2])
+	if err := waitAny(context.Background(), chs); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := waitAny(ctx, chs[:2]); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := waitAny(ctx, chs[:1]); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+func makeClientWithOption(t *testing.T, addr []string, option ClientOption) CacheAsideClient {
+	option.ClientOption = valkey.ClientOption{InitAddress: addr, PipelineMultiplex: -1, SelectDB: 5}
+	option.ClientTTL = time.Second
+	client, err := NewClient(option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestNegativeCache(t *testing.T) {
+	for _, useLuaLock := range []bool{false, true} {
+		client := makeClientWithOption(t, addr, ClientOption{NegativeTTL: time.Millisecond * 200, UseLuaLock: useLuaLock})
+		key := strconv.Itoa(rand.Int())
+		calls := 0
+		fn := func(ctx context.Context, key string) (val string, err error) {
+			calls++
+			return "", ErrNotFound
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := client.Get(context.Background(), time.Second, key, fn); err != ErrNotFound {
+				t.Fatal(err)
+			}
+		}
+		if calls != 1 {
+			t.Fatalf("unexpected calls %v", calls)
+		}
+		time.Sleep(time.Millisecond * 300)
+		val, err := client.Get(context.Background(), time.Second, key, func(ctx context.Context, key string) (val string, err error) {
+			return "1", nil
+		})
+		if err != nil || val != "1" {
+			t.Fatal(val, err)
+		}
+		client.Close()
+	}
+}
+
+func TestNegativeCacheDisabled(t *testing.T) {
+	client := makeClient(t, addr)
+	defer client.Close()
+	key := strconv.Itoa(rand.Int())
+	if _, err := client.Get(context.Background(), time.Second, key, func(ctx context.Context, key string) (val string, err error) {
+		return "", ErrNotFound
+	}); err != ErrNotFound {
+		t.Fatal(err)
+	}
+	// the lock should be released without caching the not found result
+	if err := client.Client().Do(context.Background(), client.Client().B().Get().Key(key).Build()).Error(); !valkey.IsValkeyNil(err) {
+		t.Fatal(err)
+	}
+}
+
+func TestGetMultiNegativeCache(t *testing.T) {
+	client := makeClientWithOption(t, addr, ClientOption{NegativeTTL: time.Millisecond * 200})
+	defer client.Close()
+	keys := []string{strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int())}
+	calls := 0
+	fn := func(ctx context.Context, keys []string) (map[string]string, error) {
+		calls++
+		return map[string]string{keys[0]: "0"}, nil
+	}
+	for i := 0; i < 2; i++ {
+		val, err := client.GetMulti(context.Background(), time.Second, keys, fn)
+		if err != nil || len(val) != 1 || val[keys[0]] != "0" {
+			t.Fatal(val, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if val, err := client.Get(context.Background(), time.Second, keys[1], nil); err != ErrNotFound {
+		t.Fatal(val, err)
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	client := makeClientWithOption(t, addr, ClientOption{StaleTTL: time.Second})
+	defer client.Close()
+	key := strconv.Itoa(rand.Int())
+	val, err := client.Get(context.Background(), time.Millisecond*300, key, func(ctx context.Context, key string) (val string, err error) {
+		return "1", nil
+	})
+	if err != nil || val != "1" {
+		t.Fatal(val, err)
+	}
+	time.Sleep(time.Millisecond * 400) // in the grace window
+	refreshed := make(chan struct{})
+	val, err = client.Get(context.Background(), time.Millisecond*300, key, func(ctx context.Context, key string) (val string, err error) {
+		defer close(refreshed)
+		return "2", nil
+	})
+	if err != nil || val != "1" {
+		t.Fatal(val, err)
+	}
+	<-refreshed
+	for val != "2" {
+		if val, err = client.Get(context.Background(), time.Millisecond*300, key, nil); err != nil {
+			t.Fatal(val, err)
+		}
+	}
+}
+
+func TestGetMultiStaleWhileRevalidate(t *testing.T) {
+	client := makeClientWithOption(t, addr, ClientOption{StaleTTL: time.Second})
+	defer client.Close()
+	keys := []string{strconv.Itoa(rand.Int()), strconv.Itoa(rand.Int())}
+	val, err := client.GetMulti(context.Background(), time.Millisecond*300, keys, func(ctx context.Context, keys []string) (map[string]string, error) {
+		return map[string]string{keys[0]: "1", keys[1]: "1"}, nil
+	})
+	if err != nil || len(val) != 2 {
+		t.Fatal(val, err)
+	}
+	time.Sleep(time.Millisecond * 400) // in the grace window
+	refreshed := make(chan []string, 1)
+	val, err = client.GetMulti(context.Background(), time.Millisecond*300, keys, func(ctx context.Context, keys []string) (map[string]string, error) {
+		refreshed <- keys
+		return map[string]string{keys[0]: "2"}, nil // keys[1] is gone
+	})
+	if err != nil || val[keys[0]] != "1" || val[keys[1]] != "1" {
+		t.Fatal(val, err)
+	}
+	if refreshing := <-refreshed; len(refreshing) != 2 {
+		t.Fatalf("unexpected refreshing keys %v", refreshing)
+	}
+	for val[keys[0]] != "2" || len(val) != 1 {
+		if val, err = client.GetMulti(context.Background(), time.Millisecond*300, keys, nil); err != nil {
+			t.Fatal(val, err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 // It allows you to cache and retrieve values of a specific type T.
 type TypedCacheAsideClient[T any] interface {
 	Get(ctx context.Context, ttl time.Duration, key string, fn func(ctx context.Context, key string) (val *T, err error)) (val *T, err error)
+	GetMulti(ctx context.Context, ttl time.Duration, keys []string, fn func(ctx context.Context, keys []string) (val map[string]*T, err error)) (val map[string]*T, err error)
 	Del(ctx context.Context, key string) error
 	Client() CacheAsideClient
 }
@@ -54,6 +55,35 @@ func (c typedCacheAsideClient[T]) Get(ctx context.Context, ttl time.Duration, ke
 	return c.deserializer(strVal)
 }
 
+// GetMulti retrieves values of type T for multiple keys from the cache. Missing keys are fetched by a single
+// call to the provided function and stored in the cache. The values are cached for the specified TTL.
+// Keys that are neither cached nor returned by the function are omitted from the result.
+func (c typedCacheAsideClient[T]) GetMulti(ctx context.Context, ttl time.Duration, keys []string, fn func(ctx context.Context, keys []string) (val map[string]*T, err error)) (val map[string]*T, err error) {
+	strVals, err := c.client.GetMulti(ctx, ttl, keys, func(ctx context.Context, keys []string) (val map[string]string, err error) {
+		results, err := fn(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		val = make(map[string]string, len(results))
+		for k, v := range results {
+			if val[k], err = c.serializer(v); err != nil {
+				return nil, err
+			}
+		}
+		return val, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	val = make(map[string]*T, len(strVals))
+	for k, v := range strVals {
+		if val[k], err = c.deserializer(v); err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
 // Del deletes the value associated with the given key from the cache.
 func (c typedCacheAsideClient[T]) Del(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key)
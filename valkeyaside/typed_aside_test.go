@@ -163,3 +163,56 @@ func TestTypedCacheAsideClient_Del(t *testing.T) {
 		t.Fatal("expected function to be called because the value should be deleted")
 	}
 }
+
+func TestTypedCacheAsideClient_GetMulti(t *testing.T) {
+	baseClient := makeClient(t, addr)
+	t.Cleanup(baseClient.Close)
+
+	serializer := func(v *testStruct) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+	deserializer := func(s string) (*testStruct, error) {
+		var v testStruct
+		err := json.Unmarshal([]byte(s), &v)
+		return &v, err
+	}
+
+	client := NewTypedCacheAsideClient[testStruct](baseClient, serializer, deserializer)
+
+	keys := []string{randStr(), randStr()}
+	for i := 0; i < 2; i++ {
+		val, err := client.GetMulti(context.Background(), time.Second, keys, func(ctx context.Context, keys []string) (map[string]*testStruct, error) {
+			if i > 0 {
+				t.Fatal("values should be cached")
+			}
+			return map[string]*testStruct{keys[0]: {ID: 1, Name: "a"}, keys[1]: {ID: 2, Name: "b"}}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(val) != 2 || *val[keys[0]] != (testStruct{ID: 1, Name: "a"}) || *val[keys[1]] != (testStruct{ID: 2, Name: "b"}) {
+			t.Fatalf("unexpected values %v", val)
+		}
+	}
+
+	t.Run("loader error", func(t *testing.T) {
+		_, err := client.GetMulti(context.Background(), time.Second, []string{randStr()}, func(ctx context.Context, keys []string) (map[string]*testStruct, error) {
+			return nil, errors.New("loader error")
+		})
+		if err == nil {
+			t.Fatal("expected loader error")
+		}
+	})
+
+	t.Run("deserialization error", func(t *testing.T) {
+		badDeserializer := func(s string) (*testStruct, error) {
+			return nil, errors.New("deserialization error")
+		}
+		clientWithBadDeserializer := NewTypedCacheAsideClient[testStruct](baseClient, serializer, badDeserializer)
+		_, err := clientWithBadDeserializer.GetMulti(context.Background(), time.Second, keys, nil)
+		if err == nil {
+			t.Fatal("expected deserialization error")
+		}
+	})
+}
@@ -29,7 +29,10 @@ package valkeycompat
 import (
 	"context"
 	"encoding"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"runtime"
 	"strconv"
@@ -60,6 +63,8 @@ type Cmdable interface {
 	SSubscribe(ctx context.Context, channels ...string) PubSub
 
 	Watch(ctx context.Context, fn func(Tx) error, keys ...string) error
+
+	AddHook(hook Hook)
 }
 
 type CoreCmdable interface {
@@ -436,6 +441,7 @@ type CoreCmdable interface {
 	TimeseriesCmdable
 	JSONCmdable
 	SearchCmdable
+	VectorSetCmdable
 }
 
 type SearchCmdable interface {
@@ -459,6 +465,8 @@ type SearchCmdable interface {
 	FTExplain(ctx context.Context, index string, query string) *StringCmd
 	FTExplainWithArgs(ctx context.Context, index string, query string, options *FTExplainOptions) *StringCmd
 	FTInfo(ctx context.Context, index string) *FTInfoCmd
+	FTProfileSearch(ctx context.Context, index string, limited bool, query string) *FTProfileCmd
+	FTProfileAggregate(ctx context.Context, index string, limited bool, query string) *FTProfileCmd
 	FTSpellCheck(ctx context.Context, index string, query string) *FTSpellCheckCmd
 	FTSpellCheckWithArgs(ctx context.Context, index string, query string, options *FTSpellCheckOptions) *FTSpellCheckCmd
 	FTSearch(ctx context.Context, index string, query string) *FTSearchCmd
@@ -469,6 +477,28 @@ type SearchCmdable interface {
 	FTTagVals(ctx context.Context, index string, field string) *StringSliceCmd
 }
 
+// see go-redis v9.10.0 https://github.com/redis/go-redis/blob/v9.10.0/vectorset_commands.go
+type VectorSetCmdable interface {
+	VAdd(ctx context.Context, key, element string, val Vector) *BoolCmd
+	VAddWithArgs(ctx context.Context, key, element string, val Vector, addArgs *VAddArgs) *BoolCmd
+	VCard(ctx context.Context, key string) *IntCmd
+	VDim(ctx context.Context, key string) *IntCmd
+	VEmb(ctx context.Context, key, element string, raw bool) *SliceCmd
+	VGetAttr(ctx context.Context, key, element string) *StringCmd
+	VInfo(ctx context.Context, key string) *MapStringInterfaceCmd
+	VLinks(ctx context.Context, key, element string) *StringSliceCmd
+	VLinksWithScores(ctx context.Context, key, element string) *VectorScoreSliceCmd
+	VRandMember(ctx context.Context, key string) *StringCmd
+	VRandMemberCount(ctx context.Context, key string, count int) *StringSliceCmd
+	VRem(ctx context.Context, key, element string) *BoolCmd
+	VSetAttr(ctx context.Context, key, element string, attr interface{}) *BoolCmd
+	VClearAttributes(ctx context.Context, key, element string) *BoolCmd
+	VSim(ctx context.Context, key string, val Vector) *StringSliceCmd
+	VSimWithScores(ctx context.Context, key string, val Vector) *VectorScoreSliceCmd
+	VSimWithArgs(ctx context.Context, key string, val Vector, args *VSimArgs) *StringSliceCmd
+	VSimWithArgsWithScores(ctx context.Context, key string, val Vector, args *VSimArgs) *VectorScoreSliceCmd
+}
+
 // https://github.com/redis/go-redis/blob/af4872cbd0de349855ce3f0978929c2f56eb995f/probabilistic.go#L10
 type ProbabilisticCmdable interface {
 	BFAdd(ctx context.Context, key string, element interface{}) *BoolCmd
@@ -5610,6 +5640,34 @@ func (c *Compat) FTInfo(ctx context.Context, index string) *FTInfoCmd {
 	return newFTInfoCmd(c.client.Do(ctx, cmd))
 }
 
+// FTProfileSearch - Executes a search query and returns its result with the profile of the query execution.
+// The 'limited' parameter removes details of the reader iterators from the profile.
+// For more information, please refer to the Redis documentation:
+// [FT.PROFILE]: (https://redis.io/commands/ft.profile/)
+func (c *Compat) FTProfileSearch(ctx context.Context, index string, limited bool, query string) *FTProfileCmd {
+	var cmd valkey.Completed
+	if limited {
+		cmd = c.client.B().FtProfile().Index(index).Search().Limited().Query(query).Build()
+	} else {
+		cmd = c.client.B().FtProfile().Index(index).Search().Query(query).Build()
+	}
+	return newFTProfileCmd(c.client.Do(ctx, cmd), false)
+}
+
+// FTProfileAggregate - Executes an aggregate query and returns its result with the profile of the query execution.
+// The 'limited' parameter removes details of the reader iterators from the profile.
+// For more information, please refer to the Redis documentation:
+// [FT.PROFILE]: (https://redis.io/commands/ft.profile/)
+func (c *Compat) FTProfileAggregate(ctx context.Context, index string, limited bool, query string) *FTProfileCmd {
+	var cmd valkey.Completed
+	if limited {
+		cmd = c.client.B().FtProfile().Index(index).Aggregate().Limited().Query(query).Build()
+	} else {
+		cmd = c.client.B().FtProfile().Index(index).Aggregate().Query(query).Build()
+	}
+	return newFTProfileCmd(c.client.Do(ctx, cmd), true)
+}
+
 // FTSpellCheck - Checks a query string for spelling errors.
 // For more details about a spellcheck query please follow:
 // https://redis.io/docs/interact/search-and-query/advanced-concepts/spellcheck/
@@ -5747,8 +5805,32 @@ func (c *Compat) FTSearchWithArgs(ctx context.Context, index string, query strin
 				}
 			}
 		}
-		// FIXME: go-redis doesn't implement SUMMARIZE option
 		// [SUMMARIZE [ FIELDS count field [field ...]] [FRAGS num] [LEN fragsize] [SEPARATOR separator]]
+		if sum := options.Summarize; sum != nil {
+			_cmd = cmds.Incomplete(cmds.FtSearchQuery(_cmd).Summarize())
+			if len(sum.Fields) > 0 {
+				_cmd = cmds.Incomplete(cmds.FtSearchSummarizeSummarize(_cmd).Fields(strconv.Itoa(len(sum.Fields))).Field(sum.Fields...))
+			}
+			if sum.Frags > 0 {
+				_cmd = cmds.Incomplete(cmds.FtSearchSummarizeSummarize(_cmd).Frags(int64(sum.Frags)))
+			}
+			if sum.Len > 0 {
+				_cmd = cmds.Incomplete(cmds.FtSearchSummarizeFrags(_cmd).Len(int64(sum.Len)))
+			}
+			if sum.Separator != "" {
+				_cmd = cmds.Incomplete(cmds.FtSearchSummarizeLen(_cmd).Separator(sum.Separator))
+			}
+		}
+		// [HIGHLIGHT [ FIELDS count field [field ...]] [ TAGS open close]]
+		if hl := options.Highlight; hl != nil {
+			_cmd = cmds.Incomplete(cmds.FtSearchQuery(_cmd).Highlight())
+			if len(hl.Fields) > 0 {
+				_cmd = cmds.Incomplete(cmds.FtSearchHighlightHighlight(_cmd).Fields(strconv.Itoa(len(hl.Fields))).Field(hl.Fields...))
+			}
+			if hl.OpenTag != "" || hl.CloseTag != "" {
+				_cmd = cmds.Incomplete(cmds.FtSearchHighlightHighlight(_cmd).Tags().OpenClose(hl.OpenTag, hl.CloseTag))
+			}
+		}
 		// [SLOP slop]
 		if options.Slop > 0 {
 			_cmd = cmds.Incomplete(cmds.FtSearchQuery(_cmd).Slop(int64(options.Slop)))
@@ -5866,6 +5948,258 @@ func (c *Compat) FTTagVals(ctx context.Context, index string, field string) *Str
 	return newStringSliceCmd(c.client.Do(ctx, cmd))
 }
 
+// vectorValues converts the val to float32 values. The VectorRef is not convertible and is handled by the callers.
+func vectorValues(val Vector) ([]float32, error) {
+	switch v := val.(type) {
+	case *VectorValues:
+		vec := make([]float32, len(v.Val))
+		for i, f := range v.Val {
+			vec[i] = float32(f)
+		}
+		return vec, nil
+	case *VectorFP32:
+		if len(v.Val)%4 != 0 {
+			return nil, fmt.Errorf("the length of VectorFP32 should be a multiple of 4, got %v", len(v.Val))
+		}
+		vec := make([]float32, len(v.Val)/4)
+		for i := range vec {
+			vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(v.Val[i*4:]))
+		}
+		return vec, nil
+	default:
+		return nil, fmt.Errorf("unsupported vector %T", val)
+	}
+}
+
+// VAdd - Adds an element with the vector to the vector set.
+// For more information, please refer to the Redis documentation:
+// [VADD]: (https://redis.io/commands/vadd/)
+func (c *Compat) VAdd(ctx context.Context, key, element string, val Vector) *BoolCmd {
+	return c.VAddWithArgs(ctx, key, element, val, nil)
+}
+
+// VAddWithArgs - Adds an element with the vector to the vector set with additional options.
+// For more information, please refer to the Redis documentation:
+// [VADD]: (https://redis.io/commands/vadd/)
+func (c *Compat) VAddWithArgs(ctx context.Context, key, element string, val Vector, addArgs *VAddArgs) *BoolCmd {
+	_cmd := cmds.Incomplete(c.client.B().Vadd().Key(key))
+	if addArgs != nil && addArgs.Reduce > 0 {
+		_cmd = cmds.Incomplete(cmds.VaddKey(_cmd).Reduce(addArgs.Reduce))
+	}
+	vec, err := vectorValues(val)
+	if err != nil {
+		return &BoolCmd{baseCmd: baseCmd[bool]{err: err}}
+	}
+	_cmd = cmds.Incomplete(cmds.VaddKey(_cmd).Values(int64(len(vec))).Vector(vec...).Element(element))
+	if addArgs != nil {
+		if addArgs.Cas {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).Cas())
+		}
+		if addArgs.NoQuant {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).Noquant())
+		} else if addArgs.Bin {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).Bin())
+		}
+		if addArgs.EF > 0 {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).Ef(addArgs.EF))
+		}
+		if addArgs.SetAttr != "" {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).Setattr(addArgs.SetAttr))
+		}
+		if addArgs.M > 0 {
+			_cmd = cmds.Incomplete(cmds.VaddElement(_cmd).M(addArgs.M))
+		}
+	}
+	cmd := cmds.VaddElement(_cmd).Build()
+	return newBoolCmd(c.client.Do(ctx, cmd))
+}
+
+// VCard - Returns the number of elements in the vector set.
+// For more information, please refer to the Redis documentation:
+// [VCARD]: (https://redis.io/commands/vcard/)
+func (c *Compat) VCard(ctx context.Context, key string) *IntCmd {
+	cmd := c.client.B().Vcard().Key(key).Build()
+	return newIntCmd(c.client.Do(ctx, cmd))
+}
+
+// VDim - Returns the dimension of the vectors in the vector set.
+// For more information, please refer to the Redis documentation:
+// [VDIM]: (https://redis.io/commands/vdim/)
+func (c *Compat) VDim(ctx context.Context, key string) *IntCmd {
+	cmd := c.client.B().Vdim().Key(key).Build()
+	return newIntCmd(c.client.Do(ctx, cmd))
+}
+
+// VEmb - Returns the vector of the element. If 'raw' is true, the internal representation of the vector is returned instead.
+// For more information, please refer to the Redis documentation:
+// [VEMB]: (https://redis.io/commands/vemb/)
+func (c *Compat) VEmb(ctx context.Context, key, element string, raw bool) *SliceCmd {
+	var cmd valkey.Completed
+	if raw {
+		cmd = c.client.B().Vemb().Key(key).Element(element).Raw().Build()
+	} else {
+		cmd = c.client.B().Vemb().Key(key).Element(element).Build()
+	}
+	return newSliceCmd(c.client.Do(ctx, cmd), false)
+}
+
+// VGetAttr - Returns the JSON attributes of the element.
+// For more information, please refer to the Redis documentation:
+// [VGETATTR]: (https://redis.io/commands/vgetattr/)
+func (c *Compat) VGetAttr(ctx context.Context, key, element string) *StringCmd {
+	cmd := c.client.B().Vgetattr().Key(key).Element(element).Build()
+	return newStringCmd(c.client.Do(ctx, cmd))
+}
+
+// VInfo - Returns information about the vector set.
+// For more information, please refer to the Redis documentation:
+// [VINFO]: (https://redis.io/commands/vinfo/)
+func (c *Compat) VInfo(ctx context.Context, key string) *MapStringInterfaceCmd {
+	cmd := c.client.B().Vinfo().Key(key).Build()
+	return newMapStringInterfaceCmd(c.client.Do(ctx, cmd))
+}
+
+// VLinks - Returns the neighbors of the element in all layers of the HNSW graph.
+// For more information, please refer to the Redis documentation:
+// [VLINKS]: (https://redis.io/commands/vlinks/)
+func (c *Compat) VLinks(ctx context.Context, key, element string) *StringSliceCmd {
+	cmd := c.client.B().Vlinks().Key(key).Element(element).Build()
+	ret := &StringSliceCmd{layers: true}
+	ret.from(c.client.Do(ctx, cmd))
+	return ret
+}
+
+// VLinksWithScores - Returns the neighbors of the element in all layers of the HNSW graph with their similarity scores.
+// For more information, please refer to the Redis documentation:
+// [VLINKS]: (https://redis.io/commands/vlinks/)
+func (c *Compat) VLinksWithScores(ctx context.Context, key, element string) *VectorScoreSliceCmd {
+	cmd := c.client.B().Vlinks().Key(key).Element(element).Withscores().Build()
+	return newVectorScoreSliceCmd(c.client.Do(ctx, cmd), true)
+}
+
+// VRandMember - Returns a random element of the vector set.
+// For more information, please refer to the Redis documentation:
+// [VRANDMEMBER]: (https://redis.io/commands/vrandmember/)
+func (c *Compat) VRandMember(ctx context.Context, key string) *StringCmd {
+	cmd := c.client.B().Vrandmember().Key(key).Build()
+	return newStringCmd(c.client.Do(ctx, cmd))
+}
+
+// VRandMemberCount - Returns random elements of the vector set.
+// For more information, please refer to the Redis documentation:
+// [VRANDMEMBER]: (https://redis.io/commands/vrandmember/)
+func (c *Compat) VRandMemberCount(ctx context.Context, key string, count int) *StringSliceCmd {
+	cmd := c.client.B().Vrandmember().Key(key).Count(int64(count)).Build()
+	return newStringSliceCmd(c.client.Do(ctx, cmd))
+}
+
+// VRem - Removes the element from the vector set.
+// For more information, please refer to the Redis documentation:
+// [VREM]: (https://redis.io/commands/vrem/)
+func (c *Compat) VRem(ctx context.Context, key, element string) *BoolCmd {
+	cmd := c.client.B().Vrem().Key(key).Element(element).Build()
+	return newBoolCmd(c.client.Do(ctx, cmd))
+}
+
+// VSetAttr - Sets the JSON attributes of the element. The 'attr' is marshaled to JSON unless it is a string.
+// For more information, please refer to the Redis documentation:
+// [VSETATTR]: (https://redis.io/commands/vsetattr/)
+func (c *Compat) VSetAttr(ctx context.Context, key, element string, attr interface{}) *BoolCmd {
+	var attrStr string
+	switch v := attr.(type) {
+	case string:
+		attrStr = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return &BoolCmd{baseCmd: baseCmd[bool]{err: err}}
+		}
+		attrStr = string(b)
+	}
+	cmd := c.client.B().Vsetattr().Key(key).Element(element).Json(attrStr).Build()
+	return newBoolCmd(c.client.Do(ctx, cmd))
+}
+
+// VClearAttributes - Removes the JSON attributes of the element.
+// For more information, please refer to the Redis documentation:
+// [VSETATTR]: (https://redis.io/commands/vsetattr/)
+func (c *Compat) VClearAttributes(ctx context.Context, key, element string) *BoolCmd {
+	return c.VSetAttr(ctx, key, element, "")
+}
+
+// VSim - Returns the elements similar to the vector.
+// For more information, please refer to the Redis documentation:
+// [VSIM]: (https://redis.io/commands/vsim/)
+func (c *Compat) VSim(ctx context.Context, key string, val Vector) *StringSliceCmd {
+	return c.VSimWithArgs(ctx, key, val, nil)
+}
+
+// VSimWithScores - Returns the elements similar to the vector with their similarity scores.
+// For more information, please refer to the Redis documentation:
+// [VSIM]: (https://redis.io/commands/vsim/)
+func (c *Compat) VSimWithScores(ctx context.Context, key string, val Vector) *VectorScoreSliceCmd {
+	return c.VSimWithArgsWithScores(ctx, key, val, nil)
+}
+
+// VSimWithArgs - Returns the elements similar to the vector with additional options.
+// For more information, please refer to the Redis documentation:
+// [VSIM]: (https://redis.io/commands/vsim/)
+func (c *Compat) VSimWithArgs(ctx context.Context, key string, val Vector, args *VSimArgs) *StringSliceCmd {
+	cmd, err := c.vsim(key, val, args, false)
+	if err != nil {
+		return &StringSliceCmd{baseCmd: baseCmd[[]string]{err: err}}
+	}
+	return newStringSliceCmd(c.client.Do(ctx, cmd))
+}
+
+// VSimWithArgsWithScores - Returns the elements similar to the vector with their similarity scores and additional options.
+// For more information, please refer to the Redis documentation:
+// [VSIM]: (https://redis.io/commands/vsim/)
+func (c *Compat) VSimWithArgsWithScores(ctx context.Context, key string, val Vector, args *VSimArgs) *VectorScoreSliceCmd {
+	cmd, err := c.vsim(key, val, args, true)
+	if err != nil {
+		return &VectorScoreSliceCmd{baseCmd: baseCmd[[]VectorScore]{err: err}}
+	}
+	return newVectorScoreSliceCmd(c.client.Do(ctx, cmd), false)
+}
+
+func (c *Compat) vsim(key string, val Vector, args *VSimArgs, withScores bool) (valkey.Completed, error) {
+	var _cmd cmds.Incomplete
+	if ref, ok := val.(*VectorRef); ok {
+		_cmd = cmds.Incomplete(c.client.B().Vsim().Key(key).Ele().Element(ref.Name))
+	} else {
+		vec, err := vectorValues(val)
+		if err != nil {
+			return valkey.Completed{}, err
+		}
+		_cmd = cmds.Incomplete(c.client.B().Vsim().Key(key).Values(int64(len(vec))).Vector(vec...))
+	}
+	if withScores {
+		_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Withscores())
+	}
+	if args != nil {
+		if args.Count > 0 {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Count(args.Count))
+		}
+		if args.EF > 0 {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Ef(args.EF))
+		}
+		if args.Filter != "" {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Filter(args.Filter))
+		}
+		if args.FilterEF > 0 {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).FilterEf(args.FilterEF))
+		}
+		if args.Truth {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Truth())
+		}
+		if args.NoThread {
+			_cmd = cmds.Incomplete(cmds.VsimQueryTypeEleElement(_cmd).Nothread())
+		}
+	}
+	return cmds.VsimQueryTypeEleElement(_cmd).Build(), nil
+}
+
 func (c *Compat) ModuleLoadex(ctx context.Context, conf *ModuleLoadexConfig) *StringCmd {
 	cmd := c.client.B().ModuleLoadex().Path(conf.Path).Config()
 	for k, v := range conf.Conf {
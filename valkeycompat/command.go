@@ -27,13 +27,16 @@
 package valkeycompat
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unsafe"
 
 	"github.com/valkey-io/valkey-go"
 	"github.com/valkey-io/valkey-go/internal/util"
@@ -85,6 +88,41 @@ func (cmd *baseCmd[T]) RawResult() (any, error) {
 
 type Cmd struct {
 	baseCmd[any]
+	args []any
+}
+
+// NewCmd returns a Cmd with the args, such as the ones passed to the ProcessHook.
+func NewCmd(_ context.Context, args ...any) *Cmd {
+	return &Cmd{args: args}
+}
+
+// Args returns the args of the command, which are only available to the Cmd passed to the hooks.
+func (cmd *Cmd) Args() []any {
+	return cmd.args
+}
+
+// Name returns the lowercase command name.
+func (cmd *Cmd) Name() string {
+	if len(cmd.args) == 0 {
+		return ""
+	}
+	return strings.ToLower(str(cmd.args[0]))
+}
+
+func (cmd *Cmd) String() string {
+	var sb strings.Builder
+	for i, arg := range cmd.args {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(str(arg))
+	}
+	if cmd.err != nil {
+		sb.WriteString(": " + cmd.err.Error())
+	} else if cmd.val != nil {
+		sb.WriteString(": " + fmt.Sprint(cmd.val))
+	}
+	return sb.String()
 }
 
 func (cmd *Cmd) from(res valkey.ValkeyResult) {
@@ -551,14 +589,37 @@ func (cmd *SliceCmd) Scan(dst any) error {
 
 type StringSliceCmd struct {
 	baseCmd[[]string]
+	layers bool // for VLINKS, which replies an array of arrays
 }
 
 func (cmd *StringSliceCmd) from(res valkey.ValkeyResult) {
+	if cmd.layers {
+		cmd.fromLayers(res)
+		return
+	}
 	val, err := res.AsStrSlice()
 	cmd.SetVal(val)
 	cmd.SetErr(err)
 }
 
+func (cmd *StringSliceCmd) fromLayers(res valkey.ValkeyResult) {
+	layers, err := res.ToArray()
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	var val []string
+	for _, layer := range layers {
+		s, err := layer.AsStrSlice()
+		if err != nil {
+			cmd.SetErr(err)
+			return
+		}
+		val = append(val, s...)
+	}
+	cmd.SetVal(val)
+}
+
 func newStringSliceCmd(res valkey.ValkeyResult) *StringSliceCmd {
 	cmd := &StringSliceCmd{}
 	cmd.from(res)
@@ -3793,6 +3854,21 @@ type FTSearchSortBy struct {
 	Desc      bool
 }
 
+// FTSearchSummarize is the SUMMARIZE option of FT.SEARCH. Zero values are omitted.
+type FTSearchSummarize struct {
+	Separator string
+	Fields    []string
+	Frags     int
+	Len       int
+}
+
+// FTSearchHighlight is the HIGHLIGHT option of FT.SEARCH. Zero values are omitted.
+type FTSearchHighlight struct {
+	OpenTag  string
+	CloseTag string
+	Fields   []string
+}
+
 type FTDropIndexOptions struct {
 	DeleteDocs bool
 }
@@ -4304,6 +4380,8 @@ type FTSearchResult struct {
 
 type FTSearchOptions struct {
 	Params          map[string]interface{}
+	Summarize       *FTSearchSummarize
+	Highlight       *FTSearchHighlight
 	Language        string
 	Expander        string
 	Scorer          string
@@ -5524,3 +5602,247 @@ func (cmd *FunctionStatsCmd) parseRunningScripts(msg valkey.ValkeyMessage) ([]Ru
 	}
 	return vals, err
 }
+
+// Vector is the vector of VADD and VSIM.
+type Vector interface {
+	Value() []any
+}
+
+var (
+	_ Vector = (*VectorFP32)(nil)
+	_ Vector = (*VectorValues)(nil)
+	_ Vector = (*VectorRef)(nil)
+)
+
+// VectorFP32 is a vector of little-endian float32 values.
+type VectorFP32 struct {
+	Val []byte
+}
+
+func (v *VectorFP32) Value() []any {
+	return []any{"FP32", v.Val}
+}
+
+// VectorValues is a vector of float values.
+type VectorValues struct {
+	Val []float64
+}
+
+func (v *VectorValues) Value() []any {
+	res := make([]any, 2+len(v.Val))
+	res[0] = "Values"
+	res[1] = len(v.Val)
+	for i, f := range v.Val {
+		res[2+i] = f
+	}
+	return res
+}
+
+// VectorRef refers to the vector of an existing element. It is only supported by VSIM.
+type VectorRef struct {
+	Name string
+}
+
+func (v *VectorRef) Value() []any {
+	return []any{"ele", v.Name}
+}
+
+type VAddArgs struct {
+	SetAttr string
+	Reduce  int64
+	EF      int64
+	M       int64
+	Cas     bool
+	NoQuant bool
+	// Q8 is the default quantization, so it adds nothing to the command.
+	Q8  bool
+	Bin bool
+}
+
+type VSimArgs struct {
+	Filter   string
+	Count    int64
+	EF       int64
+	FilterEF int64
+	Truth    bool
+	NoThread bool
+}
+
+type VectorScore struct {
+	Name  string
+	Score float64
+}
+
+type VectorScoreSliceCmd struct {
+	baseCmd[[]VectorScore]
+	layers bool // for VLINKS WITHSCORES, which replies an array of layers
+}
+
+func (cmd *VectorScoreSliceCmd) from(res valkey.ValkeyResult) {
+	msg, err := res.ToMessage()
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	if !cmd.layers {
+		val, err := toVectorScores(nil, msg)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return
+	}
+	layers, err := msg.ToArray()
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	var val []VectorScore
+	for _, layer := range layers {
+		if val, err = toVectorScores(val, layer); err != nil {
+			cmd.SetErr(err)
+			return
+		}
+	}
+	cmd.SetVal(val)
+}
+
+// toVectorScores appends the scores of a RESP3 map or a RESP2 flat array to the dst.
+// The elements of a RESP3 map are sorted by their scores in descending order, as they are replied.
+func toVectorScores(dst []VectorScore, msg valkey.ValkeyMessage) ([]VectorScore, error) {
+	if msg.IsMap() {
+		m, err := msg.AsMap()
+		if err != nil {
+			return nil, err
+		}
+		scores := make([]VectorScore, 0, len(m))
+		for name, v := range m {
+			score, err := v.AsFloat64()
+			if err != nil {
+				return nil, err
+			}
+			scores = append(scores, VectorScore{Name: name, Score: score})
+		}
+		sort.Slice(scores, func(i, j int) bool {
+			if scores[i].Score == scores[j].Score {
+				return scores[i].Name < scores[j].Name
+			}
+			return scores[i].Score > scores[j].Score
+		})
+		return append(dst, scores...), nil
+	}
+	arr, err := msg.ToArray()
+	if err != nil {
+		return nil, err
+	}
+	if len(arr)%2 != 0 {
+		return nil, fmt.Errorf("got %d, wanted even number of elements", len(arr))
+	}
+	for i := 0; i < len(arr); i += 2 {
+		name, err := arr[i].ToString()
+		if err != nil {
+			return nil, err
+		}
+		score, err := arr[i+1].AsFloat64()
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, VectorScore{Name: name, Score: score})
+	}
+	return dst, nil
+}
+
+func newVectorScoreSliceCmd(res valkey.ValkeyResult, layers bool) *VectorScoreSliceCmd {
+	cmd := &VectorScoreSliceCmd{layers: layers}
+	cmd.from(res)
+	return cmd
+}
+
+// FTProfileResult is the result of FT.PROFILE. Only one of the Search and the Aggregate is set, depending on the profiled query.
+type FTProfileResult struct {
+	Aggregate *FTAggregateResult
+	Profile   any
+	Search    FTSearchResult
+}
+
+type FTProfileCmd struct {
+	baseCmd[FTProfileResult]
+	aggregate bool
+}
+
+func (cmd *FTProfileCmd) from(res valkey.ValkeyResult) {
+	if err := res.Error(); err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	anyRes, err := res.ToAny()
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	cmd.SetRawVal(anyRes)
+	msg, err := res.ToMessage()
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	var results, profile valkey.ValkeyMessage
+	var hasProfile bool
+	if msg.IsMap() {
+		m, err := msg.ToMap()
+		if err != nil {
+			cmd.SetErr(err)
+			return
+		}
+		if r, ok := m["Results"]; ok {
+			// RESP3 replies since search 8.0: {"Results": results, "Profile": profile}
+			results = r
+			profile, hasProfile = m["Profile"]
+		} else {
+			// RESP3 replies before search 8.0: the profile is attached to the results
+			results = msg
+			profile, hasProfile = m["profile"]
+		}
+	} else {
+		arr, err := msg.ToArray()
+		if err != nil {
+			cmd.SetErr(err)
+			return
+		}
+		if len(arr) != 2 {
+			cmd.SetErr(fmt.Errorf("got %d, wanted 2 elements of results and profile", len(arr)))
+			return
+		}
+		results, profile, hasProfile = arr[0], arr[1], true
+	}
+	var val FTProfileResult
+	resultsRes := *(*valkey.ValkeyResult)(unsafe.Pointer(&proxyresult{val: results}))
+	if cmd.aggregate {
+		agg := &AggregateCmd{}
+		agg.from(resultsRes)
+		if err := agg.Err(); err != nil {
+			cmd.SetErr(err)
+			return
+		}
+		val.Aggregate = agg.Val()
+	} else {
+		search := &FTSearchCmd{}
+		search.from(resultsRes)
+		if err := search.Err(); err != nil {
+			cmd.SetErr(err)
+			return
+		}
+		val.Search = search.Val()
+	}
+	if hasProfile {
+		if val.Profile, err = profile.ToAny(); err != nil {
+			cmd.SetErr(err)
+			return
+		}
+	}
+	cmd.SetVal(val)
+}
+
+func newFTProfileCmd(res valkey.ValkeyResult, aggregate bool) *FTProfileCmd {
+	cmd := &FTProfileCmd{aggregate: aggregate}
+	cmd.from(res)
+	return cmd
+}
@@ -27,14 +27,19 @@
 package valkeycompat
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/valkey-io/valkey-go"
 	"github.com/valkey-io/valkey-go/mock"
+	"go.uber.org/mock/gomock"
 )
 
 var _ = Describe("Commands", func() {
@@ -1183,3 +1188,186 @@ func TestClientInfoParsing(t *testing.T) {
 		t.Errorf("Expected Addr to be '127.0.0.1:6379', got '%s'", info.Addr)
 	}
 }
+
+func TestVectorSetCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	adapter := NewAdapter(m)
+
+	fp32 := make([]byte, 8)
+	binary.LittleEndian.PutUint32(fp32, math.Float32bits(1))
+	binary.LittleEndian.PutUint32(fp32[4:], math.Float32bits(0.5))
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("VADD", "k", "REDUCE", "2", "VALUES", "2", "1", "0.5", "e", "CAS", "NOQUANT", "EF", "100", "SETATTR", `{"a":1}`, "M", "16")).Return(mock.Result(mock.ValkeyInt64(1)))
+	if v, err := adapter.VAddWithArgs(ctx, "k", "e", &VectorValues{Val: []float64{1, 0.5}}, &VAddArgs{Reduce: 2, Cas: true, NoQuant: true, EF: 100, SetAttr: `{"a":1}`, M: 16}).Result(); err != nil || !v {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VADD", "k", "VALUES", "2", "1", "0.5", "f")).Return(mock.Result(mock.ValkeyInt64(0)))
+	if v, err := adapter.VAdd(ctx, "k", "f", &VectorFP32{Val: fp32}).Result(); err != nil || v {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	if err := adapter.VAdd(ctx, "k", "f", &VectorFP32{Val: fp32[:5]}).Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+	if err := adapter.VAdd(ctx, "k", "f", &VectorRef{Name: "e"}).Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+	if err := adapter.VSim(ctx, "k", &VectorFP32{Val: fp32[:5]}).Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+	if err := adapter.VSimWithScores(ctx, "k", &VectorFP32{Val: fp32[:5]}).Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("VSIM", "k", "ELE", "e", "WITHSCORES", "COUNT", "2", "FILTER", ".a > 0", "TRUTH")).Return(mock.Result(mock.ValkeyMap(map[string]valkey.ValkeyMessage{
+		"f": mock.ValkeyFloat64(0.5), "e": mock.ValkeyFloat64(1),
+	})))
+	if v, err := adapter.VSimWithArgsWithScores(ctx, "k", &VectorRef{Name: "e"}, &VSimArgs{Count: 2, Filter: ".a > 0", Truth: true}).Result(); err != nil ||
+		!reflect.DeepEqual(v, []VectorScore{{Name: "e", Score: 1}, {Name: "f", Score: 0.5}}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VSIM", "k", "VALUES", "2", "1", "0.5", "WITHSCORES")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyString("e"), mock.ValkeyString("1"), mock.ValkeyString("f"), mock.ValkeyString("0.5"),
+	)))
+	if v, err := adapter.VSimWithScores(ctx, "k", &VectorValues{Val: []float64{1, 0.5}}).Result(); err != nil ||
+		!reflect.DeepEqual(v, []VectorScore{{Name: "e", Score: 1}, {Name: "f", Score: 0.5}}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VSIM", "k", "ELE", "e")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("e"), mock.ValkeyString("f"))))
+	if v, err := adapter.VSim(ctx, "k", &VectorRef{Name: "e"}).Result(); err != nil || !reflect.DeepEqual(v, []string{"e", "f"}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("VLINKS", "k", "e")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyArray(mock.ValkeyString("f"), mock.ValkeyString("g")), mock.ValkeyArray(mock.ValkeyString("f")),
+	)))
+	if v, err := adapter.VLinks(ctx, "k", "e").Result(); err != nil || !reflect.DeepEqual(v, []string{"f", "g", "f"}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VLINKS", "k", "e", "WITHSCORES")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyMap(map[string]valkey.ValkeyMessage{"f": mock.ValkeyFloat64(0.5)}),
+		mock.ValkeyArray(mock.ValkeyString("g"), mock.ValkeyString("0.25")),
+	)))
+	if v, err := adapter.VLinksWithScores(ctx, "k", "e").Result(); err != nil ||
+		!reflect.DeepEqual(v, []VectorScore{{Name: "f", Score: 0.5}, {Name: "g", Score: 0.25}}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("VSETATTR", "k", "e", `{"a":1}`)).Return(mock.Result(mock.ValkeyInt64(1)))
+	if v, err := adapter.VSetAttr(ctx, "k", "e", map[string]int{"a": 1}).Result(); err != nil || !v {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	if err := adapter.VSetAttr(ctx, "k", "e", make(chan int)).Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VSETATTR", "k", "e", "")).Return(mock.Result(mock.ValkeyInt64(1)))
+	if v, err := adapter.VClearAttributes(ctx, "k", "e").Result(); err != nil || !v {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VEMB", "k", "e", "RAW")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyString("f32"), mock.ValkeyBlobString(string(fp32)), mock.ValkeyFloat64(1),
+	)))
+	if v, err := adapter.VEmb(ctx, "k", "e", true).Result(); err != nil || len(v) != 3 || v[0] != "f32" {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VRANDMEMBER", "k", "2")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("e"), mock.ValkeyString("f"))))
+	if v, err := adapter.VRandMemberCount(ctx, "k", 2).Result(); err != nil || !reflect.DeepEqual(v, []string{"e", "f"}) {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VCARD", "k")).Return(mock.Result(mock.ValkeyInt64(2)))
+	if v, err := adapter.VCard(ctx, "k").Result(); err != nil || v != 2 {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	m.EXPECT().Do(gomock.Any(), mock.Match("VREM", "k", "e")).Return(mock.Result(mock.ValkeyInt64(1)))
+	if v, err := adapter.VRem(ctx, "k", "e").Result(); err != nil || !v {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	m.EXPECT().DoMulti(gomock.Any(), mock.Match("VLINKS", "k", "e"), mock.Match("VLINKS", "k", "e", "WITHSCORES")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyArray(mock.ValkeyArray(mock.ValkeyString("f")))),
+		mock.Result(mock.ValkeyArray(mock.ValkeyArray(mock.ValkeyString("f"), mock.ValkeyString("0.5")))),
+	})
+	pipe := adapter.Pipeline()
+	invalid := pipe.VAdd(ctx, "k", "f", &VectorRef{Name: "e"})
+	links := pipe.VLinks(ctx, "k", "e")
+	scores := pipe.VLinksWithScores(ctx, "k", "e")
+	if _, err := pipe.Exec(ctx); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if !reflect.DeepEqual(links.Val(), []string{"f"}) || !reflect.DeepEqual(scores.Val(), []VectorScore{{Name: "f", Score: 0.5}}) {
+		t.Fatalf("unexpected result %v %v", links.Val(), scores.Val())
+	}
+	if invalid.Err() == nil {
+		t.Fatalf("unexpected nil err")
+	}
+}
+
+func TestFTSearchSummarizeHighlight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	adapter := NewAdapter(m)
+	m.EXPECT().Do(gomock.Any(), mock.Match("FT.SEARCH", "idx", "q",
+		"SUMMARIZE", "FIELDS", "1", "f", "FRAGS", "2", "LEN", "10", "SEPARATOR", ",",
+		"HIGHLIGHT", "FIELDS", "1", "f", "TAGS", "<b>", "</b>",
+	)).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(0))))
+	if v, err := adapter.FTSearchWithArgs(ctx, "idx", "q", &FTSearchOptions{
+		Summarize: &FTSearchSummarize{Fields: []string{"f"}, Frags: 2, Len: 10, Separator: ","},
+		Highlight: &FTSearchHighlight{Fields: []string{"f"}, OpenTag: "<b>", CloseTag: "</b>"},
+	}).Result(); err != nil || v.Total != 0 {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+}
+
+func TestFTProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	adapter := NewAdapter(m)
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("FT.PROFILE", "idx", "SEARCH", "LIMITED", "QUERY", "q")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyArray(mock.ValkeyInt64(1), mock.ValkeyString("doc1"), mock.ValkeyArray(mock.ValkeyString("f"), mock.ValkeyString("v"))),
+		mock.ValkeyArray(mock.ValkeyString("Total profile time"), mock.ValkeyString("0.1")),
+	)))
+	v, err := adapter.FTProfileSearch(ctx, "idx", true, "q").Result()
+	if err != nil || v.Search.Total != 1 || len(v.Search.Docs) != 1 || v.Search.Docs[0].ID != "doc1" || v.Search.Docs[0].Fields["f"] != "v" {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	if !reflect.DeepEqual(v.Profile, []any{"Total profile time", "0.1"}) {
+		t.Fatalf("unexpected profile %v", v.Profile)
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("FT.PROFILE", "idx", "SEARCH", "QUERY", "q")).Return(mock.Result(mock.ValkeyMap(map[string]valkey.ValkeyMessage{
+		"Results": mock.ValkeyMap(map[string]valkey.ValkeyMessage{
+			"total_results": mock.ValkeyInt64(1),
+			"results": mock.ValkeyArray(mock.ValkeyMap(map[string]valkey.ValkeyMessage{
+				"id":               mock.ValkeyString("doc1"),
+				"extra_attributes": mock.ValkeyMap(map[string]valkey.ValkeyMessage{"f": mock.ValkeyString("v")}),
+			})),
+		}),
+		"Profile": mock.ValkeyMap(map[string]valkey.ValkeyMessage{"Shards": mock.ValkeyArray()}),
+	})))
+	v, err = adapter.FTProfileSearch(ctx, "idx", false, "q").Result()
+	if err != nil || v.Search.Total != 1 || v.Search.Docs[0].Fields["f"] != "v" || v.Profile == nil {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("FT.PROFILE", "idx", "AGGREGATE", "QUERY", "*")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyArray(mock.ValkeyInt64(1), mock.ValkeyArray(mock.ValkeyString("f"), mock.ValkeyString("v"))),
+		mock.ValkeyArray(),
+	)))
+	v, err = adapter.FTProfileAggregate(ctx, "idx", false, "*").Result()
+	if err != nil || v.Aggregate == nil || v.Aggregate.Total != 1 || len(v.Aggregate.Rows) != 1 {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+
+	m.EXPECT().Do(gomock.Any(), mock.Match("FT.PROFILE", "idx", "SEARCH", "QUERY", "q")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1))))
+	if err := adapter.FTProfileSearch(ctx, "idx", false, "q").Err(); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+}
@@ -0,0 +1,274 @@
+package valkeycompat
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+type (
+	// DialHook is the go-redis style dial function. The network is always "tcp".
+	DialHook func(ctx context.Context, network, addr string) (net.Conn, error)
+	// ProcessHook is the go-redis style function that sends a command.
+	ProcessHook func(ctx context.Context, cmd Cmder) error
+	// ProcessPipelineHook is the go-redis style function that sends multiple commands together.
+	ProcessPipelineHook func(ctx context.Context, cmds []Cmder) error
+)
+
+// Hook is the go-redis style hook added by Compat.AddHook. Each method receives the next function
+// of the chain and returns a function that wraps it. A hook must call the next function to send the commands.
+//
+// The cmd passed to the ProcessHook and the cmds passed to the ProcessPipelineHook are always *Cmd,
+// whose Name() and Args() describe the command, and whose Val() is the reply after the next function returns.
+type Hook interface {
+	DialHook(next DialHook) DialHook
+	ProcessHook(next ProcessHook) ProcessHook
+	ProcessPipelineHook(next ProcessPipelineHook) ProcessPipelineHook
+}
+
+var errNotProcessed = errors.New("valkeycompat: the command is not processed by the hook")
+
+// NewAdapterWithOption creates a valkey.Client with the option and returns it together with its adapter.
+// Unlike NewAdapter, the option.DialCtxFn is wrapped so that the DialHook of the hooks applies to every connection
+// dialed by the client, including the ones dialed by valkey.NewClient. Hooks added later by AddHook apply to
+// later dials only. The returned valkey.Client should be closed when it is no longer used.
+func NewAdapterWithOption(option valkey.ClientOption, hooks ...Hook) (valkey.Client, Cmdable, error) {
+	h := &hookset{}
+	for _, hook := range hooks {
+		h.add(hook)
+	}
+	dialFn := option.DialCtxFn
+	if dialFn == nil {
+		if option.DialFn != nil {
+			fn := option.DialFn
+			dialFn = func(_ context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+				return fn(dst, dialer, cfg)
+			}
+		} else {
+			dialFn = valkey.DefaultDialCtxFn
+		}
+	}
+	option.DialCtxFn = func(ctx context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+		return h.dial(func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return dialFn(ctx, addr, dialer, cfg)
+		})(ctx, "tcp", dst)
+	}
+	client, err := valkey.NewClient(option)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, &Compat{client: &hookclient{Client: client, h: h}, maxp: runtime.GOMAXPROCS(0)}, nil
+}
+
+// AddHook adds the go-redis style hook to the adapter. The first added hook is the outermost one.
+// The hook applies to commands, including the ones sent by Cache(), Watch(), Pipeline() and TxPipeline()
+// created after the AddHook. Commands of a TxPipeline are wrapped by MULTI and EXEC when passed to the ProcessPipelineHook.
+// Receive and streaming commands are not hooked. The DialHook only applies if the adapter is created by NewAdapterWithOption.
+func (c *Compat) AddHook(hook Hook) {
+	hc, ok := c.client.(*hookclient)
+	if !ok {
+		hc = &hookclient{Client: c.client, h: &hookset{}}
+		c.client = hc
+	}
+	hc.h.add(hook)
+}
+
+// hookset is shared by the clients derived from the same adapter, so that hooks added later are visible to all of them.
+type hookset struct {
+	hooks atomic.Pointer[[]Hook]
+}
+
+func (h *hookset) add(hook Hook) {
+	var hooks []Hook
+	if p := h.hooks.Load(); p != nil {
+		hooks = append(hooks, *p...)
+	}
+	hooks = append(hooks, hook)
+	h.hooks.Store(&hooks)
+}
+
+func (h *hookset) load() []Hook {
+	if p := h.hooks.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (h *hookset) dial(next DialHook) DialHook {
+	hooks := h.load()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		next = hooks[i].DialHook(next)
+	}
+	return next
+}
+
+func commandArgs(commands []string) []any {
+	args := make([]any, len(commands))
+	for i, s := range commands {
+		args[i] = s
+	}
+	return args
+}
+
+func errorResult(err error) valkey.ValkeyResult {
+	return *(*valkey.ValkeyResult)(unsafe.Pointer(&proxyresult{err: err}))
+}
+
+// process sends a command by the do through the ProcessHook chain.
+func (h *hookset) process(ctx context.Context, commands []string, do func(ctx context.Context) valkey.ValkeyResult) valkey.ValkeyResult {
+	hooks := h.load()
+	if len(hooks) == 0 {
+		return do(ctx)
+	}
+	var resp valkey.ValkeyResult
+	var done bool
+	var failed error
+	next := ProcessHook(func(ctx context.Context, cmd Cmder) error {
+		resp, done = do(ctx), true
+		cmd.SetErr(nil)
+		cmd.from(resp)
+		failed = cmd.Err()
+		return failed
+	})
+	for i := len(hooks) - 1; i >= 0; i-- {
+		next = hooks[i].ProcessHook(next)
+	}
+	cmd := NewCmd(ctx, commandArgs(commands)...)
+	if err := next(ctx, cmd); !done || (err != nil && err != failed) {
+		if err == nil {
+			err = errNotProcessed
+		}
+		return errorResult(err)
+	}
+	return resp
+}
+
+// pipeline sends multiple commands by the do through the ProcessPipelineHook chain.
+func (h *hookset) pipeline(ctx context.Context, commands [][]string, do func(ctx context.Context) []valkey.ValkeyResult) []valkey.ValkeyResult {
+	hooks := h.load()
+	if len(hooks) == 0 {
+		return do(ctx)
+	}
+	var resps []valkey.ValkeyResult
+	var done bool
+	var failed error
+	next := ProcessPipelineHook(func(ctx context.Context, cmds []Cmder) error {
+		resps, done = do(ctx), true
+		for i, resp := range resps {
+			if err := resp.NonValkeyError(); err != nil {
+				failed = err
+			}
+			if i < len(cmds) {
+				cmds[i].SetErr(nil)
+				cmds[i].from(resp)
+			}
+		}
+		return failed
+	})
+	for i := len(hooks) - 1; i >= 0; i-- {
+		next = hooks[i].ProcessPipelineHook(next)
+	}
+	cmds := make([]Cmder, len(commands))
+	for i, c := range commands {
+		cmds[i] = NewCmd(ctx, commandArgs(c)...)
+	}
+	if err := next(ctx, cmds); !done || (err != nil && err != failed) {
+		if err == nil {
+			err = errNotProcessed
+		}
+		resps = make([]valkey.ValkeyResult, len(commands))
+		for i := range resps {
+			resps[i] = errorResult(err)
+		}
+	}
+	return resps
+}
+
+func completedCommands(multi []valkey.Completed) [][]string {
+	commands := make([][]string, len(multi))
+	for i, cmd := range multi {
+		commands[i] = cmd.Commands()
+	}
+	return commands
+}
+
+var _ valkey.Client = (*hookclient)(nil)
+
+type hookclient struct {
+	valkey.Client
+	h *hookset
+}
+
+func (c *hookclient) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return c.h.process(ctx, cmd.Commands(), func(ctx context.Context) valkey.ValkeyResult {
+		return c.Client.Do(ctx, cmd)
+	})
+}
+
+func (c *hookclient) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return c.h.pipeline(ctx, completedCommands(multi), func(ctx context.Context) []valkey.ValkeyResult {
+		return c.Client.DoMulti(ctx, multi...)
+	})
+}
+
+func (c *hookclient) DoCache(ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) valkey.ValkeyResult {
+	return c.h.process(ctx, cmd.Commands(), func(ctx context.Context) valkey.ValkeyResult {
+		return c.Client.DoCache(ctx, cmd, ttl)
+	})
+}
+
+func (c *hookclient) DoMultiCache(ctx context.Context, multi ...valkey.CacheableTTL) []valkey.ValkeyResult {
+	commands := make([][]string, len(multi))
+	for i, ct := range multi {
+		commands[i] = ct.Cmd.Commands()
+	}
+	return c.h.pipeline(ctx, commands, func(ctx context.Context) []valkey.ValkeyResult {
+		return c.Client.DoMultiCache(ctx, multi...)
+	})
+}
+
+func (c *hookclient) Dedicated(fn func(valkey.DedicatedClient) error) error {
+	return c.Client.Dedicated(func(client valkey.DedicatedClient) error {
+		return fn(&hookdedicated{DedicatedClient: client, h: c.h})
+	})
+}
+
+func (c *hookclient) Dedicate() (valkey.DedicatedClient, func()) {
+	client, cancel := c.Client.Dedicate()
+	return &hookdedicated{DedicatedClient: client, h: c.h}, cancel
+}
+
+func (c *hookclient) Nodes() map[string]valkey.Client {
+	nodes := c.Client.Nodes()
+	hooked := make(map[string]valkey.Client, len(nodes))
+	for addr, client := range nodes {
+		hooked[addr] = &hookclient{Client: client, h: c.h}
+	}
+	return hooked
+}
+
+var _ valkey.DedicatedClient = (*hookdedicated)(nil)
+
+type hookdedicated struct {
+	valkey.DedicatedClient
+	h *hookset
+}
+
+func (d *hookdedicated) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	return d.h.process(ctx, cmd.Commands(), func(ctx context.Context) valkey.ValkeyResult {
+		return d.DedicatedClient.Do(ctx, cmd)
+	})
+}
+
+func (d *hookdedicated) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	return d.h.pipeline(ctx, completedCommands(multi), func(ctx context.Context) []valkey.ValkeyResult {
+		return d.DedicatedClient.DoMulti(ctx, multi...)
+	})
+}
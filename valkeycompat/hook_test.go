@@ -0,0 +1,214 @@
+package valkeycompat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type recordHook struct {
+	name    string
+	records *[]string
+	process func(next ProcessHook) ProcessHook
+}
+
+func (h *recordHook) record(s string) {
+	*h.records = append(*h.records, h.name+" "+s)
+}
+
+func (h *recordHook) DialHook(next DialHook) DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		h.record("dial " + network + " " + addr)
+		return conn, err
+	}
+}
+
+func (h *recordHook) ProcessHook(next ProcessHook) ProcessHook {
+	if h.process != nil {
+		return h.process(next)
+	}
+	return func(ctx context.Context, cmd Cmder) error {
+		h.record("before " + cmd.(*Cmd).Name())
+		err := next(ctx, cmd)
+		h.record("after " + cmd.(*Cmd).String())
+		return err
+	}
+}
+
+func (h *recordHook) ProcessPipelineHook(next ProcessPipelineHook) ProcessPipelineHook {
+	return func(ctx context.Context, cmds []Cmder) error {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.(*Cmd).Name()
+		}
+		h.record("before " + strings.Join(names, ","))
+		err := next(ctx, cmds)
+		vals := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			vals[i] = cmd.(*Cmd).String()
+		}
+		h.record("after " + strings.Join(vals, ","))
+		return err
+	}
+}
+
+func TestAddHookProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	m.EXPECT().Do(gomock.Any(), mock.Match("GET", "k")).Return(mock.Result(mock.ValkeyString("v")))
+	m.EXPECT().Do(gomock.Any(), mock.Match("GET", "n")).Return(mock.Result(mock.ValkeyNil()))
+	m.EXPECT().DoCache(gomock.Any(), mock.Match("GET", "c"), time.Second).Return(mock.Result(mock.ValkeyString("cv")))
+
+	var records []string
+	adapter := NewAdapter(m)
+	adapter.AddHook(&recordHook{name: "h1", records: &records})
+	adapter.AddHook(&recordHook{name: "h2", records: &records})
+
+	if v, err := adapter.Get(ctx, "k").Result(); err != nil || v != "v" {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	if err := adapter.Get(ctx, "n").Err(); !valkey.IsValkeyNil(err) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if v, err := adapter.Cache(time.Second).Get(ctx, "c").Result(); err != nil || v != "cv" {
+		t.Fatalf("unexpected result %v %v", v, err)
+	}
+	expected := []string{
+		"h1 before get", "h2 before get", "h2 after GET k: v", "h1 after GET k: v",
+		"h1 before get", "h2 before get", "h2 after GET n: valkey nil message", "h1 after GET n: valkey nil message",
+		"h1 before get", "h2 before get", "h2 after GET c: cv", "h1 after GET c: cv",
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("unexpected records %q", records)
+	}
+}
+
+func TestAddHookProcessError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	m.EXPECT().Do(gomock.Any(), mock.Match("GET", "k")).Return(mock.Result(mock.ValkeyString("v")))
+
+	denied := errors.New("denied")
+	var records []string
+	adapter := NewAdapter(m)
+	adapter.AddHook(&recordHook{name: "h1", records: &records, process: func(next ProcessHook) ProcessHook {
+		return func(ctx context.Context, cmd Cmder) error {
+			if cmd.(*Cmd).Args()[1] == "denied" {
+				return denied
+			}
+			if cmd.(*Cmd).Args()[1] == "skipped" {
+				return nil
+			}
+			if err := next(ctx, cmd); err != nil {
+				return err
+			}
+			return denied
+		}
+	}})
+	if err := adapter.Get(ctx, "denied").Err(); err != denied {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := adapter.Get(ctx, "skipped").Err(); err != errNotProcessed {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := adapter.Get(ctx, "k").Err(); err != denied {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+func TestAddHookPipeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	m.EXPECT().DoMulti(gomock.Any(), mock.Match("SET", "k", "v"), mock.Match("GET", "k")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")), mock.Result(mock.ValkeyString("v")),
+	})
+	m.EXPECT().DoMulti(gomock.Any(), mock.Match("MULTI"), mock.Match("INCR", "i"), mock.Match("EXEC")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")), mock.Result(mock.ValkeyString("QUEUED")), mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1))),
+	})
+
+	var records []string
+	adapter := NewAdapter(m)
+	adapter.AddHook(&recordHook{name: "h1", records: &records})
+
+	cmds, err := adapter.Pipelined(ctx, func(p Pipeliner) error {
+		p.Set(ctx, "k", "v", 0)
+		p.Get(ctx, "k")
+		return nil
+	})
+	if err != nil || len(cmds) != 2 || cmds[1].(*StringCmd).Val() != "v" {
+		t.Fatalf("unexpected result %v %v", cmds, err)
+	}
+	cmds, err = adapter.TxPipelined(ctx, func(p Pipeliner) error {
+		p.Incr(ctx, "i")
+		return nil
+	})
+	if err != nil || len(cmds) != 1 || cmds[0].(*IntCmd).Val() != 1 {
+		t.Fatalf("unexpected result %v %v", cmds, err)
+	}
+	expected := []string{
+		"h1 before set,get", "h1 after SET k v: OK,GET k: v",
+		"h1 before multi,incr,exec", "h1 after MULTI: OK,INCR i: QUEUED,EXEC: [1]",
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("unexpected records %q", records)
+	}
+}
+
+func TestAddHookWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock.NewClient(ctrl)
+	d := mock.NewDedicatedClient(ctrl)
+	m.EXPECT().Dedicate().Return(d, func() {})
+	d.EXPECT().Do(gomock.Any(), mock.Match("WATCH", "k")).Return(mock.Result(mock.ValkeyString("OK")))
+	d.EXPECT().Do(gomock.Any(), mock.Match("GET", "k")).Return(mock.Result(mock.ValkeyString("v")))
+
+	var records []string
+	adapter := NewAdapter(m)
+	adapter.AddHook(&recordHook{name: "h1", records: &records})
+	if err := adapter.Watch(ctx, func(tx Tx) error {
+		return tx.Get(ctx, "k").Err()
+	}, "k"); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	expected := []string{
+		"h1 before watch", "h1 after WATCH k: OK",
+		"h1 before get", "h1 after GET k: v",
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("unexpected records %q", records)
+	}
+}
+
+func TestNewAdapterWithOptionDialHook(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	var records []string
+	if _, _, err := NewAdapterWithOption(valkey.ClientOption{InitAddress: []string{addr}}, &recordHook{name: "h1", records: &records}); err == nil {
+		t.Fatalf("unexpected nil err")
+	}
+	if len(records) == 0 || records[0] != "h1 dial tcp "+addr {
+		t.Fatalf("unexpected records %q", records)
+	}
+}
@@ -3102,6 +3102,18 @@ func (c *Pipeline) FTInfo(ctx context.Context, index string) *FTInfoCmd {
 	return ret
 }
 
+func (c *Pipeline) FTProfileSearch(ctx context.Context, index string, limited bool, query string) *FTProfileCmd {
+	ret := c.comp.FTProfileSearch(ctx, index, limited, query)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) FTProfileAggregate(ctx context.Context, index string, limited bool, query string) *FTProfileCmd {
+	ret := c.comp.FTProfileAggregate(ctx, index, limited, query)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
 func (c *Pipeline) FTSpellCheck(ctx context.Context, index string, query string) *FTSpellCheckCmd {
 	ret := c.comp.FTSpellCheck(ctx, index, query)
 	c.rets = append(c.rets, ret)
@@ -3150,6 +3162,130 @@ func (c *Pipeline) FTTagVals(ctx context.Context, index string, field string) *S
 	return ret
 }
 
+func (c *Pipeline) VAdd(ctx context.Context, key, element string, val Vector) *BoolCmd {
+	ret := c.comp.VAdd(ctx, key, element, val)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VAddWithArgs(ctx context.Context, key, element string, val Vector, addArgs *VAddArgs) *BoolCmd {
+	ret := c.comp.VAddWithArgs(ctx, key, element, val, addArgs)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VCard(ctx context.Context, key string) *IntCmd {
+	ret := c.comp.VCard(ctx, key)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VDim(ctx context.Context, key string) *IntCmd {
+	ret := c.comp.VDim(ctx, key)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VEmb(ctx context.Context, key, element string, raw bool) *SliceCmd {
+	ret := c.comp.VEmb(ctx, key, element, raw)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VGetAttr(ctx context.Context, key, element string) *StringCmd {
+	ret := c.comp.VGetAttr(ctx, key, element)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VInfo(ctx context.Context, key string) *MapStringInterfaceCmd {
+	ret := c.comp.VInfo(ctx, key)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VLinks(ctx context.Context, key, element string) *StringSliceCmd {
+	ret := c.comp.VLinks(ctx, key, element)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VLinksWithScores(ctx context.Context, key, element string) *VectorScoreSliceCmd {
+	ret := c.comp.VLinksWithScores(ctx, key, element)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VRandMember(ctx context.Context, key string) *StringCmd {
+	ret := c.comp.VRandMember(ctx, key)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VRandMemberCount(ctx context.Context, key string, count int) *StringSliceCmd {
+	ret := c.comp.VRandMemberCount(ctx, key, count)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VRem(ctx context.Context, key, element string) *BoolCmd {
+	ret := c.comp.VRem(ctx, key, element)
+	c.rets = append(c.rets, ret)
+	return ret
+}
+
+func (c *Pipeline) VSetAttr(ctx context.Context, key, element string, attr interface{}) *BoolCmd {
+	ret := c.comp.VSetAttr(ctx, key, element, attr)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VClearAttributes(ctx context.Context, key, element string) *BoolCmd {
+	ret := c.comp.VClearAttributes(ctx, key, element)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VSim(ctx context.Context, key string, val Vector) *StringSliceCmd {
+	ret := c.comp.VSim(ctx, key, val)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VSimWithScores(ctx context.Context, key string, val Vector) *VectorScoreSliceCmd {
+	ret := c.comp.VSimWithScores(ctx, key, val)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VSimWithArgs(ctx context.Context, key string, val Vector, args *VSimArgs) *StringSliceCmd {
+	ret := c.comp.VSimWithArgs(ctx, key, val, args)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
+func (c *Pipeline) VSimWithArgsWithScores(ctx context.Context, key string, val Vector, args *VSimArgs) *VectorScoreSliceCmd {
+	ret := c.comp.VSimWithArgsWithScores(ctx, key, val, args)
+	if ret.Err() == nil { // not sent if the args are invalid
+		c.rets = append(c.rets, ret)
+	}
+	return ret
+}
+
 func (c *Pipeline) ModuleLoadex(ctx context.Context, conf *ModuleLoadexConfig) *StringCmd {
 	ret := c.comp.ModuleLoadex(ctx, conf)
 	c.rets = append(c.rets, ret)
@@ -21,11 +21,49 @@ type Hook interface {
 	DoMultiStream(client valkey.Client, ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream
 }
 
-// WithHook wraps valkey.Client with Hook and allows the user to intercept valkey.Client
+// DedicatedHook is an optional interface of Hook to intercept the acquisition and the release of dedicated connections.
+// Commands sent by the acquired valkey.DedicatedClient still go through the Hook.
+type DedicatedHook interface {
+	// Dedicated intercepts client.Dedicated. The connection is acquired before the fn is called and released after it returns.
+	Dedicated(client valkey.Client, fn func(valkey.DedicatedClient) error) (err error)
+	// Dedicate intercepts client.Dedicate. The connection is released when the returned cancel function is called.
+	Dedicate(client valkey.Client) (valkey.DedicatedClient, func())
+}
+
+// PubSubHook is an optional interface of Hook to intercept pub/sub message delivery.
+type PubSubHook interface {
+	// OnMessage intercepts every message delivered to the fn of Receive and to the PubSubHooks.OnMessage of SetPubSubHooks.
+	// The ctx is the one passed to Receive, or context.Background() for SetPubSubHooks.
+	OnMessage(ctx context.Context, msg valkey.PubSubMessage, fn func(msg valkey.PubSubMessage))
+	// SetPubSubHooks intercepts valkey.DedicatedClient.SetPubSubHooks.
+	SetPubSubHooks(client valkey.DedicatedClient, hooks valkey.PubSubHooks) <-chan error
+}
+
+// WithHook wraps valkey.Client with Hook and allows the user to intercept valkey.Client.
+// If the Hook also implements DedicatedHook or PubSubHook, they are used as well.
 func WithHook(client valkey.Client, hook Hook) valkey.Client {
+	if b, ok := hook.(binder); ok {
+		hook = b.bind(client)
+	}
 	return &hookclient{client: client, hook: hook}
 }
 
+// binder is implemented by Hooks that keep states per wrapped client, such as KeyPrefix.
+type binder interface {
+	// bind returns the Hook for the client. It is called once by WithHook.
+	bind(client valkey.Client) Hook
+}
+
+// Chain wraps valkey.Client with multiple Hooks. The first hook is the outermost one:
+// it intercepts a call first and sees the result last, after all the following hooks.
+// That is, Chain(client, h1, h2) is equivalent to WithHook(WithHook(client, h2), h1).
+func Chain(client valkey.Client, hooks ...Hook) valkey.Client {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		client = WithHook(client, hooks[i])
+	}
+	return client
+}
+
 type hookclient struct {
 	client valkey.Client
 	hook   Hook
@@ -60,24 +98,34 @@ func (c *hookclient) DoMultiStream(ctx context.Context, multi ...valkey.Complete
 }
 
 func (c *hookclient) Dedicated(fn func(valkey.DedicatedClient) error) (err error) {
-	return c.client.Dedicated(func(client valkey.DedicatedClient) error {
+	wrapped := func(client valkey.DedicatedClient) error {
 		return fn(&dedicated{client: &extended{DedicatedClient: client}, hook: c.hook})
-	})
+	}
+	if h, ok := c.hook.(DedicatedHook); ok {
+		return h.Dedicated(c.client, wrapped)
+	}
+	return c.client.Dedicated(wrapped)
 }
 
 func (c *hookclient) Dedicate() (valkey.DedicatedClient, func()) {
-	client, cancel := c.client.Dedicate()
+	var client valkey.DedicatedClient
+	var cancel func()
+	if h, ok := c.hook.(DedicatedHook); ok {
+		client, cancel = h.Dedicate(c.client)
+	} else {
+		client, cancel = c.client.Dedicate()
+	}
 	return &dedicated{client: &extended{DedicatedClient: client}, hook: c.hook}, cancel
 }
 
 func (c *hookclient) Receive(ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
-	return c.hook.Receive(c.client, ctx, subscribe, fn)
+	return c.hook.Receive(c.client, ctx, subscribe, onMessage(c.hook, ctx, fn))
 }
 
 func (c *hookclient) Nodes() map[string]valkey.Client {
 	nodes := c.client.Nodes()
 	for addr, client := range nodes {
-		nodes[addr] = &hookclient{client: client, hook: c.hook}
+		nodes[addr] = WithHook(client, c.hook)
 	}
 	return nodes
 }
@@ -110,10 +158,14 @@ func (d *dedicated) DoMulti(ctx context.Context, multi ...valkey.Completed) (res
 }
 
 func (d *dedicated) Receive(ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
-	return d.hook.Receive(d.client, ctx, subscribe, fn)
+	return d.hook.Receive(d.client, ctx, subscribe, onMessage(d.hook, ctx, fn))
 }
 
 func (d *dedicated) SetPubSubHooks(hooks valkey.PubSubHooks) <-chan error {
+	if h, ok := d.hook.(PubSubHook); ok {
+		hooks.OnMessage = onMessage(d.hook, context.Background(), hooks.OnMessage)
+		return h.SetPubSubHooks(d.client.DedicatedClient, hooks)
+	}
 	return d.client.SetPubSubHooks(hooks)
 }
 
@@ -121,6 +173,17 @@ func (d *dedicated) Close() {
 	d.client.Close()
 }
 
+// onMessage wraps the fn with PubSubHook.OnMessage if the hook implements it.
+func onMessage(hook Hook, ctx context.Context, fn func(msg valkey.PubSubMessage)) func(msg valkey.PubSubMessage) {
+	h, ok := hook.(PubSubHook)
+	if !ok || fn == nil {
+		return fn
+	}
+	return func(msg valkey.PubSubMessage) {
+		h.OnMessage(ctx, msg, fn)
+	}
+}
+
 var _ valkey.Client = (*extended)(nil)
 
 type extended struct {
@@ -228,6 +228,97 @@ func TestWithHook(t *testing.T) {
 	}
 }
 
+type extendedhook struct {
+	hook
+	acquired int
+	released int
+	messages []string
+	sethooks int
+}
+
+func (h *extendedhook) Dedicated(client valkey.Client, fn func(valkey.DedicatedClient) error) (err error) {
+	return client.Dedicated(func(c valkey.DedicatedClient) error {
+		h.acquired++
+		defer func() { h.released++ }()
+		return fn(c)
+	})
+}
+
+func (h *extendedhook) OnMessage(ctx context.Context, msg valkey.PubSubMessage, fn func(msg valkey.PubSubMessage)) {
+	h.messages = append(h.messages, msg.Channel)
+	fn(msg)
+}
+
+func (h *extendedhook) SetPubSubHooks(client valkey.DedicatedClient, hooks valkey.PubSubHooks) <-chan error {
+	h.sethooks++
+	return client.SetPubSubHooks(hooks)
+}
+
+type dedicatedhook struct {
+	extendedhook
+}
+
+func (h *dedicatedhook) Dedicate(client valkey.Client) (valkey.DedicatedClient, func()) {
+	c, cancel := client.Dedicate()
+	h.acquired++
+	return c, func() {
+		h.released++
+		cancel()
+	}
+}
+
+func TestWithExtendedHook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocked := mock.NewClient(ctrl)
+	h := &dedicatedhook{}
+	hooked := WithHook(mocked, h)
+
+	testHooked(t, hooked, mocked)
+	if len(h.messages) != 1 {
+		t.Fatalf("unexpected messages %v", h.messages)
+	}
+	{
+		dc := mock.NewDedicatedClient(ctrl)
+		mocked.EXPECT().Dedicate().Return(dc, func() {})
+		c, cancel := hooked.Dedicate()
+		if h.acquired != 1 || h.released != 0 {
+			t.Fatalf("unexpected acquired %v released %v", h.acquired, h.released)
+		}
+		testHookedDedicated(t, c, dc)
+		cancel()
+		if h.acquired != 1 || h.released != 1 {
+			t.Fatalf("unexpected acquired %v released %v", h.acquired, h.released)
+		}
+	}
+	{
+		dc := mock.NewDedicatedClient(ctrl)
+		mocked.EXPECT().Dedicated(gomock.Any()).DoAndReturn(func(fn func(c valkey.DedicatedClient) error) error {
+			return fn(dc)
+		})
+		if err := hooked.Dedicated(func(c valkey.DedicatedClient) error {
+			if h.acquired != 2 || h.released != 1 {
+				t.Fatalf("unexpected acquired %v released %v", h.acquired, h.released)
+			}
+			dc.EXPECT().SetPubSubHooks(gomock.Any()).DoAndReturn(func(hooks valkey.PubSubHooks) <-chan error {
+				hooks.OnMessage(valkey.PubSubMessage{Channel: "d"})
+				return nil
+			})
+			c.SetPubSubHooks(valkey.PubSubHooks{OnMessage: func(m valkey.PubSubMessage) {}})
+			return errors.New("any")
+		}); err.Error() != "any" {
+			t.Fatalf("unexpected err %v", err)
+		}
+		if h.acquired != 2 || h.released != 2 {
+			t.Fatalf("unexpected acquired %v released %v", h.acquired, h.released)
+		}
+	}
+	if len(h.messages) != 3 || h.messages[2] != "d" || h.sethooks != 2 {
+		t.Fatalf("unexpected messages %v sethooks %v", h.messages, h.sethooks)
+	}
+}
+
 func TestForbiddenMethodForDedicatedClient(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
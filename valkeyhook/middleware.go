@@ -0,0 +1,298 @@
+package valkeyhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+var (
+	// ErrCommandDenied is returned for commands rejected by AllowCommands or DenyCommands.
+	ErrCommandDenied = errors.New("valkeyhook: command denied")
+	// ErrPayloadTooLarge is returned for commands rejected by MaxPayloadSize.
+	ErrPayloadTooLarge = errors.New("valkeyhook: payload too large")
+)
+
+// KeyPrefix returns a Hook that namespaces keys with the prefix by valkey.WithKeyPrefix, so it behaves the same:
+// the keys and the patterns of KEYS and SCAN are prefixed, and the prefix is stripped from the keys in their replies.
+// The prefixed client is built once by WithHook, and dedicated clients are acquired from it,
+// so that the replies of EXEC sent separately on a dedicated connection are stripped as well.
+func KeyPrefix(prefix string) Hook {
+	return &keyprefix{prefix: prefix}
+}
+
+type keyprefix struct {
+	prefixed valkey.Client // the valkey.WithKeyPrefix of the wrapped client, set by bind
+	prefix   string
+}
+
+func (h *keyprefix) bind(client valkey.Client) Hook {
+	return &keyprefix{prefixed: valkey.WithKeyPrefix(client, h.prefix), prefix: h.prefix}
+}
+
+// target returns the client to send commands through.
+// The dedicated clients are already prefixed, since they are acquired by the Dedicated and Dedicate of the hook.
+func (h *keyprefix) target(client valkey.Client) valkey.Client {
+	if _, ok := client.(*extended); ok {
+		return client
+	}
+	if h.prefixed != nil {
+		return h.prefixed
+	}
+	return valkey.WithKeyPrefix(client, h.prefix)
+}
+
+func (h *keyprefix) Do(client valkey.Client, ctx context.Context, cmd valkey.Completed) (resp valkey.ValkeyResult) {
+	return h.target(client).Do(ctx, cmd)
+}
+
+func (h *keyprefix) DoMulti(client valkey.Client, ctx context.Context, multi ...valkey.Completed) (resps []valkey.ValkeyResult) {
+	return h.target(client).DoMulti(ctx, multi...)
+}
+
+func (h *keyprefix) DoCache(client valkey.Client, ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) (resp valkey.ValkeyResult) {
+	return h.target(client).DoCache(ctx, cmd, ttl)
+}
+
+func (h *keyprefix) DoMultiCache(client valkey.Client, ctx context.Context, multi ...valkey.CacheableTTL) (resps []valkey.ValkeyResult) {
+	return h.target(client).DoMultiCache(ctx, multi...)
+}
+
+func (h *keyprefix) Receive(client valkey.Client, ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
+	return h.target(client).Receive(ctx, subscribe, fn)
+}
+
+func (h *keyprefix) DoStream(client valkey.Client, ctx context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	return h.target(client).DoStream(ctx, cmd)
+}
+
+func (h *keyprefix) DoMultiStream(client valkey.Client, ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	return h.target(client).DoMultiStream(ctx, multi...)
+}
+
+func (h *keyprefix) Dedicated(client valkey.Client, fn func(valkey.DedicatedClient) error) (err error) {
+	return h.target(client).Dedicated(fn)
+}
+
+func (h *keyprefix) Dedicate(client valkey.Client) (valkey.DedicatedClient, func()) {
+	return h.target(client).Dedicate()
+}
+
+// SlowLog returns a Hook that calls the fn with the commands that take longer than the threshold to complete.
+// Commands sent together by DoMulti or DoMultiCache are reported together with the elapsed time of the whole batch.
+// Receive, DoStream and DoMultiStream are not measured since they are expected to be long-running.
+// The arguments of every measured command are copied before sending, so the commands are still recycled.
+func SlowLog(threshold time.Duration, fn func(ctx context.Context, elapsed time.Duration, commands [][]string)) Hook {
+	return &slowlog{threshold: threshold, fn: fn}
+}
+
+type slowlog struct {
+	fn        func(ctx context.Context, elapsed time.Duration, commands [][]string)
+	threshold time.Duration
+}
+
+func (h *slowlog) Do(client valkey.Client, ctx context.Context, cmd valkey.Completed) (resp valkey.ValkeyResult) {
+	commands := [][]string{copyCommands(cmd.Commands())}
+	start := time.Now()
+	resp = client.Do(ctx, cmd)
+	if elapsed := time.Since(start); elapsed >= h.threshold {
+		h.fn(ctx, elapsed, commands)
+	}
+	return
+}
+
+func (h *slowlog) DoMulti(client valkey.Client, ctx context.Context, multi ...valkey.Completed) (resps []valkey.ValkeyResult) {
+	commands := make([][]string, len(multi))
+	for i, cmd := range multi {
+		commands[i] = copyCommands(cmd.Commands())
+	}
+	start := time.Now()
+	resps = client.DoMulti(ctx, multi...)
+	if elapsed := time.Since(start); elapsed >= h.threshold {
+		h.fn(ctx, elapsed, commands)
+	}
+	return
+}
+
+func (h *slowlog) DoCache(client valkey.Client, ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) (resp valkey.ValkeyResult) {
+	commands := [][]string{copyCommands(cmd.Commands())}
+	start := time.Now()
+	resp = client.DoCache(ctx, cmd, ttl)
+	if elapsed := time.Since(start); elapsed >= h.threshold {
+		h.fn(ctx, elapsed, commands)
+	}
+	return
+}
+
+func (h *slowlog) DoMultiCache(client valkey.Client, ctx context.Context, multi ...valkey.CacheableTTL) (resps []valkey.ValkeyResult) {
+	commands := make([][]string, len(multi))
+	for i, ct := range multi {
+		commands[i] = copyCommands(ct.Cmd.Commands())
+	}
+	start := time.Now()
+	resps = client.DoMultiCache(ctx, multi...)
+	if elapsed := time.Since(start); elapsed >= h.threshold {
+		h.fn(ctx, elapsed, commands)
+	}
+	return
+}
+
+// copyCommands copies the arguments of a command before sending it, since the command is recycled after it completes.
+func copyCommands(commands []string) []string {
+	return append(make([]string, 0, len(commands)), commands...)
+}
+
+func (h *slowlog) Receive(client valkey.Client, ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
+	return client.Receive(ctx, subscribe, fn)
+}
+
+func (h *slowlog) DoStream(client valkey.Client, ctx context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	return client.DoStream(ctx, cmd)
+}
+
+func (h *slowlog) DoMultiStream(client valkey.Client, ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	return client.DoMultiStream(ctx, multi...)
+}
+
+// AllowCommands returns a Hook that rejects commands not in the names with ErrCommandDenied.
+// A name can be a command, such as "GET", or a command with its subcommand, such as "CONFIG GET". Names are case-insensitive.
+// If any command of a DoMulti or DoMultiCache is rejected, none of them are sent.
+func AllowCommands(names ...string) Hook {
+	allowed := commandSet(names)
+	return &guard{check: func(commands []string) error {
+		if allowed.contains(commands) {
+			return nil
+		}
+		return fmt.Errorf("%w: %s is not allowed", ErrCommandDenied, commands[0])
+	}}
+}
+
+// DenyCommands returns a Hook that rejects commands in the names with ErrCommandDenied.
+// A name can be a command, such as "FLUSHALL", or a command with its subcommand, such as "CONFIG SET". Names are case-insensitive.
+// If any command of a DoMulti or DoMultiCache is rejected, none of them are sent.
+func DenyCommands(names ...string) Hook {
+	denied := commandSet(names)
+	return &guard{check: func(commands []string) error {
+		if denied.contains(commands) {
+			return fmt.Errorf("%w: %s is denied", ErrCommandDenied, commands[0])
+		}
+		return nil
+	}}
+}
+
+// MaxPayloadSize returns a Hook that rejects commands whose arguments are larger than the size in total with ErrPayloadTooLarge.
+// If any command of a DoMulti or DoMultiCache is rejected, none of them are sent.
+func MaxPayloadSize(size int) Hook {
+	return &guard{check: func(commands []string) error {
+		n := 0
+		for _, s := range commands {
+			n += len(s)
+		}
+		if n > size {
+			return fmt.Errorf("%w: %s has %d bytes, exceeding the limit of %d bytes", ErrPayloadTooLarge, commands[0], n, size)
+		}
+		return nil
+	}}
+}
+
+type commands map[string]struct{}
+
+func commandSet(names []string) commands {
+	set := make(commands, len(names))
+	for _, name := range names {
+		set[strings.ToUpper(strings.Join(strings.Fields(name), " "))] = struct{}{}
+	}
+	return set
+}
+
+func (s commands) contains(cmd []string) bool {
+	if len(cmd) == 0 {
+		return false
+	}
+	name := strings.ToUpper(cmd[0])
+	if _, ok := s[name]; ok {
+		return true
+	}
+	if len(cmd) > 1 {
+		_, ok := s[name+" "+strings.ToUpper(cmd[1])]
+		return ok
+	}
+	return false
+}
+
+// guard rejects commands that fail the check without sending them.
+type guard struct {
+	check func(commands []string) error
+}
+
+func (h *guard) multi(multi []valkey.Completed) error {
+	for i := range multi {
+		if err := h.check(multi[i].Commands()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func errorResults(n int, err error) []valkey.ValkeyResult {
+	resps := make([]valkey.ValkeyResult, n)
+	for i := range resps {
+		resps[i] = NewErrorResult(err)
+	}
+	return resps
+}
+
+func (h *guard) Do(client valkey.Client, ctx context.Context, cmd valkey.Completed) (resp valkey.ValkeyResult) {
+	if err := h.check(cmd.Commands()); err != nil {
+		return NewErrorResult(err)
+	}
+	return client.Do(ctx, cmd)
+}
+
+func (h *guard) DoMulti(client valkey.Client, ctx context.Context, multi ...valkey.Completed) (resps []valkey.ValkeyResult) {
+	if err := h.multi(multi); err != nil {
+		return errorResults(len(multi), err)
+	}
+	return client.DoMulti(ctx, multi...)
+}
+
+func (h *guard) DoCache(client valkey.Client, ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) (resp valkey.ValkeyResult) {
+	if err := h.check(cmd.Commands()); err != nil {
+		return NewErrorResult(err)
+	}
+	return client.DoCache(ctx, cmd, ttl)
+}
+
+func (h *guard) DoMultiCache(client valkey.Client, ctx context.Context, multi ...valkey.CacheableTTL) (resps []valkey.ValkeyResult) {
+	for i := range multi {
+		if err := h.check(multi[i].Cmd.Commands()); err != nil {
+			return errorResults(len(multi), err)
+		}
+	}
+	return client.DoMultiCache(ctx, multi...)
+}
+
+func (h *guard) Receive(client valkey.Client, ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
+	if err = h.check(subscribe.Commands()); err != nil {
+		return err
+	}
+	return client.Receive(ctx, subscribe, fn)
+}
+
+func (h *guard) DoStream(client valkey.Client, ctx context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	if err := h.check(cmd.Commands()); err != nil {
+		return NewErrorResultStream(err)
+	}
+	return client.DoStream(ctx, cmd)
+}
+
+func (h *guard) DoMultiStream(client valkey.Client, ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	if err := h.multi(multi); err != nil {
+		return NewErrorResultStream(err)
+	}
+	return client.DoMultiStream(ctx, multi...)
+}
@@ -0,0 +1,269 @@
+package valkeyhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/mock"
+	"go.uber.org/mock/gomock"
+)
+
+type orderhook struct {
+	hook
+	name string
+	logs *[]string
+}
+
+func (h *orderhook) Do(client valkey.Client, ctx context.Context, cmd valkey.Completed) (resp valkey.ValkeyResult) {
+	*h.logs = append(*h.logs, h.name+" before")
+	resp = client.Do(ctx, cmd)
+	*h.logs = append(*h.logs, h.name+" after")
+	return
+}
+
+func TestChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+
+	var logs []string
+	hooked := Chain(mocked, &orderhook{name: "1", logs: &logs}, &orderhook{name: "2", logs: &logs}, &orderhook{name: "3", logs: &logs})
+	mocked.EXPECT().Do(ctx, mock.Match("GET", "a")).DoAndReturn(func(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+		logs = append(logs, "do")
+		return mock.Result(mock.ValkeyNil())
+	})
+	hooked.Do(ctx, hooked.B().Get().Key("a").Build())
+	if !reflect.DeepEqual(logs, []string{"1 before", "2 before", "3 before", "do", "3 after", "2 after", "1 after"}) {
+		t.Fatalf("unexpected order %v", logs)
+	}
+	if Chain(mocked) != mocked {
+		t.Fatalf("Chain without hooks should return the client")
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+	hooked := WithHook(mocked, KeyPrefix("t1:"))
+	{
+		mocked.EXPECT().Do(ctx, mock.Match("MSET", "t1:{a}1", "1", "t1:{a}2", "2")).Return(mock.Result(mock.ValkeyString("OK")))
+		hooked.Do(ctx, hooked.B().Mset().KeyValue().KeyValue("{a}1", "1").KeyValue("{a}2", "2").Build())
+	}
+	{
+		mocked.EXPECT().DoMulti(ctx, mock.Match("GET", "t1:a"), mock.Match("KEYS", "t1:a*")).Return([]valkey.ValkeyResult{
+			mock.Result(mock.ValkeyNil()), mock.Result(mock.ValkeyArray(mock.ValkeyBlobString("t1:a1"))),
+		})
+		resps := hooked.DoMulti(ctx, hooked.B().Get().Key("a").Build(), hooked.B().Keys().Pattern("a*").Build())
+		if keys, err := resps[1].AsStrSlice(); err != nil || len(keys) != 1 || keys[0] != "a1" {
+			t.Fatalf("unexpected keys %v %v", keys, err)
+		}
+	}
+	{
+		mocked.EXPECT().DoCache(ctx, mock.Match("GET", "t1:b"), time.Second).Return(mock.Result(mock.ValkeyNil()))
+		hooked.DoCache(ctx, hooked.B().Get().Key("b").Cache(), time.Second)
+	}
+	{
+		mocked.EXPECT().DoMultiCache(ctx, mock.Match("GET", "t1:c")).Return([]valkey.ValkeyResult{mock.Result(mock.ValkeyNil())})
+		hooked.DoMultiCache(ctx, valkey.CT(hooked.B().Get().Key("c").Cache(), time.Second))
+	}
+	{
+		mocked.EXPECT().DoStream(ctx, mock.Match("GET", "t1:d")).Return(mock.ValkeyResultStream(mock.ValkeyNil()))
+		hooked.DoStream(ctx, hooked.B().Get().Key("d").Build())
+	}
+	{
+		mocked.EXPECT().DoMultiStream(ctx, mock.Match("XREAD", "STREAMS", "t1:e", "0")).Return(mock.MultiValkeyResultStream(mock.ValkeyNil()))
+		hooked.DoMultiStream(ctx, hooked.B().Xread().Streams().Key("e").Id("0").Build())
+	}
+	{
+		mocked.EXPECT().Receive(ctx, mock.Match("SUBSCRIBE", "ch"), gomock.Any()).Return(nil)
+		hooked.Receive(ctx, hooked.B().Subscribe().Channel("ch").Build(), func(msg valkey.PubSubMessage) {})
+	}
+	if h := hooked.(*hookclient).hook.(*keyprefix); h.prefixed == nil {
+		t.Fatalf("the prefixed client should be built by WithHook")
+	}
+	{
+		node := mock.NewClient(ctrl)
+		mocked.EXPECT().Nodes().Return(map[string]valkey.Client{"n": node})
+		node.EXPECT().Do(ctx, mock.Match("GET", "t1:f")).Return(mock.Result(mock.ValkeyNil()))
+		hooked.Nodes()["n"].Do(ctx, hooked.B().Get().Key("f").Build())
+	}
+	testKeyPrefixTx := func(t *testing.T, c valkey.DedicatedClient, dc *mock.DedicatedClient) {
+		gomock.InOrder(
+			dc.EXPECT().Do(ctx, mock.Match("MULTI")).Return(mock.Result(mock.ValkeyString("OK"))),
+			dc.EXPECT().Do(ctx, mock.Match("KEYS", "t1:*")).Return(mock.Result(mock.ValkeyString("QUEUED"))),
+			dc.EXPECT().Do(ctx, mock.Match("EXEC")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyArray(mock.ValkeyBlobString("t1:a"))))),
+		)
+		c.Do(ctx, c.B().Multi().Build())
+		c.Do(ctx, c.B().Keys().Pattern("*").Build())
+		keys, err := c.Do(ctx, c.B().Exec().Build()).ToArray()
+		if err != nil || len(keys) != 1 {
+			t.Fatalf("unexpected exec %v %v", keys, err)
+		}
+		if k, err := keys[0].AsStrSlice(); err != nil || len(k) != 1 || k[0] != "a" {
+			t.Fatalf("unexpected keys %v %v", k, err)
+		}
+	}
+	t.Run("Dedicate", func(t *testing.T) {
+		dc := mock.NewDedicatedClient(ctrl)
+		mocked.EXPECT().Dedicate().Return(dc, func() {})
+		c, cancel := hooked.Dedicate()
+		defer cancel()
+		testKeyPrefixTx(t, c, dc)
+	})
+	t.Run("Dedicated", func(t *testing.T) {
+		dc := mock.NewDedicatedClient(ctrl)
+		mocked.EXPECT().Dedicated(gomock.Any()).DoAndReturn(func(fn func(c valkey.DedicatedClient) error) error {
+			return fn(dc)
+		})
+		if err := hooked.Dedicated(func(c valkey.DedicatedClient) error {
+			testKeyPrefixTx(t, c, dc)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestSlowLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+
+	var logged [][]string
+	hooked := WithHook(mocked, SlowLog(10*time.Millisecond, func(ctx context.Context, elapsed time.Duration, commands [][]string) {
+		if elapsed < 10*time.Millisecond {
+			t.Fatalf("unexpected elapsed %v", elapsed)
+		}
+		logged = append(logged, commands...)
+	}))
+	slow := func(resp valkey.ValkeyResult) func() valkey.ValkeyResult {
+		return func() valkey.ValkeyResult {
+			time.Sleep(10 * time.Millisecond)
+			return resp
+		}
+	}
+	mocked.EXPECT().Do(ctx, mock.Match("GET", "fast")).Return(mock.Result(mock.ValkeyNil()))
+	mocked.EXPECT().Do(ctx, mock.Match("GET", "slow")).DoAndReturn(func(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+		return slow(mock.Result(mock.ValkeyNil()))()
+	})
+	mocked.EXPECT().DoMulti(ctx, mock.Match("GET", "m1"), mock.Match("GET", "m2")).DoAndReturn(func(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+		return []valkey.ValkeyResult{slow(mock.Result(mock.ValkeyNil()))(), mock.Result(mock.ValkeyNil())}
+	})
+	mocked.EXPECT().DoCache(ctx, mock.Match("GET", "c"), time.Second).DoAndReturn(func(ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) valkey.ValkeyResult {
+		return slow(mock.Result(mock.ValkeyNil()))()
+	})
+	mocked.EXPECT().DoMultiCache(ctx, mock.Match("GET", "mc")).DoAndReturn(func(ctx context.Context, multi ...valkey.CacheableTTL) []valkey.ValkeyResult {
+		return []valkey.ValkeyResult{slow(mock.Result(mock.ValkeyNil()))()}
+	})
+	hooked.Do(ctx, hooked.B().Get().Key("fast").Build())
+	hooked.Do(ctx, hooked.B().Get().Key("slow").Build())
+	hooked.DoMulti(ctx, hooked.B().Get().Key("m1").Build(), hooked.B().Get().Key("m2").Build())
+	hooked.DoCache(ctx, hooked.B().Get().Key("c").Cache(), time.Second)
+	hooked.DoMultiCache(ctx, valkey.CT(hooked.B().Get().Key("mc").Cache(), time.Second))
+	if !reflect.DeepEqual(logged, [][]string{{"GET", "slow"}, {"GET", "m1"}, {"GET", "m2"}, {"GET", "c"}, {"GET", "mc"}}) {
+		t.Fatalf("unexpected logged %v", logged)
+	}
+}
+
+func TestAllowCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+	hooked := WithHook(mocked, AllowCommands("get", "config  get"))
+
+	mocked.EXPECT().Do(ctx, mock.Match("GET", "a")).Return(mock.Result(mock.ValkeyNil()))
+	mocked.EXPECT().Do(ctx, mock.Match("CONFIG", "GET", "maxmemory")).Return(mock.Result(mock.ValkeyArray()))
+	if err := hooked.Do(ctx, hooked.B().Get().Key("a").Build()).Error(); !valkey.IsValkeyNil(err) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.Do(ctx, hooked.B().ConfigGet().Parameter("maxmemory").Build()).Error(); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.Do(ctx, hooked.B().ConfigSet().ParameterValue().ParameterValue("maxmemory", "1").Build()).Error(); !errors.Is(err, ErrCommandDenied) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	for _, resp := range hooked.DoMulti(ctx, hooked.B().Get().Key("a").Build(), hooked.B().Set().Key("a").Value("b").Build()) {
+		if err := resp.Error(); !errors.Is(err, ErrCommandDenied) {
+			t.Fatalf("unexpected err %v", err)
+		}
+	}
+	if err := hooked.Receive(ctx, hooked.B().Subscribe().Channel("ch").Build(), func(msg valkey.PubSubMessage) {}); !errors.Is(err, ErrCommandDenied) {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+func TestDenyCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+	hooked := WithHook(mocked, DenyCommands("FLUSHALL", "CONFIG SET"))
+
+	mocked.EXPECT().Do(ctx, mock.Match("CONFIG", "GET", "maxmemory")).Return(mock.Result(mock.ValkeyArray()))
+	mocked.EXPECT().DoCache(ctx, mock.Match("GET", "a"), time.Second).Return(mock.Result(mock.ValkeyNil()))
+	mocked.EXPECT().DoMultiCache(ctx, mock.Match("GET", "a")).Return([]valkey.ValkeyResult{mock.Result(mock.ValkeyNil())})
+	if err := hooked.Do(ctx, hooked.B().ConfigGet().Parameter("maxmemory").Build()).Error(); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.Do(ctx, hooked.B().Arbitrary("flushall").Build()).Error(); !errors.Is(err, ErrCommandDenied) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.DoCache(ctx, hooked.B().Get().Key("a").Cache(), time.Second).Error(); !valkey.IsValkeyNil(err) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.DoMultiCache(ctx, valkey.CT(hooked.B().Get().Key("a").Cache(), time.Second))[0].Error(); !valkey.IsValkeyNil(err) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	s := hooked.DoStream(ctx, hooked.B().ConfigSet().ParameterValue().ParameterValue("maxmemory", "1").Build())
+	if _, err := s.WriteTo(io.Discard); !errors.Is(err, ErrCommandDenied) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	s = hooked.DoMultiStream(ctx, hooked.B().Flushall().Build())
+	if _, err := s.WriteTo(io.Discard); !errors.Is(err, ErrCommandDenied) {
+		t.Fatalf("unexpected err %v", err)
+	}
+}
+
+func TestMaxPayloadSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mocked := mock.NewClient(ctrl)
+	hooked := WithHook(mocked, MaxPayloadSize(10))
+
+	mocked.EXPECT().Do(ctx, mock.Match("SET", "a", "12345")).Return(mock.Result(mock.ValkeyString("OK")))
+	if err := hooked.Do(ctx, hooked.B().Set().Key("a").Value("12345").Build()).Error(); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.Do(ctx, hooked.B().Set().Key("a").Value("123456789").Build()).Error(); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	if err := hooked.DoCache(ctx, hooked.B().Get().Key(strings.Repeat("a", 10)).Cache(), time.Second).Error(); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("unexpected err %v", err)
+	}
+	for _, resp := range hooked.DoMultiCache(ctx,
+		valkey.CT(hooked.B().Get().Key("a").Cache(), time.Second),
+		valkey.CT(hooked.B().Get().Key(strings.Repeat("a", 10)).Cache(), time.Second),
+	) {
+		if err := resp.Error(); !errors.Is(err, ErrPayloadTooLarge) {
+			t.Fatalf("unexpected err %v", err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package valkeylimiter
+
+import (
+	"sync"
+	"time"
+)
+
+type deniedKey struct {
+	identifier string
+	limit      int64
+	window     time.Duration
+}
+
+// deniedCache remembers identifiers that have exhausted their limit until their ResetAtMs,
+// so that further requests can be rejected without a round trip.
+type deniedCache struct {
+	entries map[deniedKey]int64
+	sweepAt int
+	mu      sync.Mutex
+}
+
+const minDeniedSweep = 1024
+
+func newDeniedCache() *deniedCache {
+	return &deniedCache{entries: make(map[deniedKey]int64), sweepAt: minDeniedSweep}
+}
+
+func (c *deniedCache) Get(key deniedKey, nowMs int64) (resetAtMs int64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if resetAtMs, ok = c.entries[key]; ok && resetAtMs <= nowMs {
+		delete(c.entries, key)
+		return 0, false
+	}
+	return resetAtMs, ok
+}
+
+func (c *deniedCache) Set(key deniedKey, resetAtMs, nowMs int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.sweepAt {
+		for k, v := range c.entries {
+			if v <= nowMs {
+				delete(c.entries, k)
+			}
+		}
+		c.sweepAt = max(len(c.entries)*2, minDeniedSweep)
+	}
+	c.entries[key] = resetAtMs
+}
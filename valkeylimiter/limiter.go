@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
 )
 
 var (
@@ -15,6 +16,22 @@ var (
 	ErrInvalidLimit    = errors.New("limit must be positive")
 	ErrInvalidWindow   = errors.New("window must be positive")
 	ErrNilBuilder      = errors.New("client builder is required")
+	ErrInvalidAlgo     = errors.New("unknown rate limiting algorithm")
+)
+
+// Algorithm selects how a rate limiter counts requests within a window.
+type Algorithm int
+
+const (
+	// FixedWindow counts requests in consecutive, non-overlapping windows.
+	// It is the cheapest algorithm but allows up to twice the limit around window boundaries.
+	FixedWindow Algorithm = iota
+	// SlidingWindowLog records a timestamp for every allowed request in a sorted set.
+	// It enforces the limit exactly over any window-sized interval at the cost of O(limit) memory per identifier.
+	SlidingWindowLog
+	// SlidingWindowCounter approximates a sliding window by weighting the previous window's count
+	// by how much of it still overlaps the sliding window, plus the current window's count.
+	SlidingWindowCounter
 )
 
 type Result struct {
@@ -27,6 +44,8 @@ type RateLimiterClient interface {
 	Check(ctx context.Context, identifier string, options ...RateLimitOption) (Result, error)
 	Allow(ctx context.Context, identifier string, options ...RateLimitOption) (Result, error)
 	AllowN(ctx context.Context, identifier string, n int64, options ...RateLimitOption) (Result, error)
+	AllowMulti(ctx context.Context, limits []LimitSpec) (MultiResult, error)
+	AllowMultiN(ctx context.Context, limits []LimitSpec, n int64) (MultiResult, error)
 	Limit() int
 }
 
@@ -40,6 +59,9 @@ type rateLimiter struct {
 	client           valkey.Client
 	keyPrefix        string
 	defaultRateLimit RateLimitOption
+	checkSlots       bool // whether the client routes commands by slots, such as a cluster client
+	denied           *deniedCache
+	algorithm        Algorithm
 }
 
 type RateLimiterOption struct {
@@ -48,6 +70,12 @@ type RateLimiterOption struct {
 	ClientOption  valkey.ClientOption
 	Limit         int
 	Window        time.Duration
+	// Algorithm is the rate limiting algorithm. Defaults to FixedWindow.
+	Algorithm Algorithm
+	// LocalDenyCache enables an in-process cache of identifiers that have exhausted their limit.
+	// Until their ResetAtMs, further requests for them are rejected locally without a round trip.
+	// It is best-effort: a reset of the keys in Valkey is not observed until ResetAtMs.
+	LocalDenyCache bool
 }
 
 func NewRateLimiter(option RateLimiterOption) (RateLimiterClient, error) {
@@ -57,6 +85,9 @@ func NewRateLimiter(option RateLimiterOption) (RateLimiterClient, error) {
 	if option.Limit <= 0 {
 		return nil, ErrInvalidLimit
 	}
+	if option.Algorithm < FixedWindow || option.Algorithm > SlidingWindowCounter {
+		return nil, ErrInvalidAlgo
+	}
 	if option.KeyPrefix == "" {
 		option.KeyPrefix = PlaceholderPrefix
 	}
@@ -66,6 +97,10 @@ func NewRateLimiter(option RateLimiterOption) (RateLimiterClient, error) {
 			limit:  int64(option.Limit),
 			window: option.Window,
 		},
+		algorithm: option.Algorithm,
+	}
+	if option.LocalDenyCache {
+		rl.denied = newDeniedCache()
 	}
 
 	var err error
@@ -78,6 +113,10 @@ func NewRateLimiter(option RateLimiterOption) (RateLimiterClient, error) {
 		return nil, err
 	}
 	rl.keyPrefix = option.KeyPrefix
+	// the command is only built to know whether the client checks slots, and is recycled right away.
+	probe := rl.client.B().Get().Key(rl.keyPrefix).Build()
+	rl.checkSlots = probe.Slot()&cmds.NoSlot == 0
+	cmds.PutCompleted(probe)
 	return rl, nil
 }
 
@@ -102,11 +141,19 @@ func (l *rateLimiter) AllowN(ctx context.Context, identifier string, n int64, op
 		rl = options[len(options)-1]
 	}
 
+	now := time.Now().UTC()
+
+	var dk deniedKey
+	if l.denied != nil {
+		dk = deniedKey{identifier: identifier, limit: rl.limit, window: rl.window}
+		if resetAt, ok := l.denied.Get(dk, now.UnixMilli()); ok {
+			return Result{Allowed: false, Remaining: 0, ResetAtMs: resetAt}, nil
+		}
+	}
+
 	bufs := rateBuffersPool.Get(0, 128)
 	defer rateBuffersPool.Put(bufs)
 
-	now := time.Now().UTC()
-
 	offset := len(bufs.keyBuf)
 	bufs.keyBuf = append(bufs.keyBuf, l.keyPrefix...)
 	bufs.keyBuf = append(bufs.keyBuf, keyDelimOpen...)
@@ -118,15 +165,42 @@ func (l *rateLimiter) AllowN(ctx context.Context, identifier string, n int64, op
 	bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, n, 10)
 	arg1 := valkey.BinaryString(bufs.keyBuf[offset:])
 
-	offset = len(bufs.keyBuf)
-	bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, now.Add(rl.window).UnixMilli(), 10)
-	arg2 := valkey.BinaryString(bufs.keyBuf[offset:])
+	var script *valkey.Lua
+	var args []string
+	switch l.algorithm {
+	case SlidingWindowLog, SlidingWindowCounter:
+		offset = len(bufs.keyBuf)
+		bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, max(rl.window.Milliseconds(), 1), 10)
+		arg2 := valkey.BinaryString(bufs.keyBuf[offset:])
 
-	offset = len(bufs.keyBuf)
-	bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, now.UnixMilli(), 10)
-	arg3 := valkey.BinaryString(bufs.keyBuf[offset:])
+		offset = len(bufs.keyBuf)
+		bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, now.UnixMilli(), 10)
+		arg3 := valkey.BinaryString(bufs.keyBuf[offset:])
+
+		offset = len(bufs.keyBuf)
+		bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, rl.limit, 10)
+		arg4 := valkey.BinaryString(bufs.keyBuf[offset:])
+
+		args = []string{arg1, arg2, arg3, arg4}
+		if l.algorithm == SlidingWindowLog {
+			script = slidingWindowLogScript
+		} else {
+			script = slidingWindowCounterScript
+		}
+	default:
+		offset = len(bufs.keyBuf)
+		bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, now.Add(rl.window).UnixMilli(), 10)
+		arg2 := valkey.BinaryString(bufs.keyBuf[offset:])
 
-	resp := rateLimitScript.Exec(ctx, l.client, []string{key}, []string{arg1, arg2, arg3})
+		offset = len(bufs.keyBuf)
+		bufs.keyBuf = strconv.AppendInt(bufs.keyBuf, now.UnixMilli(), 10)
+		arg3 := valkey.BinaryString(bufs.keyBuf[offset:])
+
+		args = []string{arg1, arg2, arg3}
+		script = rateLimitScript
+	}
+
+	resp := script.Exec(ctx, l.client, []string{key}, args)
 	if err := resp.Error(); err != nil {
 		return Result{}, err
 	}
@@ -148,6 +222,17 @@ func (l *rateLimiter) AllowN(ctx context.Context, identifier string, n int64, op
 
 	remaining := max(rl.limit-current, 0)
 	allowed := current <= rl.limit && (n > 0 || current < rl.limit)
+	if l.denied != nil {
+		// the sliding window scripts do not record rejected tokens,
+		// so the stored count is lower than the reported one.
+		stored := current
+		if !allowed && l.algorithm != FixedWindow {
+			stored -= n
+		}
+		if stored >= rl.limit {
+			l.denied.Set(dk, resetAt, now.UnixMilli())
+		}
+	}
 
 	return Result{
 		Allowed:   allowed,
@@ -171,3 +256,53 @@ end
 local current = redis.call("incrby", rate_limit_key, increment_amount)
 return { current, expires_at }
 `)
+
+// slidingWindowLogScript keeps one sorted set member per allowed token, scored by its timestamp.
+// Tokens are only recorded when the whole request fits, so rejected requests never consume the limit.
+// The ":seq" counter shares the {identifier} hash tag and only exists to make members unique.
+var slidingWindowLogScript = valkey.NewLuaScript(`
+local log_key = KEYS[1]
+local increment_amount = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local current_time = tonumber(ARGV[3])
+local limit = tonumber(ARGV[4])
+local seq_key = log_key .. ":seq"
+redis.call("zremrangebyscore", log_key, "-inf", current_time - window)
+local current = redis.call("zcard", log_key)
+if increment_amount > 0 and current + increment_amount <= limit then
+  local seq = redis.call("incrby", seq_key, increment_amount)
+  for i = seq - increment_amount + 1, seq do
+    redis.call("zadd", log_key, current_time, i)
+  end
+  redis.call("pexpire", log_key, window + 1000)
+  redis.call("pexpire", seq_key, window + 1000)
+end
+local reset_at = current_time + window
+local oldest = redis.call("zrange", log_key, 0, 0, "withscores")
+if oldest[2] then
+  reset_at = tonumber(oldest[2]) + window
+end
+return { current + increment_amount, reset_at }
+`)
+
+// slidingWindowCounterScript estimates the count of the sliding window as
+// previous * (overlap of the previous window) + current, using one counter per fixed window.
+var slidingWindowCounterScript = valkey.NewLuaScript(`
+local rate_limit_key = KEYS[1]
+local increment_amount = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local current_time = tonumber(ARGV[3])
+local limit = tonumber(ARGV[4])
+local window_start = current_time - (current_time % window)
+local current_key = rate_limit_key .. ":" .. window_start
+local previous_key = rate_limit_key .. ":" .. (window_start - window)
+local current = tonumber(redis.call("get", current_key) or "0")
+local previous = tonumber(redis.call("get", previous_key) or "0")
+local weight = (window - (current_time - window_start)) / window
+local estimated = math.floor(previous * weight) + current
+if increment_amount > 0 and estimated + increment_amount <= limit then
+  redis.call("incrby", current_key, increment_amount)
+  redis.call("pexpireat", current_key, window_start + 2 * window + 1000)
+end
+return { estimated + increment_amount, window_start + window }
+`)
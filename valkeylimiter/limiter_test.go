@@ -61,6 +61,18 @@ func TestNewRateLimiter(t *testing.T) {
 			},
 			wantErr: valkeylimiter.ErrInvalidLimit,
 		},
+		{
+			name: "invalid algorithm",
+			opt: valkeylimiter.RateLimiterOption{
+				ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+					return mock.NewClient(gomock.NewController(t)), nil
+				},
+				Limit:     1,
+				Window:    time.Second,
+				Algorithm: valkeylimiter.Algorithm(-1),
+			},
+			wantErr: valkeylimiter.ErrInvalidAlgo,
+		},
 		{
 			name: "empty key prefix",
 			opt: valkeylimiter.RateLimiterOption{
@@ -260,6 +272,157 @@ func TestRateLimiter_AllowN(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_SlidingWindow(t *testing.T) {
+	now := time.Now()
+	resetTime := now.Add(time.Second).UnixMilli()
+
+	for _, algo := range []valkeylimiter.Algorithm{valkeylimiter.SlidingWindowLog, valkeylimiter.SlidingWindowCounter} {
+		tests := []struct {
+			name       string
+			current    int64
+			wantResult valkeylimiter.Result
+		}{
+			{
+				name:    "allowed",
+				current: 3,
+				wantResult: valkeylimiter.Result{
+					Allowed:   true,
+					Remaining: 2,
+					ResetAtMs: resetTime,
+				},
+			},
+			{
+				name:    "denied",
+				current: 6,
+				wantResult: valkeylimiter.Result{
+					Allowed:   false,
+					Remaining: 0,
+					ResetAtMs: resetTime,
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				client := mock.NewClient(ctrl)
+				client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+					// EVALSHA sha 1 key n window now limit
+					return len(cmd) == 8 && cmd[3] == "valkeylimiter:{test}" &&
+						cmd[4] == "1" && cmd[5] == "1000" && cmd[7] == "5"
+				}, "sliding window script")).Return(mock.Result(mock.ValkeyArray(
+					mock.ValkeyInt64(tt.current),
+					mock.ValkeyInt64(resetTime),
+				))).Times(1)
+
+				limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+					ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+						return client, nil
+					},
+					Limit:     5,
+					Window:    time.Second,
+					Algorithm: algo,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := limiter.Allow(context.Background(), "test")
+				if err != nil {
+					t.Fatalf("Allow() error = %v", err)
+				}
+				if got != tt.wantResult {
+					t.Fatalf("Allow() = %+v, want %+v", got, tt.wantResult)
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimiter_LocalDenyCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	resetTime := now.Add(time.Second).UnixMilli()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(gomock.Any(), gomock.Any()).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyInt64(11),
+		mock.ValkeyInt64(resetTime),
+	))).Times(1)
+	client.EXPECT().Do(gomock.Any(), gomock.Any()).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyInt64(1),
+		mock.ValkeyInt64(resetTime),
+	))).Times(2)
+
+	limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+		ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+			return client, nil
+		},
+		Limit:          10,
+		Window:         time.Second,
+		LocalDenyCache: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := valkeylimiter.Result{
+		Allowed:   false,
+		Remaining: 0,
+		ResetAtMs: resetTime,
+	}
+	for i := 0; i < 3; i++ {
+		got, err := limiter.Allow(context.Background(), "test")
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if got != want {
+			t.Fatalf("Allow() = %+v, want %+v", got, want)
+		}
+	}
+
+	// other identifiers and limits are not affected
+	if got, err := limiter.Allow(context.Background(), "other"); err != nil || !got.Allowed {
+		t.Fatalf("Allow() = %+v, %v", got, err)
+	}
+	if got, err := limiter.Allow(context.Background(), "test", valkeylimiter.WithCustomRateLimit(20, time.Second)); err != nil || !got.Allowed {
+		t.Fatalf("Allow() = %+v, %v", got, err)
+	}
+}
+
+func TestRateLimiter_LocalDenyCacheExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resetTime := time.Now().Add(-time.Second).UnixMilli()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(gomock.Any(), gomock.Any()).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyInt64(11),
+		mock.ValkeyInt64(resetTime),
+	))).Times(2)
+
+	limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+		ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+			return client, nil
+		},
+		Limit:          10,
+		Window:         time.Second,
+		LocalDenyCache: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if got, err := limiter.Allow(context.Background(), "test"); err != nil || got.Allowed {
+			t.Fatalf("Allow() = %+v, %v", got, err)
+		}
+	}
+}
+
 func TestRateLimiter_Check(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
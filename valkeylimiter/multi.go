@@ -0,0 +1,269 @@
+package valkeylimiter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+// LimitSpec is one of the limits evaluated together by AllowMulti.
+type LimitSpec struct {
+	// Identifier is the identifier of the limit, such as a user ID or a tenant ID.
+	// Identifiers starting with the same hash tag, such as "{tenant-9}:user-1" and "{tenant-9}",
+	// share a slot and are evaluated atomically in Valkey Cluster.
+	Identifier string
+	// Option overrides the limit and window of the limiter. Use WithCustomRateLimit to build it.
+	// The zero value uses the limiter's default limit and window.
+	Option RateLimitOption
+}
+
+// MultiResult is the result of AllowMulti.
+type MultiResult struct {
+	// Results are the results of each LimitSpec, in the same order.
+	// When Allowed is false, no tokens are consumed, and Results[i].Allowed
+	// indicates whether the i-th limit alone would have allowed the request.
+	// If the request is rejected by the LocalDenyCache, only the results of
+	// the locally denied limits are filled, and the others are left as zero values.
+	Results []Result
+	// Allowed is true only if all limits allowed the request.
+	Allowed bool
+}
+
+type multiGroup struct {
+	keys []string
+	args []string
+	idx  []int
+}
+
+func (l *rateLimiter) AllowMulti(ctx context.Context, limits []LimitSpec) (MultiResult, error) {
+	return l.AllowMultiN(ctx, limits, 1)
+}
+
+// AllowMultiN consumes n tokens from every limit only if all of them allow the request.
+// Limits sharing the same slot are evaluated atomically by a single Lua script.
+// Otherwise, it falls back to a best-effort two-phase check: all slots are checked first without
+// consuming tokens, and then tokens are consumed slot by slot. If a limit is exhausted by concurrent
+// requests between the two phases, tokens already consumed from the other slots are not refunded.
+func (l *rateLimiter) AllowMultiN(ctx context.Context, limits []LimitSpec, n int64) (MultiResult, error) {
+	if n < 0 {
+		return MultiResult{}, ErrInvalidTokens
+	}
+	if len(limits) == 0 {
+		return MultiResult{Allowed: true}, nil
+	}
+
+	now := time.Now().UTC()
+	rls := make([]RateLimitOption, len(limits))
+	for i, spec := range limits {
+		rls[i] = spec.Option
+		if rls[i].limit == 0 && rls[i].window == 0 {
+			rls[i] = l.defaultRateLimit
+		}
+	}
+
+	if l.denied != nil {
+		var res MultiResult
+		for i, spec := range limits {
+			if resetAt, ok := l.denied.Get(deniedKey{identifier: spec.Identifier, limit: rls[i].limit, window: rls[i].window}, now.UnixMilli()); ok {
+				if res.Results == nil {
+					res.Results = make([]Result, len(limits))
+				}
+				res.Results[i] = Result{Allowed: false, Remaining: 0, ResetAtMs: resetAt}
+			}
+		}
+		if res.Results != nil {
+			return res, nil
+		}
+	}
+
+	groups := l.groupMulti(limits, rls, now)
+	if len(groups) > 1 && n > 0 {
+		checked, err := l.execMulti(ctx, groups, limits, rls, 0)
+		if err != nil {
+			return MultiResult{}, err
+		}
+		if res := l.multiResult(checked, rls, n, now, limits); !res.Allowed {
+			return res, nil
+		}
+	}
+	stored, err := l.execMulti(ctx, groups, limits, rls, n)
+	if err != nil {
+		return MultiResult{}, err
+	}
+	return l.multiResult(stored, rls, n, now, limits), nil
+}
+
+// groupMulti groups limits by their slots. All limits are put into one group if the client doesn't check slots.
+func (l *rateLimiter) groupMulti(limits []LimitSpec, rls []RateLimitOption, now time.Time) []multiGroup {
+	nowArg := strconv.FormatInt(now.UnixMilli(), 10)
+	slots := make(map[uint16]int, 1)
+	groups := make([]multiGroup, 0, 1)
+	for i, spec := range limits {
+		key := l.keyPrefix + keyDelimOpen + spec.Identifier + keyDelimClose
+		slot := cmds.NoSlot
+		if l.checkSlots {
+			slot = cmds.Slot(key)
+		}
+		g, ok := slots[slot]
+		if !ok {
+			g = len(groups)
+			slots[slot] = g
+			groups = append(groups, multiGroup{args: []string{"", nowArg}})
+		}
+		groups[g].keys = append(groups[g].keys, key)
+		groups[g].args = append(groups[g].args,
+			strconv.FormatInt(max(rls[i].window.Milliseconds(), 1), 10),
+			strconv.FormatInt(rls[i].limit, 10),
+		)
+		groups[g].idx = append(groups[g].idx, i)
+	}
+	return groups
+}
+
+type multiStored struct {
+	stored  int64
+	resetAt int64
+}
+
+func (l *rateLimiter) execMulti(ctx context.Context, groups []multiGroup, limits []LimitSpec, rls []RateLimitOption, n int64) ([]multiStored, error) {
+	var script *valkey.Lua
+	switch l.algorithm {
+	case SlidingWindowLog:
+		script = slidingWindowLogMultiScript
+	case SlidingWindowCounter:
+		script = slidingWindowCounterMultiScript
+	default:
+		script = fixedWindowMultiScript
+	}
+	nArg := strconv.FormatInt(n, 10)
+	stored := make([]multiStored, len(limits))
+	for _, g := range groups {
+		g.args[0] = nArg
+		resp := script.Exec(ctx, l.client, g.keys, g.args)
+		if err := resp.Error(); err != nil {
+			return nil, err
+		}
+		arr, err := resp.ToArray()
+		if err != nil || len(arr) != 2*len(g.keys) {
+			return nil, ErrInvalidResponse
+		}
+		for j, i := range g.idx {
+			if stored[i].stored, err = arr[2*j].ToInt64(); err != nil {
+				return nil, ErrInvalidResponse
+			}
+			if stored[i].resetAt, err = arr[2*j+1].ToInt64(); err != nil {
+				return nil, ErrInvalidResponse
+			}
+		}
+	}
+	return stored, nil
+}
+
+func (l *rateLimiter) multiResult(stored []multiStored, rls []RateLimitOption, n int64, now time.Time, limits []LimitSpec) MultiResult {
+	res := MultiResult{Results: make([]Result, len(stored)), Allowed: true}
+	for i, s := range stored {
+		res.Results[i].Allowed = s.stored+n <= rls[i].limit && (n > 0 || s.stored < rls[i].limit)
+		res.Results[i].ResetAtMs = s.resetAt
+		res.Allowed = res.Allowed && res.Results[i].Allowed
+	}
+	for i, s := range stored {
+		current := s.stored
+		if res.Allowed {
+			current += n
+		}
+		res.Results[i].Remaining = max(rls[i].limit-current, 0)
+		if l.denied != nil && current >= rls[i].limit {
+			l.denied.Set(deniedKey{identifier: limits[i].Identifier, limit: rls[i].limit, window: rls[i].window}, s.resetAt, now.UnixMilli())
+		}
+	}
+	return res
+}
+
+// multiLimitDriver evaluates the peek function of every key first, and calls the take function
+// of every key only if all of them allow the increment. It returns the stored count before the
+// increment and the reset time of each key. The peek and take functions are prepended by each algorithm.
+const multiLimitDriver = `
+local increment_amount = tonumber(ARGV[1])
+local current_time = tonumber(ARGV[2])
+local states = {}
+local allowed = increment_amount > 0
+for i = 1, #KEYS do
+  local window = tonumber(ARGV[2 * i + 1])
+  local limit = tonumber(ARGV[2 * i + 2])
+  local state = peek(KEYS[i], window, current_time)
+  if state.stored + increment_amount > limit then
+    allowed = false
+  end
+  states[i] = state
+end
+local result = {}
+for i = 1, #KEYS do
+  local window = tonumber(ARGV[2 * i + 1])
+  if allowed then
+    take(KEYS[i], increment_amount, window, current_time, states[i])
+  end
+  result[2 * i - 1] = states[i].stored
+  result[2 * i] = states[i].reset_at
+end
+return result
+`
+
+var fixedWindowMultiScript = valkey.NewLuaScript(`
+local function peek(key, window, current_time)
+  local expires_at = tonumber(redis.call("get", key .. ":ex"))
+  if not expires_at or expires_at < current_time then
+    return { stored = 0, reset_at = current_time + window, fresh = true }
+  end
+  return { stored = tonumber(redis.call("get", key) or "0"), reset_at = expires_at }
+end
+local function take(key, increment_amount, window, current_time, state)
+  if state.fresh then
+    redis.call("set", key, increment_amount, "pxat", state.reset_at + 1000)
+    redis.call("set", key .. ":ex", state.reset_at, "pxat", state.reset_at + 1000)
+  else
+    redis.call("incrby", key, increment_amount)
+  end
+end
+` + multiLimitDriver)
+
+var slidingWindowLogMultiScript = valkey.NewLuaScript(`
+local function oldest_reset_at(key, window, current_time)
+  local oldest = redis.call("zrange", key, 0, 0, "withscores")
+  if oldest[2] then
+    return tonumber(oldest[2]) + window
+  end
+  return current_time + window
+end
+local function peek(key, window, current_time)
+  redis.call("zremrangebyscore", key, "-inf", current_time - window)
+  return { stored = redis.call("zcard", key), reset_at = oldest_reset_at(key, window, current_time) }
+end
+local function take(key, increment_amount, window, current_time, state)
+  local seq_key = key .. ":seq"
+  local seq = redis.call("incrby", seq_key, increment_amount)
+  for i = seq - increment_amount + 1, seq do
+    redis.call("zadd", key, current_time, i)
+  end
+  redis.call("pexpire", key, window + 1000)
+  redis.call("pexpire", seq_key, window + 1000)
+  state.reset_at = oldest_reset_at(key, window, current_time)
+end
+` + multiLimitDriver)
+
+var slidingWindowCounterMultiScript = valkey.NewLuaScript(`
+local function peek(key, window, current_time)
+  local window_start = current_time - (current_time % window)
+  local current = tonumber(redis.call("get", key .. ":" .. window_start) or "0")
+  local previous = tonumber(redis.call("get", key .. ":" .. (window_start - window)) or "0")
+  local weight = (window - (current_time - window_start)) / window
+  return { stored = math.floor(previous * weight) + current, reset_at = window_start + window, window_start = window_start }
+end
+local function take(key, increment_amount, window, current_time, state)
+  local current_key = key .. ":" .. state.window_start
+  redis.call("incrby", current_key, increment_amount)
+  redis.call("pexpireat", current_key, state.window_start + 2 * window + 1000)
+end
+` + multiLimitDriver)
@@ -0,0 +1,225 @@
+package valkeylimiter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/mock"
+	"github.com/valkey-io/valkey-go/valkeylimiter"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimiter_AllowMulti(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resetTime := time.Now().Add(time.Second).UnixMilli()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+		// EVALSHA sha 2 key1 key2 n now window1 limit1 window2 limit2
+		return len(cmd) == 11 && cmd[2] == "2" && cmd[3] == "valkeylimiter:{user}" && cmd[4] == "valkeylimiter:{tenant}" &&
+			cmd[5] == "1" && cmd[7] == "1000" && cmd[8] == "10" && cmd[9] == "60000" && cmd[10] == "100"
+	}, "multi limit script")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyInt64(3),
+		mock.ValkeyInt64(resetTime),
+		mock.ValkeyInt64(99),
+		mock.ValkeyInt64(resetTime),
+	))).Times(1)
+
+	limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+		ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+			return client, nil
+		},
+		Limit:  10,
+		Window: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := limiter.AllowMulti(context.Background(), []valkeylimiter.LimitSpec{
+		{Identifier: "user"},
+		{Identifier: "tenant", Option: valkeylimiter.WithCustomRateLimit(100, time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("AllowMulti() error = %v", err)
+	}
+	want := valkeylimiter.MultiResult{
+		Results: []valkeylimiter.Result{
+			{Allowed: true, Remaining: 6, ResetAtMs: resetTime},
+			{Allowed: true, Remaining: 0, ResetAtMs: resetTime},
+		},
+		Allowed: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("AllowMulti() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRateLimiter_AllowMultiRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resetTime := time.Now().Add(time.Second).UnixMilli()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(gomock.Any(), gomock.Any()).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyInt64(3),
+		mock.ValkeyInt64(resetTime),
+		mock.ValkeyInt64(100),
+		mock.ValkeyInt64(resetTime),
+	))).Times(1)
+
+	limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+		ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+			return client, nil
+		},
+		Limit:          10,
+		Window:         time.Second,
+		LocalDenyCache: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limits := []valkeylimiter.LimitSpec{
+		{Identifier: "user"},
+		{Identifier: "tenant", Option: valkeylimiter.WithCustomRateLimit(100, time.Minute)},
+	}
+	got, err := limiter.AllowMulti(context.Background(), limits)
+	if err != nil {
+		t.Fatalf("AllowMulti() error = %v", err)
+	}
+	want := valkeylimiter.MultiResult{
+		Results: []valkeylimiter.Result{
+			{Allowed: true, Remaining: 7, ResetAtMs: resetTime},
+			{Allowed: false, Remaining: 0, ResetAtMs: resetTime},
+		},
+		Allowed: false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("AllowMulti() = %+v, want %+v", got, want)
+	}
+
+	// the exhausted tenant is now rejected locally
+	got, err = limiter.AllowMulti(context.Background(), limits)
+	if err != nil {
+		t.Fatalf("AllowMulti() error = %v", err)
+	}
+	want = valkeylimiter.MultiResult{
+		Results: []valkeylimiter.Result{
+			{},
+			{Allowed: false, Remaining: 0, ResetAtMs: resetTime},
+		},
+		Allowed: false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("AllowMulti() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRateLimiter_AllowMultiCrossSlot(t *testing.T) {
+	resetTime := time.Now().Add(time.Second).UnixMilli()
+
+	t.Run("allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock.NewClient(ctrl, mock.WithSlotCheck())
+		gomock.InOrder(
+			client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+				return cmd[3] == "valkeylimiter:{user}" && cmd[4] == "0"
+			}, "check user")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1), mock.ValkeyInt64(resetTime)))),
+			client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+				return cmd[3] == "valkeylimiter:{global}" && cmd[4] == "0"
+			}, "check global")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(5), mock.ValkeyInt64(resetTime)))),
+			client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+				return cmd[3] == "valkeylimiter:{user}" && cmd[4] == "1"
+			}, "take user")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1), mock.ValkeyInt64(resetTime)))),
+			client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+				return cmd[3] == "valkeylimiter:{global}" && cmd[4] == "1"
+			}, "take global")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(5), mock.ValkeyInt64(resetTime)))),
+		)
+
+		limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+			ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+				return client, nil
+			},
+			Limit:  10,
+			Window: time.Second,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := limiter.AllowMulti(context.Background(), []valkeylimiter.LimitSpec{{Identifier: "user"}, {Identifier: "global"}})
+		if err != nil {
+			t.Fatalf("AllowMulti() error = %v", err)
+		}
+		if !got.Allowed || got.Results[0].Remaining != 8 || got.Results[1].Remaining != 4 {
+			t.Fatalf("AllowMulti() = %+v", got)
+		}
+	})
+
+	t.Run("rejected by check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock.NewClient(ctrl, mock.WithSlotCheck())
+		client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+			return cmd[3] == "valkeylimiter:{user}" && cmd[4] == "0"
+		}, "check user")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1), mock.ValkeyInt64(resetTime))))
+		client.EXPECT().Do(gomock.Any(), mock.MatchFn(func(cmd []string) bool {
+			return cmd[3] == "valkeylimiter:{global}" && cmd[4] == "0"
+		}, "check global")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(10), mock.ValkeyInt64(resetTime))))
+
+		limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+			ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+				return client, nil
+			},
+			Limit:  10,
+			Window: time.Second,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := limiter.AllowMulti(context.Background(), []valkeylimiter.LimitSpec{{Identifier: "user"}, {Identifier: "global"}})
+		if err != nil {
+			t.Fatalf("AllowMulti() error = %v", err)
+		}
+		if got.Allowed || !got.Results[0].Allowed || got.Results[1].Allowed {
+			t.Fatalf("AllowMulti() = %+v", got)
+		}
+	})
+}
+
+func TestRateLimiter_AllowMultiInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(gomock.Any(), gomock.Any()).Return(mock.Result(mock.ValkeyArray(mock.ValkeyInt64(1)))).Times(1)
+
+	limiter, err := valkeylimiter.NewRateLimiter(valkeylimiter.RateLimiterOption{
+		ClientBuilder: func(option valkey.ClientOption) (valkey.Client, error) {
+			return client, nil
+		},
+		Limit:  10,
+		Window: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := limiter.AllowMultiN(context.Background(), []valkeylimiter.LimitSpec{{Identifier: "user"}}, -1); err != valkeylimiter.ErrInvalidTokens {
+		t.Fatalf("AllowMultiN() error = %v", err)
+	}
+	if got, err := limiter.AllowMulti(context.Background(), nil); err != nil || !got.Allowed {
+		t.Fatalf("AllowMulti() = %+v, %v", got, err)
+	}
+	if _, err := limiter.AllowMulti(context.Background(), []valkeylimiter.LimitSpec{{Identifier: "user"}}); err != valkeylimiter.ErrInvalidResponse {
+		t.Fatalf("AllowMulti() error = %v", err)
+	}
+}
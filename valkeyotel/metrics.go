@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,6 +25,7 @@ var (
 // MetricAttrs set additional attributes to append to each metric.
 func MetricAttrs(attrs ...attribute.KeyValue) Option {
 	return func(o *otelclient) {
+		o.metricAttrs = attrs
 		mAttrs := metric.WithAttributeSet(attribute.NewSet(attrs...))
 		// Allocate slices once and use many times
 		o.addOpts = []metric.AddOption{mAttrs}
@@ -46,6 +48,14 @@ func WithOperationMetricAttr() Option {
 	}
 }
 
+// WithInternalMetrics enables the pipeline, connection pool, traffic and redirect metrics reported by valkey.MetricsHooks.
+// They come with extra costs on every read and write, since each connection is wrapped to count the bytes.
+func WithInternalMetrics() Option {
+	return func(cli *otelclient) {
+		cli.withInternals = true
+	}
+}
+
 type HistogramOption struct {
 	Buckets []float64
 }
@@ -78,6 +88,16 @@ func WithHistogramOption(histogramOption HistogramOption) Option {
 // - valkey_dial_success: number of successful dials
 // - valkey_dial_conns: number of active connections
 // - valkey_dial_latency: dial latency in seconds
+// The following metrics are also recorded if the WithInternalMetrics is used:
+// - valkey_pipe_in_flight: number of commands waiting for replies on each node
+// - valkey_pipe_queued: number of commands waiting to be written on each node
+// - valkey_pipe_flush_commands: number of commands written by each flush
+// - valkey_pool_conns: number of connections of the blocking pool on each node
+// - valkey_pool_idle: number of idle connections of the blocking pool on each node
+// - valkey_pool_wait_seconds: time waiting for a connection of the blocking pool
+// - valkey_bytes_read: number of bytes read from each node
+// - valkey_bytes_written: number of bytes written to each node
+// - valkey_redirects: number of MOVED and ASK redirects replied by each node
 func NewClient(clientOption valkey.ClientOption, opts ...Option) (valkey.Client, error) {
 	oclient, err := newClient(opts...)
 	if err != nil {
@@ -85,7 +105,7 @@ func NewClient(clientOption valkey.ClientOption, opts ...Option) (valkey.Client,
 	}
 
 	if clientOption.DialCtxFn == nil {
-		clientOption.DialCtxFn = defaultDialFn
+		clientOption.DialCtxFn = valkey.DefaultDialCtxFn
 		if clientOption.DialFn != nil {
 			clientOption.DialCtxFn = func(_ context.Context, s string, dialer *net.Dialer, config *tls.Config) (conn net.Conn, err error) {
 				return clientOption.DialFn(s, dialer, config)
@@ -124,8 +144,18 @@ func NewClient(clientOption valkey.ClientOption, opts ...Option) (valkey.Client,
 
 	clientOption.DialCtxFn = trackDialing(metrics, dialTracer{Tracer: oclient.tracer, tAttrs: oclient.tAttrs}, clientOption.DialCtxFn)
 
+	if oclient.withInternals {
+		internals, err := newInternalMetrics(oclient.meter, oclient.metricAttrs, oclient.histogramOption.Buckets)
+		if err != nil {
+			return nil, err
+		}
+		oclient.registration = internals.registration
+		clientOption.MetricsHooks = chainMetricsHooks(clientOption.MetricsHooks, internals.hooks())
+	}
+
 	cli, err := valkey.NewClient(clientOption)
 	if err != nil {
+		oclient.unregister()
 		return nil, err
 	}
 	oclient.client = cli
@@ -153,6 +183,7 @@ func newClient(opts ...Option) (*otelclient, error) {
 	// Now that we have the meterProvider and tracerProvider, get the Meter and Tracer
 	cli.meter = cli.meterProvider.Meter(name)
 	cli.tracer = cli.tracerProvider.Tracer(name)
+	cli.messagePropagation.tracer = cli.tracer
 	// Now create the counters using the meter
 	var err error
 	cli.cscMiss, err = cli.meter.Int64Counter("valkey_do_cache_miss")
@@ -226,10 +257,246 @@ func (t *connTracker) Close() error {
 	return t.Conn.Close()
 }
 
-func defaultDialFn(ctx context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (conn net.Conn, err error) {
-	if cfg != nil {
-		td := tls.Dialer{NetDialer: dialer, Config: cfg}
-		return td.DialContext(ctx, "tcp", dst)
+var flushHistogramBuckets = []float64{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024}
+
+type pipeEntry struct {
+	stats func() valkey.PipeStats
+	addr  string
+}
+
+type poolEntry struct {
+	stats func() valkey.PoolStats
+	addr  string
+}
+
+type addrOpts struct {
+	addOpts     []metric.AddOption
+	recordOpts  []metric.RecordOption
+	observeOpts []metric.ObserveOption
+	movedOpts   []metric.AddOption
+	askOpts     []metric.AddOption
+}
+
+// internalMetrics records the metrics reported by valkey.MetricsHooks from inside the client.
+type internalMetrics struct {
+	inFlight     metric.Int64ObservableGauge
+	queued       metric.Int64ObservableGauge
+	poolConns    metric.Int64ObservableGauge
+	poolIdle     metric.Int64ObservableGauge
+	poolWait     metric.Float64Histogram
+	bytesRead    metric.Int64Counter
+	bytesWritten metric.Int64Counter
+	flushes      metric.Int64Histogram
+	redirects    metric.Int64Counter
+	registration metric.Registration // of the callback observing the pipes and pools, unregistered by the otelclient.Close
+	pipes        map[*pipeEntry]struct{}
+	pools        map[*poolEntry]struct{}
+	attrs        []attribute.KeyValue
+	opts         sync.Map // addr -> *addrOpts
+	mu           sync.Mutex
+}
+
+func newInternalMetrics(meter metric.Meter, attrs []attribute.KeyValue, buckets []float64) (m *internalMetrics, err error) {
+	m = &internalMetrics{
+		pipes: make(map[*pipeEntry]struct{}),
+		pools: make(map[*poolEntry]struct{}),
+		attrs: attrs,
+	}
+	if m.inFlight, err = meter.Int64ObservableGauge("valkey_pipe_in_flight"); err != nil {
+		return nil, err
+	}
+	if m.queued, err = meter.Int64ObservableGauge("valkey_pipe_queued"); err != nil {
+		return nil, err
+	}
+	if m.poolConns, err = meter.Int64ObservableGauge("valkey_pool_conns"); err != nil {
+		return nil, err
+	}
+	if m.poolIdle, err = meter.Int64ObservableGauge("valkey_pool_idle"); err != nil {
+		return nil, err
+	}
+	if m.poolWait, err = meter.Float64Histogram(
+		"valkey_pool_wait_seconds",
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(buckets...),
+	); err != nil {
+		return nil, err
+	}
+	if m.bytesRead, err = meter.Int64Counter("valkey_bytes_read", metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	if m.bytesWritten, err = meter.Int64Counter("valkey_bytes_written", metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	if m.flushes, err = meter.Int64Histogram(
+		"valkey_pipe_flush_commands",
+		metric.WithExplicitBucketBoundaries(flushHistogramBuckets...),
+	); err != nil {
+		return nil, err
+	}
+	if m.redirects, err = meter.Int64Counter("valkey_redirects"); err != nil {
+		return nil, err
+	}
+	if m.registration, err = meter.RegisterCallback(m.observe, m.inFlight, m.queued, m.poolConns, m.poolIdle); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// addrOpts returns the cached metric options with the server.address attribute of the addr.
+func (m *internalMetrics) addrOpts(addr string) *addrOpts {
+	if o, ok := m.opts.Load(addr); ok {
+		return o.(*addrOpts)
+	}
+	attrs := append(append(make([]attribute.KeyValue, 0, len(m.attrs)+2), m.attrs...), attribute.String("server.address", addr))
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	o := &addrOpts{
+		addOpts:     []metric.AddOption{set},
+		recordOpts:  []metric.RecordOption{set},
+		observeOpts: []metric.ObserveOption{set},
+		movedOpts:   []metric.AddOption{metric.WithAttributeSet(attribute.NewSet(append(attrs, attribute.String("redirect", "moved"))...))},
+		askOpts:     []metric.AddOption{metric.WithAttributeSet(attribute.NewSet(append(attrs, attribute.String("redirect", "ask"))...))},
+	}
+	actual, _ := m.opts.LoadOrStore(addr, o)
+	return actual.(*addrOpts)
+}
+
+func (m *internalMetrics) observe(_ context.Context, o metric.Observer) error {
+	m.mu.Lock()
+	pipes := make([]*pipeEntry, 0, len(m.pipes))
+	for p := range m.pipes {
+		pipes = append(pipes, p)
+	}
+	pools := make([]*poolEntry, 0, len(m.pools))
+	for p := range m.pools {
+		pools = append(pools, p)
+	}
+	m.mu.Unlock()
+
+	// aggregate the pipes and pools of the same node
+	pipeStats := make(map[string]valkey.PipeStats, len(pipes))
+	for _, p := range pipes {
+		s, t := pipeStats[p.addr], p.stats()
+		s.InFlight += t.InFlight
+		s.Queued += t.Queued
+		pipeStats[p.addr] = s
+	}
+	for addr, s := range pipeStats {
+		opts := m.addrOpts(addr).observeOpts
+		o.ObserveInt64(m.inFlight, int64(s.InFlight), opts...)
+		o.ObserveInt64(m.queued, int64(s.Queued), opts...)
+	}
+	poolStats := make(map[string]valkey.PoolStats, len(pools))
+	for _, p := range pools {
+		s, t := poolStats[p.addr], p.stats()
+		s.Size += t.Size
+		s.Idle += t.Idle
+		poolStats[p.addr] = s
+	}
+	for addr, s := range poolStats {
+		opts := m.addrOpts(addr).observeOpts
+		o.ObserveInt64(m.poolConns, int64(s.Size), opts...)
+		o.ObserveInt64(m.poolIdle, int64(s.Idle), opts...)
+	}
+	return nil
+}
+
+func (m *internalMetrics) hooks() valkey.MetricsHooks {
+	return valkey.MetricsHooks{
+		OnPipeOpen: func(addr string, stats func() valkey.PipeStats) func() {
+			e := &pipeEntry{addr: addr, stats: stats}
+			m.mu.Lock()
+			m.pipes[e] = struct{}{}
+			m.mu.Unlock()
+			return func() {
+				m.mu.Lock()
+				delete(m.pipes, e)
+				m.mu.Unlock()
+			}
+		},
+		OnPoolOpen: func(addr string, stats func() valkey.PoolStats) func() {
+			e := &poolEntry{addr: addr, stats: stats}
+			m.mu.Lock()
+			m.pools[e] = struct{}{}
+			m.mu.Unlock()
+			return func() {
+				m.mu.Lock()
+				delete(m.pools, e)
+				m.mu.Unlock()
+			}
+		},
+		OnPoolWait: func(addr string, wait time.Duration) {
+			m.poolWait.Record(context.Background(), float64(wait)/float64(time.Second), m.addrOpts(addr).recordOpts...)
+		},
+		OnBytesRead: func(addr string, n int) {
+			m.bytesRead.Add(context.Background(), int64(n), m.addrOpts(addr).addOpts...)
+		},
+		OnBytesWritten: func(addr string, n int) {
+			m.bytesWritten.Add(context.Background(), int64(n), m.addrOpts(addr).addOpts...)
+		},
+		OnFlush: func(addr string, commands int) {
+			m.flushes.Record(context.Background(), int64(commands), m.addrOpts(addr).recordOpts...)
+		},
+		OnRedirect: func(addr string, target string, mode valkey.RedirectMode) {
+			if mode == valkey.RedirectAsk {
+				m.redirects.Add(context.Background(), 1, m.addrOpts(addr).askOpts...)
+			} else {
+				m.redirects.Add(context.Background(), 1, m.addrOpts(addr).movedOpts...)
+			}
+		},
+	}
+}
+
+// chainMetricsHooks calls the hooks of both a and b.
+func chainMetricsHooks(a, b valkey.MetricsHooks) valkey.MetricsHooks {
+	return valkey.MetricsHooks{
+		OnPipeOpen: func(addr string, stats func() valkey.PipeStats) func() {
+			return chainClosers(chainOpen(a.OnPipeOpen, addr, stats), chainOpen(b.OnPipeOpen, addr, stats))
+		},
+		OnPoolOpen: func(addr string, stats func() valkey.PoolStats) func() {
+			return chainClosers(chainOpen(a.OnPoolOpen, addr, stats), chainOpen(b.OnPoolOpen, addr, stats))
+		},
+		OnPoolWait:     chain2(a.OnPoolWait, b.OnPoolWait),
+		OnBytesRead:    chain2(a.OnBytesRead, b.OnBytesRead),
+		OnBytesWritten: chain2(a.OnBytesWritten, b.OnBytesWritten),
+		OnFlush:        chain2(a.OnFlush, b.OnFlush),
+		OnRedirect: func(addr string, target string, mode valkey.RedirectMode) {
+			if a.OnRedirect != nil {
+				a.OnRedirect(addr, target, mode)
+			}
+			if b.OnRedirect != nil {
+				b.OnRedirect(addr, target, mode)
+			}
+		},
+	}
+}
+
+func chainOpen[T any](fn func(string, func() T) func(), addr string, stats func() T) func() {
+	if fn == nil {
+		return nil
+	}
+	return fn(addr, stats)
+}
+
+func chainClosers(a, b func()) func() {
+	return func() {
+		if a != nil {
+			a()
+		}
+		if b != nil {
+			b()
+		}
+	}
+}
+
+func chain2[T any](a, b func(string, T)) func(string, T) {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return func(addr string, v T) {
+		a(addr, v)
+		b(addr, v)
 	}
-	return dialer.DialContext(ctx, "tcp", dst)
 }
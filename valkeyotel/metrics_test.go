@@ -7,7 +7,9 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
+	metricapi "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
@@ -114,6 +116,15 @@ func TestNewClientMeterError(t *testing.T) {
 		{"valkey_dial_latency"},
 		{"valkey_command_duration_seconds"},
 		{"valkey_command_errors"},
+		{"valkey_pipe_in_flight"},
+		{"valkey_pipe_queued"},
+		{"valkey_pool_conns"},
+		{"valkey_pool_idle"},
+		{"valkey_pool_wait_seconds"},
+		{"valkey_bytes_read"},
+		{"valkey_bytes_written"},
+		{"valkey_pipe_flush_commands"},
+		{"valkey_redirects"},
 	}
 
 	for _, tt := range tests {
@@ -124,6 +135,7 @@ func TestNewClientMeterError(t *testing.T) {
 					InitAddress: []string{"127.0.0.1:6379"},
 				},
 				WithMeterProvider(meterProvider),
+				WithInternalMetrics(),
 			)
 			if !errors.Is(err, errMocked) || !strings.Contains(err.Error(), tt.name) {
 				t.Errorf("mocked error: got %s, want %s", err, errMocked)
@@ -250,6 +262,157 @@ func TestTrackDialing(t *testing.T) {
 	})
 }
 
+func TestInternalMetrics(t *testing.T) {
+	mxp := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(mxp))
+
+	var userHooks int
+	hooks := valkey.MetricsHooks{
+		OnBytesRead: func(addr string, n int) { userHooks++ },
+		OnPipeOpen: func(addr string, stats func() valkey.PipeStats) func() {
+			userHooks++
+			return func() { userHooks++ }
+		},
+	}
+	m, err := newInternalMetrics(meterProvider.Meter("test"), nil, defaultHistogramBuckets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hooks = chainMetricsHooks(hooks, m.hooks())
+
+	closePipe1 := hooks.OnPipeOpen("n1", func() valkey.PipeStats { return valkey.PipeStats{InFlight: 2, Queued: 1} })
+	closePipe2 := hooks.OnPipeOpen("n1", func() valkey.PipeStats { return valkey.PipeStats{InFlight: 3, Queued: 4} })
+	closePool := hooks.OnPoolOpen("n1", func() valkey.PoolStats { return valkey.PoolStats{Size: 5, Idle: 2} })
+	hooks.OnPoolWait("n1", time.Second)
+	hooks.OnBytesRead("n1", 10)
+	hooks.OnBytesWritten("n1", 20)
+	hooks.OnFlush("n1", 7)
+	hooks.OnRedirect("n1", "n2", valkey.RedirectMove)
+	hooks.OnRedirect("n1", "n2", valkey.RedirectAsk)
+
+	metrics := metricdata.ResourceMetrics{}
+	if err := mxp.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]int64{
+		"valkey_pipe_in_flight": 5,
+		"valkey_pipe_queued":    5,
+		"valkey_pool_conns":     5,
+		"valkey_pool_idle":      2,
+	} {
+		if got := int64GaugeMetric(metrics, name); got != want {
+			t.Errorf("%s: got %d, want %d", name, got, want)
+		}
+	}
+	for name, want := range map[string]int64{
+		"valkey_bytes_read":    10,
+		"valkey_bytes_written": 20,
+	} {
+		if got := int64CountMetric(metrics, name); got != want {
+			t.Errorf("%s: got %d, want %d", name, got, want)
+		}
+	}
+	if got := float64HistogramMetric(metrics, "valkey_pool_wait_seconds"); got != 1 {
+		t.Errorf("valkey_pool_wait_seconds: got %v, want 1", got)
+	}
+	if got := findMetric(metrics, "valkey_pipe_flush_commands").(metricdata.Histogram[int64]).DataPoints[0].Sum; got != 7 {
+		t.Errorf("valkey_pipe_flush_commands: got %v, want 7", got)
+	}
+	if dps := findMetric(metrics, "valkey_redirects").(metricdata.Sum[int64]).DataPoints; len(dps) != 2 {
+		t.Errorf("valkey_redirects: got %d data points, want 2", len(dps))
+	}
+
+	closePipe1()
+	closePipe2()
+	closePool()
+	if userHooks != 5 {
+		t.Errorf("user hooks: got %d, want 5", userHooks)
+	}
+	metrics = metricdata.ResourceMetrics{}
+	if err := mxp.Collect(context.Background(), &metrics); err != nil {
+		t.Fatal(err)
+	}
+	if dps := findMetric(metrics, "valkey_pipe_in_flight"); dps != nil && len(dps.(metricdata.Gauge[int64]).DataPoints) != 0 {
+		t.Errorf("valkey_pipe_in_flight: unexpected data points after close")
+	}
+}
+
+func TestWithInternalMetrics(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		mr := metric.NewManualReader()
+		unregistered := 0
+		opts := []Option{WithMeterProvider(countingProvider{MeterProvider: metric.NewMeterProvider(metric.WithReader(mr)), unregistered: &unregistered})}
+		if enabled {
+			opts = append(opts, WithInternalMetrics())
+		}
+		c, err := NewClient(valkey.ClientOption{InitAddress: []string{"127.0.0.1:6379"}, DisableCache: true}, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Do(context.Background(), c.B().Ping().Build()).Error(); err != nil {
+			t.Fatal(err)
+		}
+
+		metrics := metricdata.ResourceMetrics{}
+		if err := mr.Collect(context.Background(), &metrics); err != nil {
+			t.Fatal(err)
+		}
+		if got := int64CountMetric(metrics, "valkey_bytes_read") > 0; got != enabled {
+			t.Fatalf("valkey_bytes_read: got %v, want %v", got, enabled)
+		}
+		if got := findMetric(metrics, "valkey_pipe_in_flight") != nil; got != enabled {
+			t.Fatalf("valkey_pipe_in_flight: got %v, want %v", got, enabled)
+		}
+
+		c.Close()
+		if want := map[bool]int{true: 1}[enabled]; unregistered != want {
+			t.Fatalf("unregistered: got %d, want %d", unregistered, want)
+		}
+	}
+}
+
+// countingProvider counts the Unregister calls of the callbacks registered to its meters.
+type countingProvider struct {
+	metricapi.MeterProvider
+	unregistered *int
+}
+
+func (p countingProvider) Meter(name string, opts ...metricapi.MeterOption) metricapi.Meter {
+	return countingMeter{Meter: p.MeterProvider.Meter(name, opts...), unregistered: p.unregistered}
+}
+
+type countingMeter struct {
+	metricapi.Meter
+	unregistered *int
+}
+
+func (m countingMeter) RegisterCallback(f metricapi.Callback, instruments ...metricapi.Observable) (metricapi.Registration, error) {
+	r, err := m.Meter.RegisterCallback(f, instruments...)
+	return countingRegistration{Registration: r, unregistered: m.unregistered}, err
+}
+
+type countingRegistration struct {
+	metricapi.Registration
+	unregistered *int
+}
+
+func (r countingRegistration) Unregister() error {
+	*r.unregistered++
+	return r.Registration.Unregister()
+}
+
+func int64GaugeMetric(metrics metricdata.ResourceMetrics, name string) int64 {
+	m := findMetric(metrics, name)
+	if m == nil {
+		return 0
+	}
+	var v int64
+	for _, dp := range m.(metricdata.Gauge[int64]).DataPoints {
+		v += dp.Value
+	}
+	return v
+}
+
 func findMetric(metrics metricdata.ResourceMetrics, name string) metricdata.Aggregation {
 	for _, sm := range metrics.ScopeMetrics {
 		for _, m := range sm.Metrics {
@@ -0,0 +1,292 @@
+package valkeyotel
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+// envelopePrefix starts a published message that carries a trace context.
+// The NUL bytes make it unlikely to collide with payloads of non-instrumented publishers.
+const envelopePrefix = "\x00otel\x00"
+
+var (
+	consumerKind = trace.WithSpanKind(trace.SpanKindConsumer)
+	msgSystem    = attribute.String("messaging.system", "valkey")
+	msgOperation = attribute.String("messaging.operation.type", "process")
+	msgDest      = attribute.Key("messaging.destination.name")
+	msgID        = attribute.Key("messaging.message.id")
+)
+
+// WithMessagePropagation enables trace context propagation through pub/sub messages and streams:
+//   - PUBLISH and SPUBLISH messages to the channels are wrapped in an envelope carrying the trace context of the command span.
+//   - Receive callbacks run inside consumer spans linked to the publisher, with the envelope already removed.
+//     Use the Receive function of this package to get the context of the consumer span in the callback.
+//   - XREAD and XREADGROUP spans get a link to the producer of each entry carrying a trace context.
+//
+// Use WithStreamPropagation to also append the trace context to XADD entries.
+//
+// The envelope changes the payload, so only the messages to the listed channels are wrapped,
+// and every subscriber of these channels must either be instrumented or remove the envelope by UnwrapMessage.
+// Messages to other channels are published unchanged.
+// If the propagator is nil, the W3C trace context propagator is used.
+func WithMessagePropagation(propagator propagation.TextMapPropagator, channels ...string) Option {
+	return func(o *otelclient) {
+		if propagator == nil {
+			propagator = propagation.TraceContext{}
+		}
+		o.propagator = propagator
+		o.channels = make(map[string]bool, len(channels))
+		for _, ch := range channels {
+			o.channels[ch] = true
+		}
+	}
+}
+
+// WithStreamPropagation enables appending the trace context of the command span to the entries
+// added by XADD to the streams, as extra fields such as traceparent and tracestate.
+// The fields are visible to every consumer of these streams, so only the listed streams get them.
+// It uses the propagator of WithMessagePropagation, or the W3C trace context propagator if there is none.
+func WithStreamPropagation(streams ...string) Option {
+	return func(o *otelclient) {
+		if o.propagator == nil {
+			o.propagator = propagation.TraceContext{}
+		}
+		o.streams = make(map[string]bool, len(streams))
+		for _, s := range streams {
+			o.streams[s] = true
+		}
+	}
+}
+
+// Receive is like the Receive of the client, but the fn also gets the context of each message.
+// If the client is created by NewClient with WithMessagePropagation, the context carries the consumer span
+// linked to the publisher, so that the work done by the fn continues the trace of the publisher.
+// Otherwise, the fn gets the ctx and the message with the envelope removed.
+func Receive(ctx context.Context, client valkey.CoreClient, subscribe valkey.Completed, fn func(ctx context.Context, msg valkey.PubSubMessage)) error {
+	if c, ok := client.(interface {
+		receiveContext(context.Context, valkey.Completed, func(context.Context, valkey.PubSubMessage)) error
+	}); ok {
+		return c.receiveContext(ctx, subscribe, fn)
+	}
+	return client.Receive(ctx, subscribe, func(msg valkey.PubSubMessage) {
+		msg.Message, _ = UnwrapMessage(msg.Message)
+		fn(ctx, msg)
+	})
+}
+
+// WrapMessage wraps the message in an envelope carrying the carrier.
+// It returns the message unchanged if the carrier is empty.
+func WrapMessage(message string, carrier propagation.MapCarrier) string {
+	if len(carrier) == 0 {
+		return message
+	}
+	values := make(url.Values, len(carrier))
+	for k, v := range carrier {
+		values.Set(k, v)
+	}
+	header := values.Encode()
+	sb := strings.Builder{}
+	sb.Grow(len(envelopePrefix) + len(header) + 1 + len(message))
+	sb.WriteString(envelopePrefix)
+	sb.WriteString(header)
+	sb.WriteByte(0)
+	sb.WriteString(message)
+	return sb.String()
+}
+
+// UnwrapMessage removes the envelope added by WrapMessage and returns the original message and its carrier.
+// It returns the message unchanged and a nil carrier if there is no envelope.
+func UnwrapMessage(message string) (string, propagation.MapCarrier) {
+	if !strings.HasPrefix(message, envelopePrefix) {
+		return message, nil
+	}
+	rest := message[len(envelopePrefix):]
+	i := strings.IndexByte(rest, 0)
+	if i < 0 {
+		return message, nil
+	}
+	values, err := url.ParseQuery(rest[:i])
+	if err != nil {
+		return message, nil
+	}
+	carrier := make(propagation.MapCarrier, len(values))
+	for k := range values {
+		carrier[k] = values.Get(k)
+	}
+	return rest[i+1:], carrier
+}
+
+type messagePropagation struct {
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+	channels   map[string]bool
+	streams    map[string]bool
+}
+
+// inject rewrites PUBLISH, SPUBLISH and XADD commands to carry the trace context of the ctx.
+func (m messagePropagation) inject(ctx context.Context, b valkey.Builder, cmd valkey.Completed) (valkey.Completed, bool) {
+	if m.propagator == nil {
+		return cmd, false
+	}
+	commands := cmd.Commands()
+	if len(commands) < 3 {
+		return cmd, false
+	}
+	switch commands[0] {
+	case "PUBLISH", "SPUBLISH":
+		if !m.channels[commands[1]] {
+			return cmd, false
+		}
+	case "XADD":
+		if !m.streams[commands[1]] {
+			return cmd, false
+		}
+	default:
+		return cmd, false
+	}
+	carrier := propagation.MapCarrier{}
+	m.propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return cmd, false
+	}
+	switch commands[0] {
+	case "PUBLISH":
+		return b.Arbitrary("PUBLISH").Args(commands[1], WrapMessage(commands[2], carrier)).Build(), true
+	case "SPUBLISH":
+		return b.Arbitrary("SPUBLISH").Keys(commands[1]).Args(WrapMessage(commands[2], carrier)).Build(), true
+	default:
+		keys := carrier.Keys()
+		sort.Strings(keys)
+		args := make([]string, 0, len(commands)-2+len(keys)*2)
+		args = append(args, commands[2:]...)
+		for _, k := range keys {
+			args = append(args, k, carrier[k])
+		}
+		return b.Arbitrary("XADD").Keys(commands[1]).Args(args...).Build(), true
+	}
+}
+
+// injectMulti is like inject but only copies the multi if any command is rewritten.
+func (m messagePropagation) injectMulti(ctx context.Context, b valkey.Builder, multi []valkey.Completed) []valkey.Completed {
+	if m.propagator == nil {
+		return multi
+	}
+	var injected []valkey.Completed
+	for i, cmd := range multi {
+		if c, ok := m.inject(ctx, b, cmd); ok {
+			if injected == nil {
+				injected = make([]valkey.Completed, len(multi))
+				copy(injected, multi)
+			}
+			injected[i] = c
+		}
+	}
+	if injected == nil {
+		return multi
+	}
+	return injected
+}
+
+// receive wraps the fn to unwrap each message and process it in a consumer span linked to the publisher.
+func (m messagePropagation) receive(ctx context.Context, fn func(msg valkey.PubSubMessage)) func(msg valkey.PubSubMessage) {
+	if m.propagator == nil || fn == nil {
+		return fn
+	}
+	return m.process(ctx, func(_ context.Context, msg valkey.PubSubMessage) { fn(msg) })
+}
+
+// process is like receive, but passes the context of the consumer span to the fn.
+func (m messagePropagation) process(ctx context.Context, fn func(ctx context.Context, msg valkey.PubSubMessage)) func(msg valkey.PubSubMessage) {
+	return func(msg valkey.PubSubMessage) {
+		message, carrier := UnwrapMessage(msg.Message)
+		msg.Message = message
+		if carrier == nil || m.propagator == nil {
+			fn(ctx, msg)
+			return
+		}
+		cctx, span := m.consume(ctx, msg.Channel, carrier)
+		fn(cctx, msg)
+		if span != nil {
+			span.End()
+		}
+	}
+}
+
+// streamReads returns the command names of the multi if it contains XREAD or XREADGROUP, or nil.
+// They must be taken before sending the commands, since the commands are recycled afterward.
+func (m messagePropagation) streamReads(multi []valkey.Completed) (ops []string) {
+	if m.propagator == nil {
+		return nil
+	}
+	for i, cmd := range multi {
+		switch op := first(cmd.Commands()); op {
+		case "XREAD", "XREADGROUP":
+			if ops == nil {
+				ops = make([]string, len(multi))
+			}
+			ops[i] = op
+		}
+	}
+	return ops
+}
+
+// linkStreamsMulti is like linkStreams but for the replies of a multi.
+func (m messagePropagation) linkStreamsMulti(span trace.Span, ops []string, resps []valkey.ValkeyResult) {
+	for i, op := range ops {
+		m.linkStreams(span, op, resps[i])
+	}
+}
+
+// linkStreams adds a link to the producer of each XREAD or XREADGROUP entry that carries a trace context to the span.
+func (m messagePropagation) linkStreams(span trace.Span, op string, resp valkey.ValkeyResult) {
+	if m.propagator == nil || resp.Error() != nil {
+		return
+	}
+	switch op {
+	case "XREAD", "XREADGROUP":
+	default:
+		return
+	}
+	streams, err := resp.AsXRead()
+	if err != nil {
+		return
+	}
+	for stream, entries := range streams {
+		for _, entry := range entries {
+			if entry.FieldValues == nil {
+				continue
+			}
+			if producer := m.extract(propagation.MapCarrier(entry.FieldValues)); producer.IsValid() {
+				span.AddLink(trace.Link{
+					SpanContext: producer,
+					Attributes:  []attribute.KeyValue{msgDest.String(stream), msgID.String(entry.ID)},
+				})
+			}
+		}
+	}
+}
+
+// consume starts a consumer span as a child of the ctx, linked to the producer span found in the carrier.
+// It returns a nil span if the carrier has no valid span context.
+func (m messagePropagation) consume(ctx context.Context, dest string, carrier propagation.MapCarrier) (context.Context, trace.Span) {
+	producer := m.extract(carrier)
+	if !producer.IsValid() {
+		return ctx, nil
+	}
+	return m.tracer.Start(ctx, "process "+dest, consumerKind,
+		trace.WithLinks(trace.Link{SpanContext: producer}),
+		trace.WithAttributes(dbattr, msgSystem, msgOperation, msgDest.String(dest)),
+	)
+}
+
+func (m messagePropagation) extract(carrier propagation.MapCarrier) trace.SpanContext {
+	return trace.SpanContextFromContext(m.propagator.Extract(context.Background(), carrier))
+}
@@ -0,0 +1,203 @@
+package valkeyotel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/internal/cmds"
+)
+
+func TestWrapMessage(t *testing.T) {
+	if m := WrapMessage("hello", nil); m != "hello" {
+		t.Fatalf("unexpected message %q", m)
+	}
+	carrier := propagation.MapCarrier{
+		"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"tracestate":  "a=1,b=2&c",
+	}
+	wrapped := WrapMessage("hello\x00world", carrier)
+	message, got := UnwrapMessage(wrapped)
+	if message != "hello\x00world" {
+		t.Fatalf("unexpected message %q", message)
+	}
+	if len(got) != 2 || got["traceparent"] != carrier["traceparent"] || got["tracestate"] != carrier["tracestate"] {
+		t.Fatalf("unexpected carrier %v", got)
+	}
+	for _, m := range []string{"hello", "\x00otel\x00traceparent=1", ""} {
+		if message, carrier := UnwrapMessage(m); message != m || carrier != nil {
+			t.Fatalf("unexpected unwrap %q %v", message, carrier)
+		}
+	}
+}
+
+func TestMessagePropagationDisabled(t *testing.T) {
+	m := messagePropagation{}
+	fn := func(valkey.PubSubMessage) {}
+	if m.receive(context.Background(), nil) != nil {
+		t.Fatalf("unexpected fn")
+	}
+	if m.receive(context.Background(), fn) == nil {
+		t.Fatalf("unexpected nil fn")
+	}
+	if multi := m.injectMulti(context.Background(), valkey.Builder{}, nil); multi != nil {
+		t.Fatalf("unexpected multi %v", multi)
+	}
+}
+
+func TestMessagePropagationChannels(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	m := messagePropagation{
+		propagator: propagation.TraceContext{},
+		tracer:     trace.NewTracerProvider(trace.WithSyncer(exp)).Tracer("test"),
+		channels:   map[string]bool{"ch": true},
+	}
+	producer := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), producer)
+	b := valkey.Builder{}
+
+	if cmd, ok := m.inject(ctx, b, b.Publish().Channel("other").Message("hello").Build()); ok || cmd.Commands()[2] != "hello" {
+		t.Fatalf("unexpected inject %v %v", cmd.Commands(), ok)
+	}
+	cmd, ok := m.inject(ctx, b, b.Publish().Channel("ch").Message("hello").Build())
+	if !ok {
+		t.Fatalf("unexpected inject %v", cmd.Commands())
+	}
+
+	var consumer oteltrace.SpanContext
+	m.process(context.Background(), func(ctx context.Context, msg valkey.PubSubMessage) {
+		if msg.Message != "hello" {
+			t.Fatalf("unexpected message %q", msg.Message)
+		}
+		consumer = oteltrace.SpanContextFromContext(ctx)
+	})(valkey.PubSubMessage{Channel: "ch", Message: cmd.Commands()[2]})
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "process ch" || spans[0].SpanContext.SpanID() != consumer.SpanID() {
+		t.Fatalf("unexpected spans %v", spans.Snapshots())
+	}
+	if len(spans[0].Links) != 1 || spans[0].Links[0].SpanContext.SpanID() != producer.SpanID() {
+		t.Fatalf("consumer span is not linked to the producer: %v", spans[0].Links)
+	}
+}
+
+func TestMessagePropagationStreams(t *testing.T) {
+	m := messagePropagation{
+		propagator: propagation.TraceContext{},
+		streams:    map[string]bool{"s": true},
+	}
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+	}))
+	b := cmds.NewBuilder(cmds.NoSlot)
+
+	if cmd, ok := m.inject(ctx, b, b.Xadd().Key("other").Id("*").FieldValue().FieldValue("f", "v").Build()); ok || len(cmd.Commands()) != 5 {
+		t.Fatalf("unexpected inject %v %v", cmd.Commands(), ok)
+	}
+	cmd, ok := m.inject(ctx, b, b.Xadd().Key("s").Id("*").FieldValue().FieldValue("f", "v").Build())
+	if commands := cmd.Commands(); !ok || len(commands) != 7 || commands[1] != "s" || commands[5] != "traceparent" {
+		t.Fatalf("unexpected inject %v %v", commands, ok)
+	}
+}
+
+func TestMessagePropagation(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tracerProvider := trace.NewTracerProvider(trace.WithSyncer(exp))
+
+	client, err := NewClient(
+		valkey.ClientOption{InitAddress: []string{"127.0.0.1:6379"}},
+		WithTracerProvider(tracerProvider),
+		WithMessagePropagation(nil, "otel_ch"),
+		WithStreamPropagation("otel_stream"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("pubsub", func(t *testing.T) {
+		exp.Reset()
+		subscribed := make(chan struct{})
+		received := make(chan string, 1)
+		spans := make(chan oteltrace.SpanContext, 1)
+		rctx, rcancel := context.WithCancel(valkey.WithOnSubscriptionHook(ctx, func(s valkey.PubSubSubscription) {
+			if s.Kind == "subscribe" {
+				close(subscribed)
+			}
+		}))
+		done := make(chan error, 1)
+		go func() {
+			done <- Receive(rctx, client, client.B().Subscribe().Channel("otel_ch").Build(), func(ctx context.Context, msg valkey.PubSubMessage) {
+				spans <- oteltrace.SpanContextFromContext(ctx)
+				received <- msg.Message
+			})
+		}()
+		<-subscribed
+		if err := client.Do(ctx, client.B().Publish().Channel("otel_ch").Message("hello").Build()).Error(); err != nil {
+			t.Fatal(err)
+		}
+		if msg := <-received; msg != "hello" {
+			t.Fatalf("unexpected message %q", msg)
+		}
+		if sc := <-spans; !sc.IsValid() {
+			t.Fatalf("unexpected consumer span context %v", sc)
+		}
+		rcancel()
+		<-done
+		validateLinkedConsumerSpan(t, exp, "PUBLISH", "process otel_ch")
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		exp.Reset()
+		client.Do(ctx, client.B().Del().Key("otel_stream").Build())
+		if err := client.Do(ctx, client.B().Xadd().Key("otel_stream").Id("*").FieldValue().FieldValue("f", "v").Build()).Error(); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := client.Do(ctx, client.B().Xrange().Key("otel_stream").Start("-").End("+").Build()).AsXRange()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].FieldValues["f"] != "v" || entries[0].FieldValues["traceparent"] == "" {
+			t.Fatalf("unexpected entries %v", entries)
+		}
+		if err := client.Do(ctx, client.B().Xread().Count(1).Streams().Key("otel_stream").Id("0").Build()).Error(); err != nil {
+			t.Fatal(err)
+		}
+		validateLinkedConsumerSpan(t, exp, "XADD", "XREAD")
+	})
+}
+
+func validateLinkedConsumerSpan(t *testing.T, exp *tracetest.InMemoryExporter, producer, consumer string) {
+	t.Helper()
+	var p, c *tracetest.SpanStub
+	spans := exp.GetSpans()
+	for i := range spans {
+		switch spans[i].Name {
+		case producer:
+			p = &spans[i]
+		case consumer:
+			c = &spans[i]
+		}
+	}
+	if p == nil || c == nil {
+		t.Fatalf("spans %q and %q not found in %v", producer, consumer, spans.Snapshots())
+	}
+	if len(c.Links) != 1 || c.Links[0].SpanContext.SpanID() != p.SpanContext.SpanID() || c.Links[0].SpanContext.TraceID() != p.SpanContext.TraceID() {
+		t.Fatalf("consumer span is not linked to the producer: %v", c.Links)
+	}
+}
@@ -18,6 +18,13 @@ var (
 	kind   = trace.WithSpanKind(trace.SpanKindClient)
 	dbattr = attribute.String("db.system", "valkey")
 	dbstmt = attribute.Key("db.statement")
+
+	serverAddr    = attribute.Key("server.address")
+	nodes         = attribute.Key("db.valkey.nodes")
+	redirectCount = attribute.Key("db.valkey.redirect_count")
+	retryCount    = attribute.Key("db.valkey.retry_count")
+	cacheHit      = attribute.Key("db.valkey.cache_hit")
+	cacheHitCount = attribute.Key("db.valkey.cache_hit_count")
 )
 
 var _ valkey.Client = (*otelclient)(nil)
@@ -99,8 +106,12 @@ type otelclient struct {
 	dbStmtFunc      StatementFunc
 	addOpts         []metric.AddOption
 	recordOpts      []metric.RecordOption
+	metricAttrs     []attribute.KeyValue
 	histogramOption HistogramOption
+	withInternals   bool                // set by WithInternalMetrics
+	registration    metric.Registration // of the internal metrics, if any
 	commandMetrics
+	messagePropagation
 }
 
 func (o *otelclient) B() valkey.Builder {
@@ -115,7 +126,11 @@ func (o *otelclient) Do(ctx context.Context, cmd valkey.Completed) (resp valkey.
 		span.SetAttributes(dbstmt.String(o.dbStmtFunc(cmd.Commands())))
 	}
 
+	cmd, _ = o.inject(ctx, o.client.B(), cmd)
+	ctx, ct := commandTrace(ctx, span)
 	resp = o.client.Do(ctx, cmd)
+	setCommandTrace(span, ct)
+	o.linkStreams(span, op, resp)
 	o.end(span, resp.Error())
 	o.recordError(ctx, op, resp.Error())
 	return
@@ -125,7 +140,12 @@ func (o *otelclient) DoMulti(ctx context.Context, multi ...valkey.Completed) (re
 	op := multiFirst(multi)
 	defer o.recordDuration(ctx, op, time.Now())
 	ctx, span := o.start(ctx, op, multiSum(multi))
+	multi = o.injectMulti(ctx, o.client.B(), multi)
+	reads := o.streamReads(multi)
+	ctx, ct := commandTrace(ctx, span)
 	resp = o.client.DoMulti(ctx, multi...)
+	setCommandTrace(span, ct)
+	o.linkStreamsMulti(span, reads, resp)
 	err := firstError(resp)
 	o.end(span, err)
 	o.recordError(ctx, op, err)
@@ -164,7 +184,12 @@ func (o *otelclient) DoCache(ctx context.Context, cmd valkey.Cacheable, ttl time
 		span.SetAttributes(dbstmt.String(o.dbStmtFunc(cmd.Commands())))
 	}
 
+	ctx, ct := commandTrace(ctx, span)
 	resp = o.client.DoCache(ctx, cmd, ttl)
+	setCommandTrace(span, ct)
+	if ct != nil {
+		span.SetAttributes(cacheHit.Bool(ct.CacheHits() > 0))
+	}
 	if resp.NonValkeyError() == nil {
 		if resp.IsCacheHit() {
 			o.cscHits.Add(ctx, 1, o.addOpts...)
@@ -181,7 +206,12 @@ func (o *otelclient) DoMultiCache(ctx context.Context, multi ...valkey.Cacheable
 	op := multiCacheableFirst(multi)
 	defer o.recordDuration(ctx, op, time.Now())
 	ctx, span := o.start(ctx, op, multiCacheableSum(multi))
+	ctx, ct := commandTrace(ctx, span)
 	resps = o.client.DoMultiCache(ctx, multi...)
+	setCommandTrace(span, ct)
+	if ct != nil {
+		span.SetAttributes(cacheHitCount.Int(ct.CacheHits()))
+	}
 	for _, resp := range resps {
 		if resp.NonValkeyError() == nil {
 			if resp.IsCacheHit() {
@@ -200,11 +230,12 @@ func (o *otelclient) DoMultiCache(ctx context.Context, multi ...valkey.Cacheable
 func (o *otelclient) Dedicated(fn func(valkey.DedicatedClient) error) (err error) {
 	return o.client.Dedicated(func(client valkey.DedicatedClient) error {
 		return fn(&dedicated{
-			client:         client,
-			tAttrs:         o.tAttrs,
-			tracer:         o.tracer,
-			dbStmtFunc:     o.dbStmtFunc,
-			commandMetrics: o.commandMetrics,
+			client:             client,
+			tAttrs:             o.tAttrs,
+			tracer:             o.tracer,
+			dbStmtFunc:         o.dbStmtFunc,
+			commandMetrics:     o.commandMetrics,
+			messagePropagation: o.messagePropagation,
 		})
 	})
 }
@@ -212,11 +243,12 @@ func (o *otelclient) Dedicated(fn func(valkey.DedicatedClient) error) (err error
 func (o *otelclient) Dedicate() (valkey.DedicatedClient, func()) {
 	client, cancel := o.client.Dedicate()
 	return &dedicated{
-		client:         client,
-		tAttrs:         o.tAttrs,
-		tracer:         o.tracer,
-		dbStmtFunc:     o.dbStmtFunc,
-		commandMetrics: o.commandMetrics,
+		client:             client,
+		tAttrs:             o.tAttrs,
+		tracer:             o.tracer,
+		dbStmtFunc:         o.dbStmtFunc,
+		commandMetrics:     o.commandMetrics,
+		messagePropagation: o.messagePropagation,
 	}, cancel
 }
 
@@ -226,7 +258,18 @@ func (o *otelclient) Receive(ctx context.Context, subscribe valkey.Completed, fn
 		span.SetAttributes(dbstmt.String(o.dbStmtFunc(subscribe.Commands())))
 	}
 
-	err = o.client.Receive(ctx, subscribe, fn)
+	err = o.client.Receive(ctx, subscribe, o.receive(ctx, fn))
+	o.end(span, err)
+	return
+}
+
+func (o *otelclient) receiveContext(ctx context.Context, subscribe valkey.Completed, fn func(ctx context.Context, msg valkey.PubSubMessage)) (err error) {
+	ctx, span := o.start(ctx, first(subscribe.Commands()), sum(subscribe.Commands()))
+	if o.dbStmtFunc != nil {
+		span.SetAttributes(dbstmt.String(o.dbStmtFunc(subscribe.Commands())))
+	}
+
+	err = o.client.Receive(ctx, subscribe, o.process(ctx, fn))
 	o.end(span, err)
 	return
 }
@@ -235,19 +278,21 @@ func (o *otelclient) Nodes() map[string]valkey.Client {
 	nodes := o.client.Nodes()
 	for addr, client := range nodes {
 		nodes[addr] = &otelclient{
-			client:          client,
-			meterProvider:   o.meterProvider,
-			tracerProvider:  o.tracerProvider,
-			tracer:          o.tracer,
-			meter:           o.meter,
-			cscMiss:         o.cscMiss,
-			cscHits:         o.cscHits,
-			addOpts:         o.addOpts,
-			recordOpts:      o.recordOpts,
-			tAttrs:          o.tAttrs,
-			histogramOption: o.histogramOption,
-			dbStmtFunc:      o.dbStmtFunc,
-			commandMetrics:  o.commandMetrics,
+			client:             client,
+			meterProvider:      o.meterProvider,
+			tracerProvider:     o.tracerProvider,
+			tracer:             o.tracer,
+			meter:              o.meter,
+			cscMiss:            o.cscMiss,
+			cscHits:            o.cscHits,
+			addOpts:            o.addOpts,
+			recordOpts:         o.recordOpts,
+			metricAttrs:        o.metricAttrs,
+			tAttrs:             o.tAttrs,
+			histogramOption:    o.histogramOption,
+			dbStmtFunc:         o.dbStmtFunc,
+			commandMetrics:     o.commandMetrics,
+			messagePropagation: o.messagePropagation,
 		}
 	}
 	return nodes
@@ -259,6 +304,14 @@ func (o *otelclient) Mode() valkey.ClientMode {
 
 func (o *otelclient) Close() {
 	o.client.Close()
+	o.unregister()
+}
+
+// unregister stops the meter from observing the internal metrics, so that the closed client is no longer referenced by the meter.
+func (o *otelclient) unregister() {
+	if o.registration != nil {
+		o.registration.Unregister()
+	}
 }
 
 var _ valkey.DedicatedClient = (*dedicated)(nil)
@@ -269,6 +322,7 @@ type dedicated struct {
 	tAttrs     trace.SpanStartEventOption
 	dbStmtFunc StatementFunc
 	commandMetrics
+	messagePropagation
 }
 
 func (d *dedicated) B() valkey.Builder {
@@ -283,7 +337,9 @@ func (d *dedicated) Do(ctx context.Context, cmd valkey.Completed) (resp valkey.V
 		span.SetAttributes(dbstmt.String(d.dbStmtFunc(cmd.Commands())))
 	}
 
+	cmd, _ = d.inject(ctx, d.client.B(), cmd)
 	resp = d.client.Do(ctx, cmd)
+	d.linkStreams(span, op, resp)
 	d.end(span, resp.Error())
 	d.recordError(ctx, op, resp.Error())
 	return
@@ -293,7 +349,10 @@ func (d *dedicated) DoMulti(ctx context.Context, multi ...valkey.Completed) (res
 	op := multiFirst(multi)
 	defer d.recordDuration(ctx, op, time.Now())
 	ctx, span := d.start(ctx, op, multiSum(multi))
+	multi = d.injectMulti(ctx, d.client.B(), multi)
+	reads := d.streamReads(multi)
 	resp = d.client.DoMulti(ctx, multi...)
+	d.linkStreamsMulti(span, reads, resp)
 	err := firstError(resp)
 	d.end(span, err)
 	d.recordError(ctx, op, err)
@@ -306,12 +365,24 @@ func (d *dedicated) Receive(ctx context.Context, subscribe valkey.Completed, fn
 		span.SetAttributes(dbstmt.String(d.dbStmtFunc(subscribe.Commands())))
 	}
 
-	err = d.client.Receive(ctx, subscribe, fn)
+	err = d.client.Receive(ctx, subscribe, d.receive(ctx, fn))
+	d.end(span, err)
+	return
+}
+
+func (d *dedicated) receiveContext(ctx context.Context, subscribe valkey.Completed, fn func(ctx context.Context, msg valkey.PubSubMessage)) (err error) {
+	ctx, span := d.start(ctx, first(subscribe.Commands()), sum(subscribe.Commands()))
+	if d.dbStmtFunc != nil {
+		span.SetAttributes(dbstmt.String(d.dbStmtFunc(subscribe.Commands())))
+	}
+
+	err = d.client.Receive(ctx, subscribe, d.process(ctx, fn))
 	d.end(span, err)
 	return
 }
 
 func (d *dedicated) SetPubSubHooks(hooks valkey.PubSubHooks) <-chan error {
+	hooks.OnMessage = d.receive(context.Background(), hooks.OnMessage)
 	return d.client.SetPubSubHooks(hooks)
 }
 
@@ -424,6 +495,30 @@ func startSpan(tracer trace.Tracer, ctx context.Context, op string, size int, at
 	return tracer.Start(ctx, op, kind, attr(op, size), attrs)
 }
 
+// commandTrace attaches a valkey.CommandTrace to the ctx only if the span is recording.
+func commandTrace(ctx context.Context, span trace.Span) (context.Context, *valkey.CommandTrace) {
+	if !span.IsRecording() {
+		return ctx, nil
+	}
+	return valkey.WithCommandTrace(ctx)
+}
+
+// setCommandTrace sets the node address, redirects and retries recorded by the client to the span.
+func setCommandTrace(span trace.Span, ct *valkey.CommandTrace) {
+	if ct == nil {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, 4)
+	if addr := ct.Addr(); addr != "" {
+		attrs = append(attrs, serverAddr.String(addr))
+	}
+	if addrs := ct.Addrs(); len(addrs) > 1 {
+		attrs = append(attrs, nodes.StringSlice(addrs))
+	}
+	attrs = append(attrs, redirectCount.Int(ct.Redirects()), retryCount.Int(ct.Retries()))
+	span.SetAttributes(attrs...)
+}
+
 func endSpan(span trace.Span, err error) {
 	if err != nil && !valkey.IsValkeyNil(err) {
 		span.RecordError(err)
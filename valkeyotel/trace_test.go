@@ -63,6 +63,24 @@ func (m *mockMeter) Float64Histogram(name string, options ...metricapi.Float64Hi
 	return nil, nil
 }
 
+func (m *mockMeter) Int64Histogram(name string, options ...metricapi.Int64HistogramOption) (metricapi.Int64Histogram, error) {
+	if m.testName == name {
+		return nil, fmt.Errorf("%w: %s", errMocked, m.testName)
+	}
+	return nil, nil
+}
+
+func (m *mockMeter) Int64ObservableGauge(name string, options ...metricapi.Int64ObservableGaugeOption) (metricapi.Int64ObservableGauge, error) {
+	if m.testName == name {
+		return nil, fmt.Errorf("%w: %s", errMocked, m.testName)
+	}
+	return nil, nil
+}
+
+func (m *mockMeter) RegisterCallback(f metricapi.Callback, instruments ...metricapi.Observable) (metricapi.Registration, error) {
+	return nil, nil
+}
+
 func TestWithClientGlobalProvider(t *testing.T) {
 	client, err := valkey.NewClient(valkey.ClientOption{InitAddress: []string{"127.0.0.1:6379"}})
 	if err != nil {
@@ -293,6 +311,36 @@ func testWithClient(t *testing.T, client valkey.Client, exp *tracetest.InMemoryE
 	validateMetricHasNoAttribute(t, metrics, "valkey_command_duration_seconds", "operation")
 }
 
+func TestCommandTraceAttributes(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tracerProvider := trace.NewTracerProvider(trace.WithSyncer(exp))
+
+	client, err := NewClient(valkey.ClientOption{InitAddress: []string{"127.0.0.1:6379"}}, WithTracerProvider(tracerProvider))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	exp.Reset()
+
+	ctx := context.Background()
+	client.Do(ctx, client.B().Set().Key("key").Value("val").Build())
+	span := exp.GetSpans().Snapshots()[0]
+	validateSpanHasAttribute(t, span, "server.address", "127.0.0.1:6379")
+	validateSpanHasIntAttribute(t, span, "db.valkey.redirect_count", 0)
+	validateSpanHasIntAttribute(t, span, "db.valkey.retry_count", 0)
+	exp.Reset()
+
+	client.DoCache(ctx, client.B().Get().Key("key").Cache(), time.Minute)
+	client.DoCache(ctx, client.B().Get().Key("key").Cache(), time.Minute)
+	spans := exp.GetSpans().Snapshots()
+	validateSpanHasBoolAttribute(t, spans[0], "db.valkey.cache_hit", false)
+	validateSpanHasBoolAttribute(t, spans[1], "db.valkey.cache_hit", true)
+	exp.Reset()
+
+	client.DoMultiCache(ctx, valkey.CT(client.B().Get().Key("key").Cache(), time.Minute))
+	validateSpanHasIntAttribute(t, exp.GetSpans().Snapshots()[0], "db.valkey.cache_hit_count", 1)
+}
+
 func validateTrace(t *testing.T, exp *tracetest.InMemoryExporter, op string, code codes.Code) {
 	if name := exp.GetSpans().Snapshots()[0].Name(); name != op {
 		t.Fatalf("unexpected span name %v", name)
@@ -442,6 +490,24 @@ func validateSpanHasAttribute(t *testing.T, span trace.ReadOnlySpan, key, value
 	}
 }
 
+func validateSpanHasIntAttribute(t *testing.T, span trace.ReadOnlySpan, key string, value int64) {
+	t.Helper()
+	if !slices.ContainsFunc(span.Attributes(), func(attr attribute.KeyValue) bool {
+		return string(attr.Key) == key && attr.Value.AsInt64() == value
+	}) {
+		t.Fatalf("expected attribute '%s: %d' not found in span attributes", key, value)
+	}
+}
+
+func validateSpanHasBoolAttribute(t *testing.T, span trace.ReadOnlySpan, key string, value bool) {
+	t.Helper()
+	if !slices.ContainsFunc(span.Attributes(), func(attr attribute.KeyValue) bool {
+		return string(attr.Key) == key && attr.Value.Type() == attribute.BOOL && attr.Value.AsBool() == value
+	}) {
+		t.Fatalf("expected attribute '%s: %v' not found in span attributes", key, value)
+	}
+}
+
 func validateMetrics(t *testing.T, metrics metricdata.ResourceMetrics, name string, value int64) {
 	t.Helper()
 
@@ -0,0 +1,339 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	// countMinSketchCounterBits is the bit width of each counter of the Count-Min Sketch.
+	countMinSketchCounterBits = 32
+
+	countMinSketchIncrByMultiScript = `
+local depth = tonumber(ARGV[1])
+local numElements = (#ARGV - 1) / (depth + 1)
+local sketchKey = KEYS[1]
+local counterKey = KEYS[2]
+
+local result = {}
+local total = 0
+for i=0, numElements-1 do
+	local base = 2 + i * (depth + 1)
+	local increment = ARGV[base]
+	local args = {'BITFIELD', sketchKey, 'OVERFLOW', 'SAT'}
+	for j=1, depth do
+		table.insert(args, 'INCRBY')
+		table.insert(args, 'u32')
+		table.insert(args, ARGV[base+j])
+		table.insert(args, increment)
+	end
+
+	local counts = redis.call(unpack(args))
+	local minCount = counts[1]
+	for j=2, #counts do
+		if counts[j] < minCount then
+			minCount = counts[j]
+		end
+	end
+
+	table.insert(result, minCount)
+	total = total + tonumber(increment)
+end
+
+redis.call('INCRBY', counterKey, total)
+return result
+`
+
+	countMinSketchQueryMultiScript = `
+local depth = tonumber(ARGV[1])
+local numElements = (#ARGV - 1) / depth
+local sketchKey = KEYS[1]
+
+local result = {}
+for i=0, numElements-1 do
+	local args = {'BITFIELD', sketchKey}
+	for j=1, depth do
+		table.insert(args, 'GET')
+		table.insert(args, 'u32')
+		table.insert(args, ARGV[1 + i * depth + j])
+	end
+
+	local counts = redis.call(unpack(args))
+	local minCount = counts[1]
+	for j=2, #counts do
+		if counts[j] < minCount then
+			minCount = counts[j]
+		end
+	end
+
+	table.insert(result, minCount)
+end
+
+return result
+`
+
+	countMinSketchResetScript = `
+local sketchKey = KEYS[1]
+local counterKey = KEYS[2]
+
+redis.call('SET', sketchKey, "")
+redis.call('SET', counterKey, 0)
+
+return 1
+`
+)
+
+var (
+	ErrErrorRateOutOfRange   = errors.New("error rate must be in range (0, 1)")
+	ErrProbabilityOutOfRange = errors.New("probability must be in range (0, 1)")
+	ErrIncrementsMismatch    = errors.New("number of increments must be equal to the number of keys")
+)
+
+// CountMinSketch based on Valkey Bitmaps.
+// Each counter is a 32-bit unsigned integer saturated at its maximum value.
+// CountMinSketch uses a 128-bit murmur3 hash function.
+type CountMinSketch interface {
+	// IncrBy increases the count of an item by the increment and returns its estimated count.
+	IncrBy(ctx context.Context, key string, increment uint64) (uint64, error)
+
+	// IncrByMulti increases the counts of one or more items by the corresponding increments
+	// and returns their estimated counts.
+	// NOTE: If keys are too many, it can block the Valkey server for a long time.
+	IncrByMulti(ctx context.Context, keys []string, increments []uint64) ([]uint64, error)
+
+	// Query returns the estimated count of an item.
+	// The estimated count is never less than the real count, but it can be larger because of the hash collisions.
+	Query(ctx context.Context, key string) (uint64, error)
+
+	// QueryMulti returns the estimated counts of one or more items.
+	QueryMulti(ctx context.Context, keys []string) ([]uint64, error)
+
+	// Reset resets the Count-Min Sketch.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the Count-Min Sketch.
+	Delete(ctx context.Context) error
+
+	// Count returns the sum of all increments in the Count-Min Sketch.
+	Count(ctx context.Context) (uint64, error)
+}
+
+type countMinSketch struct {
+	client valkey.Client
+
+	incrByMultiScript *valkey.Lua
+	queryMultiScript  *valkey.Lua
+
+	// name is the name of the Count-Min Sketch.
+	// It is used as a key in the Valkey.
+	name string
+
+	// counter is the name of the counter.
+	counter string
+
+	depthString string
+
+	incrByMultiKeys []string
+	queryMultiKeys  []string
+
+	// width is the number of counters in each row.
+	width uint
+
+	// depth is the number of rows, which is also the number of hash functions to use.
+	depth uint
+}
+
+// NewCountMinSketch creates a new Count-Min Sketch.
+// The estimated count of an item exceeds its real count by at most errorRate * Count with the probability of 1 - probability.
+// NOTE: 'name:cms:c' is used as a counter-key in the Valkey and
+// 'name:cms' is used as a sketch key in the Valkey
+// to keep track of the sum of all increments for Count method.
+func NewCountMinSketch(
+	client valkey.Client,
+	name string,
+	errorRate float64,
+	probability float64,
+) (CountMinSketch, error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyName
+	}
+
+	width, depth, err := countMinSketchSize(errorRate, probability)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: https://redis.io/docs/reference/cluster-spec/#hash-tags
+	cmsName := "{" + name + "}:cms"
+	counterName := cmsName + ":c"
+	return &countMinSketch{
+		client:            client,
+		name:              cmsName,
+		counter:           counterName,
+		width:             width,
+		depth:             depth,
+		depthString:       strconv.FormatUint(uint64(depth), 10),
+		incrByMultiScript: valkey.NewLuaScript(countMinSketchIncrByMultiScript),
+		queryMultiScript:  valkey.NewLuaScript(countMinSketchQueryMultiScript),
+		incrByMultiKeys:   []string{cmsName, counterName},
+		queryMultiKeys:    []string{cmsName},
+	}, nil
+}
+
+// countMinSketchSize returns the width and the depth of a Count-Min Sketch from its error rate and probability.
+func countMinSketchSize(errorRate float64, probability float64) (uint, uint, error) {
+	if errorRate <= 0 || errorRate >= 1 {
+		return 0, 0, ErrErrorRateOutOfRange
+	}
+	if probability <= 0 || probability >= 1 {
+		return 0, 0, ErrProbabilityOutOfRange
+	}
+
+	width := uint(math.Ceil(math.E / errorRate))
+	depth := uint(math.Ceil(math.Log(1 / probability)))
+	if uint64(width)*uint64(depth)*countMinSketchCounterBits > maxSize {
+		return 0, 0, ErrBitsSizeTooLarge
+	}
+	return width, depth, nil
+}
+
+// countMinSketchOffsets appends the bit offsets of the counters of the key in each row to the buf.
+func countMinSketchOffsets(key string, width, depth uint, buf *[]byte, offsets []string) []string {
+	h1, h2 := hash([]byte(key))
+	for i := uint(0); i < depth; i++ {
+		offset := len(*buf)
+		bit := (uint64(i)*uint64(width) + index(h1, h2, i, uint64(width))) * countMinSketchCounterBits
+		*buf = strconv.AppendUint(*buf, bit, 10)
+		offsets = append(offsets, valkey.BinaryString((*buf)[offset:]))
+	}
+	return offsets
+}
+
+func (c *countMinSketch) IncrBy(ctx context.Context, key string, increment uint64) (uint64, error) {
+	counts, err := c.IncrByMulti(ctx, []string{key}, []uint64{increment})
+	if err != nil {
+		return 0, err
+	}
+
+	return counts[0], nil
+}
+
+func (c *countMinSketch) IncrByMulti(ctx context.Context, keys []string, increments []uint64) ([]uint64, error) {
+	if len(keys) != len(increments) {
+		return nil, ErrIncrementsMismatch
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*int(c.depth+1)*12)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*int(c.depth+1)+1)
+	args = append(args, c.depthString)
+	for i, key := range keys {
+		args = append(args, strconv.FormatUint(increments[i], 10))
+		args = countMinSketchOffsets(key, c.width, c.depth, &buf.s, args)
+	}
+
+	resp := c.incrByMultiScript.Exec(ctx, c.client, c.incrByMultiKeys, args)
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+
+	return toUint64s(resp)
+}
+
+func (c *countMinSketch) Query(ctx context.Context, key string) (uint64, error) {
+	counts, err := c.QueryMulti(ctx, []string{key})
+	if err != nil {
+		return 0, err
+	}
+
+	return counts[0], nil
+}
+
+func (c *countMinSketch) QueryMulti(ctx context.Context, keys []string) ([]uint64, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*int(c.depth)*12)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*int(c.depth)+1)
+	args = append(args, c.depthString)
+	for _, key := range keys {
+		args = countMinSketchOffsets(key, c.width, c.depth, &buf.s, args)
+	}
+
+	resp := c.queryMultiScript.Exec(ctx, c.client, c.queryMultiKeys, args)
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+
+	return toUint64s(resp)
+}
+
+func (c *countMinSketch) Reset(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Eval().
+			Script(countMinSketchResetScript).
+			Numkeys(2).
+			Key(c.name, c.counter).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *countMinSketch) Delete(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Del().
+			Key(c.name, c.counter).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *countMinSketch) Count(ctx context.Context) (uint64, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Get().
+			Key(c.counter).
+			Build(),
+	)
+	count, err := resp.AsUint64()
+	if err != nil {
+		if valkey.IsValkeyNil(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func toUint64s(resp valkey.ValkeyResult) ([]uint64, error) {
+	arr, err := resp.ToArray()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]uint64, len(arr))
+	for i, el := range arr {
+		if result[i], err = el.AsUint64(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,289 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNewCountMinSketch(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cms, err := NewCountMinSketch(client, "test", 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cms == nil {
+		t.Error("Count-Min Sketch is nil")
+	}
+	if cms.(*countMinSketch).client != client {
+		t.Error("Client is not equal")
+	}
+	if cms.(*countMinSketch).name != "{test}:cms" {
+		t.Error("Name is not {test}:cms")
+	}
+	if cms.(*countMinSketch).counter != "{test}:cms:c" {
+		t.Error("Counter is not {test}:cms:c")
+	}
+	if cms.(*countMinSketch).width != 272 {
+		t.Error("Width is not 272")
+	}
+	if cms.(*countMinSketch).depth != 5 {
+		t.Error("Depth is not 5")
+	}
+}
+
+func TestNewCountMinSketchError(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := NewCountMinSketch(client, "", 0.01, 0.01)
+		if !errors.Is(err, ErrEmptyName) {
+			t.Error("Error is not ErrEmptyName")
+		}
+	})
+
+	t.Run("ErrorRateOutOfRange", func(t *testing.T) {
+		for _, errorRate := range []float64{-0.1, 0, 1, 1.1} {
+			_, err := NewCountMinSketch(client, "test", errorRate, 0.01)
+			if !errors.Is(err, ErrErrorRateOutOfRange) {
+				t.Errorf("Error is not ErrErrorRateOutOfRange for %v", errorRate)
+			}
+		}
+	})
+
+	t.Run("ProbabilityOutOfRange", func(t *testing.T) {
+		for _, probability := range []float64{-0.1, 0, 1, 1.1} {
+			_, err := NewCountMinSketch(client, "test", 0.01, probability)
+			if !errors.Is(err, ErrProbabilityOutOfRange) {
+				t.Errorf("Error is not ErrProbabilityOutOfRange for %v", probability)
+			}
+		}
+	})
+
+	t.Run("BitsSizeTooLarge", func(t *testing.T) {
+		_, err := NewCountMinSketch(client, "test", 0.0000001, 0.0000001)
+		if !errors.Is(err, ErrBitsSizeTooLarge) {
+			t.Error("Error is not ErrBitsSizeTooLarge")
+		}
+	})
+}
+
+func TestCountMinSketchIncrBy(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cms, err := NewCountMinSketch(client, "test", 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	count, err := cms.IncrBy(ctx, "hello", 3)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 3 {
+		t.Errorf("Count is not 3: %d", count)
+	}
+
+	count, err = cms.IncrBy(ctx, "hello", 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 5 {
+		t.Errorf("Count is not 5: %d", count)
+	}
+
+	count, err = cms.Query(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 5 {
+		t.Errorf("Count is not 5: %d", count)
+	}
+
+	count, err = cms.Query(ctx, "world")
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Errorf("Count is not 0: %d", count)
+	}
+
+	total, err := cms.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if total != 5 {
+		t.Errorf("Total count is not 5: %d", total)
+	}
+}
+
+func TestCountMinSketchIncrByMulti(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cms, err := NewCountMinSketch(client, "test", 0.001, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 100)
+	increments := make([]uint64, 100)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+		increments[i] = uint64(i + 1)
+	}
+
+	counts, err := cms.IncrByMulti(ctx, keys, increments)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(counts) != len(keys) {
+		t.Errorf("Counts length is not %d: %d", len(keys), len(counts))
+	}
+
+	counts, err = cms.QueryMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+	for i, count := range counts {
+		// NOTE: The estimated count is never less than the real count.
+		if count < increments[i] {
+			t.Errorf("Count of %s is less than %d: %d", keys[i], increments[i], count)
+		}
+	}
+
+	total, err := cms.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if total != 5050 {
+		t.Errorf("Total count is not 5050: %d", total)
+	}
+
+	_, err = cms.IncrByMulti(ctx, keys, increments[:1])
+	if !errors.Is(err, ErrIncrementsMismatch) {
+		t.Error("Error is not ErrIncrementsMismatch")
+	}
+}
+
+func TestCountMinSketchReset(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cms, err := NewCountMinSketch(client, "test", 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	_, err = cms.IncrBy(ctx, "hello", 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = cms.Reset(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	count, err := cms.Query(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Errorf("Count is not 0: %d", count)
+	}
+
+	total, err := cms.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if total != 0 {
+		t.Errorf("Total count is not 0: %d", total)
+	}
+}
+
+func TestCountMinSketchDelete(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cms, err := NewCountMinSketch(client, "test", 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	_, err = cms.IncrBy(ctx, "hello", 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = cms.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := client.Do(ctx, client.B().Exists().Key("{test}:cms", "{test}:cms:c").Build()).AsInt64()
+	if err != nil {
+		t.Error(err)
+	}
+	if exists != 0 {
+		t.Error("Count-Min Sketch is not deleted")
+	}
+}
@@ -0,0 +1,488 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	// cuckooFilterBucketSize is the number of fingerprints in each bucket.
+	cuckooFilterBucketSize = 4
+
+	// cuckooFilterFingerprintBits is the bit width of each fingerprint.
+	cuckooFilterFingerprintBits = 16
+
+	// cuckooFilterMaxKicks is the maximum number of relocations to insert an item.
+	cuckooFilterMaxKicks = 500
+
+	// cuckooFilterLoadFactor is the maximum load factor of buckets with 4 fingerprints.
+	cuckooFilterLoadFactor = 0.95
+
+	// cuckooFilterAltMultiplier is used to compute the alternate bucket from a fingerprint.
+	// It must be kept in sync with the alt function of the Lua scripts.
+	cuckooFilterAltMultiplier = 1540483477
+
+	cuckooFilterLuaFunctions = `
+local bucketSize = tonumber(ARGV[1])
+local numBuckets = tonumber(ARGV[2])
+local filterKey = KEYS[1]
+
+local function slots(i)
+	local args = {'BITFIELD', filterKey}
+	for j=0, bucketSize-1 do
+		table.insert(args, 'GET')
+		table.insert(args, 'u16')
+		table.insert(args, (i * bucketSize + j) * 16)
+	end
+	return redis.call(unpack(args))
+end
+
+local function set(i, j, fp)
+	return redis.call('BITFIELD', filterKey, 'SET', 'u16', (i * bucketSize + j) * 16, fp)[1]
+end
+
+local function insert(i, fp)
+	local s = slots(i)
+	for j=1, bucketSize do
+		if s[j] == 0 then
+			set(i, j-1, fp)
+			return true
+		end
+	end
+	return false
+end
+
+local function remove(i, fp)
+	local s = slots(i)
+	for j=1, bucketSize do
+		if s[j] == fp then
+			set(i, j-1, 0)
+			return true
+		end
+	end
+	return false
+end
+
+local function alt(i, fp)
+	return bit.bxor(i, (fp * 1540483477) % numBuckets)
+end
+`
+
+	cuckooFilterAddMultiScript = cuckooFilterLuaFunctions + `
+local maxKicks = tonumber(ARGV[3])
+local numElements = (#ARGV - 3) / 3
+local counterKey = KEYS[2]
+
+local result = {}
+local inserted = 0
+for k=0, numElements-1 do
+	local i1 = tonumber(ARGV[4 + k * 3])
+	local i2 = tonumber(ARGV[5 + k * 3])
+	local fp = tonumber(ARGV[6 + k * 3])
+
+	local ok = insert(i1, fp) or insert(i2, fp)
+	if not ok then
+		local i = i2
+		if math.random(2) == 1 then
+			i = i1
+		end
+
+		local swaps = {}
+		for n=1, maxKicks do
+			local j = math.random(bucketSize) - 1
+			local victim = set(i, j, fp)
+			table.insert(swaps, {i, j, victim})
+
+			fp = victim
+			i = alt(i, fp)
+			if insert(i, fp) then
+				ok = true
+				break
+			end
+		end
+
+		-- NOTE: undo the relocations, so that no fingerprint is lost when the filter is full.
+		if not ok then
+			for n=#swaps, 1, -1 do
+				set(swaps[n][1], swaps[n][2], swaps[n][3])
+			end
+		end
+	end
+
+	if ok then
+		inserted = inserted + 1
+		table.insert(result, 1)
+	else
+		table.insert(result, 0)
+	end
+end
+
+redis.call('INCRBY', counterKey, inserted)
+return result
+`
+
+	cuckooFilterExistsMultiScript = `
+local bucketSize = tonumber(ARGV[1])
+local numElements = (#ARGV - 1) / 3
+local filterKey = KEYS[1]
+
+local result = {}
+for k=0, numElements-1 do
+	local fp = tonumber(ARGV[4 + k * 3])
+	local args = {'BITFIELD', filterKey}
+	for b=2, 3 do
+		local i = tonumber(ARGV[b + k * 3])
+		for j=0, bucketSize-1 do
+			table.insert(args, 'GET')
+			table.insert(args, 'u16')
+			table.insert(args, (i * bucketSize + j) * 16)
+		end
+	end
+
+	local s = redis.call(unpack(args))
+	local found = false
+	for j=1, #s do
+		if s[j] == fp then
+			found = true
+			break
+		end
+	end
+	table.insert(result, found)
+end
+
+return result
+`
+
+	cuckooFilterRemoveMultiScript = cuckooFilterLuaFunctions + `
+local numElements = (#ARGV - 2) / 3
+local counterKey = KEYS[2]
+
+local result = {}
+local removed = 0
+for k=0, numElements-1 do
+	local i1 = tonumber(ARGV[3 + k * 3])
+	local i2 = tonumber(ARGV[4 + k * 3])
+	local fp = tonumber(ARGV[5 + k * 3])
+
+	if remove(i1, fp) or remove(i2, fp) then
+		removed = removed + 1
+		table.insert(result, true)
+	else
+		table.insert(result, false)
+	end
+end
+
+if removed > 0 then
+	redis.call('DECRBY', counterKey, removed)
+end
+return result
+`
+
+	cuckooFilterResetScript = `
+local filterKey = KEYS[1]
+local counterKey = KEYS[2]
+
+redis.call('SET', filterKey, "")
+redis.call('SET', counterKey, 0)
+
+return 1
+`
+)
+
+var (
+	ErrCapacityZero     = errors.New("capacity cannot be zero")
+	ErrCuckooFilterFull = errors.New("cuckoo filter is full")
+)
+
+// CuckooFilter based on Valkey Bitmaps.
+// Each bucket holds 4 fingerprints of 16 bits, which gives a false positive rate of about 0.012%.
+// Unlike BloomFilter, items can be removed from a CuckooFilter.
+// CuckooFilter uses a 128-bit murmur3 hash function.
+type CuckooFilter interface {
+	// Add adds an item to the Cuckoo filter.
+	// It returns ErrCuckooFilterFull if there is no room for the item.
+	// NOTE: Adding the same item more than once stores multiple fingerprints of it,
+	// so it should be removed as many times as it is added.
+	Add(ctx context.Context, key string) error
+
+	// AddMulti adds one or more items to the Cuckoo filter.
+	// It returns ErrCuckooFilterFull if there is no room for any of the items,
+	// but the other items are still added.
+	// NOTE: If keys are too many, it can block the Valkey server for a long time.
+	AddMulti(ctx context.Context, keys []string) error
+
+	// Exists checks if an item is in the Cuckoo filter.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// ExistsMulti checks if one or more items are in the Cuckoo filter.
+	// Returns a slice of bool values where each bool indicates whether the corresponding key was found.
+	ExistsMulti(ctx context.Context, keys []string) ([]bool, error)
+
+	// Remove removes an item from the Cuckoo filter.
+	// It returns false if the item is not in the Cuckoo filter.
+	// NOTE: Removing an item which was never added can remove another item sharing the same fingerprint.
+	Remove(ctx context.Context, key string) (bool, error)
+
+	// RemoveMulti removes one or more items from the Cuckoo filter.
+	// Returns a slice of bool values where each bool indicates whether the corresponding key was removed.
+	// NOTE: If keys are too many, it can block the Valkey server for a long time.
+	RemoveMulti(ctx context.Context, keys []string) ([]bool, error)
+
+	// Reset resets the Cuckoo filter.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the Cuckoo filter.
+	Delete(ctx context.Context) error
+
+	// Count returns count of items in Cuckoo filter.
+	Count(ctx context.Context) (uint64, error)
+}
+
+type cuckooFilter struct {
+	client valkey.Client
+
+	addMultiScript *valkey.Lua
+
+	existsMultiScript *valkey.Lua
+
+	removeMultiScript *valkey.Lua
+
+	// name is the name of the Cuckoo filter.
+	// It is used as a key in the Valkey.
+	name string
+
+	// counter is the name of the counter.
+	counter string
+
+	bucketSizeString string
+	numBucketsString string
+	maxKicksString   string
+
+	keys []string
+
+	// numBuckets is the number of buckets, which is a power of two.
+	numBuckets uint64
+}
+
+// NewCuckooFilter creates a new Cuckoo filter which can hold at least capacity items.
+// NOTE: 'name:cf:c' is used as a counter-key in the Valkey and
+// 'name:cf' is used as a filter key in the Valkey
+// to keep track of the number of items in the Cuckoo filter for Count method.
+func NewCuckooFilter(
+	client valkey.Client,
+	name string,
+	capacity uint,
+) (CuckooFilter, error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyName
+	}
+
+	if capacity == 0 {
+		return nil, ErrCapacityZero
+	}
+
+	numBuckets, err := cuckooFilterNumBuckets(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: https://redis.io/docs/reference/cluster-spec/#hash-tags
+	filterName := "{" + name + "}:cf"
+	counterName := filterName + ":c"
+	return &cuckooFilter{
+		client:            client,
+		name:              filterName,
+		counter:           counterName,
+		numBuckets:        numBuckets,
+		bucketSizeString:  strconv.Itoa(cuckooFilterBucketSize),
+		numBucketsString:  strconv.FormatUint(numBuckets, 10),
+		maxKicksString:    strconv.Itoa(cuckooFilterMaxKicks),
+		addMultiScript:    valkey.NewLuaScript(cuckooFilterAddMultiScript),
+		existsMultiScript: valkey.NewLuaScript(cuckooFilterExistsMultiScript),
+		removeMultiScript: valkey.NewLuaScript(cuckooFilterRemoveMultiScript),
+		keys:              []string{filterName, counterName},
+	}, nil
+}
+
+// cuckooFilterNumBuckets returns the number of buckets, rounded up to a power of two,
+// so that the alternate bucket can be computed by XOR in both directions.
+func cuckooFilterNumBuckets(capacity uint) (uint64, error) {
+	numBuckets := uint64(math.Ceil(float64(capacity) / cuckooFilterBucketSize / cuckooFilterLoadFactor))
+	if numBuckets > 1 {
+		numBuckets = 1 << bits.Len64(numBuckets-1)
+	}
+	if numBuckets*cuckooFilterBucketSize*cuckooFilterFingerprintBits > maxSize {
+		return 0, ErrBitsSizeTooLarge
+	}
+	return numBuckets, nil
+}
+
+// cuckooFilterIndexes returns the two candidate buckets and the fingerprint of the key.
+func cuckooFilterIndexes(key string, numBuckets uint64) (uint64, uint64, uint64) {
+	h1, h2 := hash([]byte(key))
+	fp := h2%(1<<cuckooFilterFingerprintBits-1) + 1
+	i1 := h1 & (numBuckets - 1)
+	i2 := i1 ^ (fp * cuckooFilterAltMultiplier % numBuckets)
+	return i1, i2, fp
+}
+
+func (c *cuckooFilter) indexes(keys []string, buf *[]byte, args []string) []string {
+	for _, key := range keys {
+		i1, i2, fp := cuckooFilterIndexes(key, c.numBuckets)
+		for _, v := range [3]uint64{i1, i2, fp} {
+			offset := len(*buf)
+			*buf = strconv.AppendUint(*buf, v, 10)
+			args = append(args, valkey.BinaryString((*buf)[offset:]))
+		}
+	}
+	return args
+}
+
+func (c *cuckooFilter) Add(ctx context.Context, key string) error {
+	return c.AddMulti(ctx, []string{key})
+}
+
+func (c *cuckooFilter) AddMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*3*8)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*3+3)
+	args = append(args, c.bucketSizeString, c.numBucketsString, c.maxKicksString)
+	args = c.indexes(keys, &buf.s, args)
+
+	resp := c.addMultiScript.Exec(ctx, c.client, c.keys, args)
+	arr, err := resp.ToArray()
+	if err != nil {
+		return err
+	}
+
+	for _, el := range arr {
+		inserted, err := el.AsBool()
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			return ErrCuckooFilterFull
+		}
+	}
+	return nil
+}
+
+func (c *cuckooFilter) Exists(ctx context.Context, key string) (bool, error) {
+	exists, err := c.ExistsMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+
+	return exists[0], nil
+}
+
+func (c *cuckooFilter) ExistsMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*3*8)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*3+1)
+	args = append(args, c.bucketSizeString)
+	args = c.indexes(keys, &buf.s, args)
+
+	resp := c.existsMultiScript.Exec(ctx, c.client, c.keys[:1], args)
+	return toBools(resp)
+}
+
+func (c *cuckooFilter) Remove(ctx context.Context, key string) (bool, error) {
+	removed, err := c.RemoveMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+
+	return removed[0], nil
+}
+
+func (c *cuckooFilter) RemoveMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*3*8)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*3+2)
+	args = append(args, c.bucketSizeString, c.numBucketsString)
+	args = c.indexes(keys, &buf.s, args)
+
+	resp := c.removeMultiScript.Exec(ctx, c.client, c.keys, args)
+	return toBools(resp)
+}
+
+func (c *cuckooFilter) Reset(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Eval().
+			Script(cuckooFilterResetScript).
+			Numkeys(2).
+			Key(c.name, c.counter).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *cuckooFilter) Delete(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Del().
+			Key(c.name, c.counter).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *cuckooFilter) Count(ctx context.Context) (uint64, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Get().
+			Key(c.counter).
+			Build(),
+	)
+	count, err := resp.AsUint64()
+	if err != nil {
+		if valkey.IsValkeyNil(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func toBools(resp valkey.ValkeyResult) ([]bool, error) {
+	arr, err := resp.ToArray()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(arr))
+	for i, el := range arr {
+		result[i], err = el.AsBool()
+		if err != nil && !valkey.IsValkeyNil(err) {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,303 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNewCuckooFilter(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cf, err := NewCuckooFilter(client, "test", 100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if cf == nil {
+		t.Error("Cuckoo filter is nil")
+	}
+	if cf.(*cuckooFilter).client != client {
+		t.Error("Client is not equal")
+	}
+	if cf.(*cuckooFilter).name != "{test}:cf" {
+		t.Error("Name is not {test}:cf")
+	}
+	if cf.(*cuckooFilter).counter != "{test}:cf:c" {
+		t.Error("Counter is not {test}:cf:c")
+	}
+	if cf.(*cuckooFilter).numBuckets != 32 {
+		t.Error("Number of buckets is not 32")
+	}
+}
+
+func TestNewCuckooFilterError(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := NewCuckooFilter(client, "", 100)
+		if !errors.Is(err, ErrEmptyName) {
+			t.Error("Error is not ErrEmptyName")
+		}
+	})
+
+	t.Run("CapacityZero", func(t *testing.T) {
+		_, err := NewCuckooFilter(client, "test", 0)
+		if !errors.Is(err, ErrCapacityZero) {
+			t.Error("Error is not ErrCapacityZero")
+		}
+	})
+
+	t.Run("BitsSizeTooLarge", func(t *testing.T) {
+		_, err := NewCuckooFilter(client, "test", 1<<30)
+		if !errors.Is(err, ErrBitsSizeTooLarge) {
+			t.Error("Error is not ErrBitsSizeTooLarge")
+		}
+	})
+}
+
+func TestCuckooFilterIndexes(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		i1, i2, fp := cuckooFilterIndexes(strconv.Itoa(i), 32)
+		if i1 >= 32 || i2 >= 32 {
+			t.Fatalf("Index is out of range: %d %d", i1, i2)
+		}
+		if fp == 0 || fp >= 1<<cuckooFilterFingerprintBits {
+			t.Fatalf("Fingerprint is out of range: %d", fp)
+		}
+		// NOTE: the alternate bucket of the alternate bucket is the original one.
+		if i1 != i2^(fp*cuckooFilterAltMultiplier%32) {
+			t.Fatalf("Alternate bucket is not symmetric: %d %d", i1, i2)
+		}
+	}
+}
+
+func TestCuckooFilterAdd(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cf, err := NewCuckooFilter(client, "test", 100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	err = cf.Add(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := cf.Exists(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	if !exists {
+		t.Error("Key hello does not exist")
+	}
+
+	exists, err = cf.Exists(ctx, "world")
+	if err != nil {
+		t.Error(err)
+	}
+	if exists {
+		t.Error("Key world exists")
+	}
+
+	count, err := cf.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Errorf("Count is not 1: %d", count)
+	}
+}
+
+func TestCuckooFilterAddMultiAndRemoveMulti(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cf, err := NewCuckooFilter(client, "test", 100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+	}
+
+	err = cf.AddMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := cf.ExistsMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+	for i, e := range exists {
+		if !e {
+			t.Errorf("Key %s does not exist", keys[i])
+		}
+	}
+
+	removed, err := cf.RemoveMulti(ctx, keys[:50])
+	if err != nil {
+		t.Error(err)
+	}
+	for i, r := range removed {
+		if !r {
+			t.Errorf("Key %s is not removed", keys[i])
+		}
+	}
+
+	exists, err = cf.ExistsMulti(ctx, keys[50:])
+	if err != nil {
+		t.Error(err)
+	}
+	for i, e := range exists {
+		if !e {
+			t.Errorf("Key %s does not exist after removal of others", keys[50+i])
+		}
+	}
+
+	count, err := cf.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 50 {
+		t.Errorf("Count is not 50: %d", count)
+	}
+
+	ok, err := cf.Remove(ctx, "not-added")
+	if err != nil {
+		t.Error(err)
+	}
+	if ok {
+		t.Error("Key not-added is removed")
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cf, err := NewCuckooFilter(client, "test", 4)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 20)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+	}
+
+	// NOTE: 2 buckets of 4 fingerprints cannot hold 20 items.
+	err = cf.AddMulti(ctx, keys)
+	if !errors.Is(err, ErrCuckooFilterFull) {
+		t.Error("Error is not ErrCuckooFilterFull")
+	}
+
+	count, err := cf.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count == 0 || count > 8 {
+		t.Errorf("Count is not in range (0, 8]: %d", count)
+	}
+}
+
+func TestCuckooFilterReset(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	cf, err := NewCuckooFilter(client, "test", 100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	err = cf.Add(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = cf.Reset(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := cf.Exists(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	if exists {
+		t.Error("Key hello exists")
+	}
+
+	count, err := cf.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Errorf("Count is not 0: %d", count)
+	}
+
+	err = cf.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
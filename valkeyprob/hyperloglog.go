@@ -0,0 +1,205 @@
+package valkeyprob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+var (
+	ErrWindowSizeLessThanOneMillisecond = errors.New("window size cannot be less than 1 millisecond")
+	ErrRetentionLessThanWindowSize      = errors.New("retention cannot be less than window size")
+)
+
+// HyperLogLog is a typed wrapper of Valkey HyperLogLogs which estimates
+// the number of distinct items added within a time range.
+// Items are added to the HyperLogLog of the current time window,
+// and the HyperLogLogs of the windows within a time range are merged when counting.
+// Items of type string and []byte are added as is, and others are encoded as JSON.
+type HyperLogLog[T any] interface {
+	// Add adds an item to the HyperLogLog of the current window.
+	// It returns true if the estimated count of the current window is changed.
+	Add(ctx context.Context, item T) (bool, error)
+
+	// AddMulti adds one or more items to the HyperLogLog of the current window.
+	// It returns true if the estimated count of the current window is changed.
+	AddMulti(ctx context.Context, items []T) (bool, error)
+
+	// Count returns the estimated number of distinct items added within the retention.
+	Count(ctx context.Context) (uint64, error)
+
+	// CountRange returns the estimated number of distinct items added
+	// within the windows overlapping the time range [from, to].
+	// Windows older than the retention are already expired and are not counted.
+	CountRange(ctx context.Context, from, to time.Time) (uint64, error)
+
+	// Delete deletes the HyperLogLogs of all windows within the retention.
+	Delete(ctx context.Context) error
+}
+
+type hyperLogLog[T any] struct {
+	client valkey.Client
+
+	// name is the key prefix of the HyperLogLogs.
+	name string
+
+	// window is the duration of each HyperLogLog.
+	window time.Duration
+
+	// retention is how long a HyperLogLog is kept after its window ends.
+	retention time.Duration
+}
+
+// NewHyperLogLog creates a new typed HyperLogLog partitioned into time windows.
+// Each window is kept for the retention after it ends.
+// NOTE: 'name:hll:{window start in unix milliseconds}' is used as a key in the Valkey for each window.
+// The windows are determined by the client clock.
+func NewHyperLogLog[T any](
+	client valkey.Client,
+	name string,
+	window time.Duration,
+	retention time.Duration,
+) (HyperLogLog[T], error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyName
+	}
+
+	if window < time.Millisecond {
+		return nil, ErrWindowSizeLessThanOneMillisecond
+	}
+
+	if retention < window {
+		return nil, ErrRetentionLessThanWindowSize
+	}
+
+	return &hyperLogLog[T]{
+		client: client,
+		// NOTE: https://redis.io/docs/reference/cluster-spec/#hash-tags
+		name:      "{" + name + "}:hll:",
+		window:    window,
+		retention: retention,
+	}, nil
+}
+
+func (c *hyperLogLog[T]) Add(ctx context.Context, item T) (bool, error) {
+	return c.AddMulti(ctx, []T{item})
+}
+
+func (c *hyperLogLog[T]) AddMulti(ctx context.Context, items []T) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	elements := make([]string, len(items))
+	for i, item := range items {
+		element, err := encodeHyperLogLogItem(item)
+		if err != nil {
+			return false, err
+		}
+		elements[i] = element
+	}
+
+	start := c.windowStart(time.Now())
+	key := c.key(start)
+	resps := c.client.DoMulti(
+		ctx,
+		c.client.B().
+			Pfadd().
+			Key(key).
+			Element(elements...).
+			Build(),
+		c.client.B().
+			Pexpireat().
+			Key(key).
+			MillisecondsTimestamp(start+(c.window+c.retention).Milliseconds()).
+			Build(),
+	)
+	if err := resps[1].Error(); err != nil {
+		return false, err
+	}
+
+	return resps[0].AsBool()
+}
+
+func (c *hyperLogLog[T]) Count(ctx context.Context) (uint64, error) {
+	now := time.Now()
+	return c.CountRange(ctx, now.Add(-c.retention), now)
+}
+
+func (c *hyperLogLog[T]) CountRange(ctx context.Context, from, to time.Time) (uint64, error) {
+	// NOTE: skip the windows which are already expired.
+	if oldest := time.Now().Add(-c.window - c.retention); from.Before(oldest) {
+		from = oldest
+	}
+
+	keys := c.keys(from, to)
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Pfcount().
+			Key(keys...).
+			Build(),
+	)
+	return resp.AsUint64()
+}
+
+func (c *hyperLogLog[T]) Delete(ctx context.Context) error {
+	now := time.Now()
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Del().
+			Key(c.keys(now.Add(-c.window-c.retention), now)...).
+			Build(),
+	)
+	return resp.Error()
+}
+
+// windowStart returns the start of the window containing t in unix milliseconds.
+func (c *hyperLogLog[T]) windowStart(t time.Time) int64 {
+	ms := t.UnixMilli()
+	size := c.window.Milliseconds()
+	return ms - ms%size
+}
+
+func (c *hyperLogLog[T]) key(start int64) string {
+	return c.name + strconv.FormatInt(start, 10)
+}
+
+// keys returns the keys of the windows overlapping the time range [from, to].
+func (c *hyperLogLog[T]) keys(from, to time.Time) []string {
+	if to.Before(from) {
+		return nil
+	}
+
+	size := c.window.Milliseconds()
+	first, last := c.windowStart(from), c.windowStart(to)
+	keys := make([]string, 0, (last-first)/size+1)
+	for start := first; start <= last; start += size {
+		keys = append(keys, c.key(start))
+	}
+	return keys
+}
+
+func encodeHyperLogLogItem(item any) (string, error) {
+	switch v := item.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return valkey.BinaryString(v), nil
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	return valkey.BinaryString(b), nil
+}
@@ -0,0 +1,181 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewHyperLogLogError(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := NewHyperLogLog[string](client, "", time.Minute, time.Hour)
+		if !errors.Is(err, ErrEmptyName) {
+			t.Error("Error is not ErrEmptyName")
+		}
+	})
+
+	t.Run("WindowSizeLessThanOneMillisecond", func(t *testing.T) {
+		_, err := NewHyperLogLog[string](client, "test", time.Microsecond, time.Hour)
+		if !errors.Is(err, ErrWindowSizeLessThanOneMillisecond) {
+			t.Error("Error is not ErrWindowSizeLessThanOneMillisecond")
+		}
+	})
+
+	t.Run("RetentionLessThanWindowSize", func(t *testing.T) {
+		_, err := NewHyperLogLog[string](client, "test", time.Hour, time.Minute)
+		if !errors.Is(err, ErrRetentionLessThanWindowSize) {
+			t.Error("Error is not ErrRetentionLessThanWindowSize")
+		}
+	})
+}
+
+func TestHyperLogLogKeys(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	hll, err := NewHyperLogLog[string](client, "test", time.Minute, time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+
+	from := time.UnixMilli(90 * 1000)
+	to := time.UnixMilli(210 * 1000)
+	keys := hll.(*hyperLogLog[string]).keys(from, to)
+	expected := []string{"{test}:hll:60000", "{test}:hll:120000", "{test}:hll:180000"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Keys is not %v: %v", expected, keys)
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Errorf("Key %d is not %s: %s", i, expected[i], keys[i])
+		}
+	}
+
+	if keys := hll.(*hyperLogLog[string]).keys(to, from); len(keys) != 0 {
+		t.Errorf("Keys is not empty: %v", keys)
+	}
+}
+
+func TestHyperLogLogAddMulti(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	type visit struct {
+		User int
+	}
+
+	hll, err := NewHyperLogLog[visit](client, "test", time.Second, time.Minute)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	items := make([]visit, 100)
+	for i := range items {
+		items[i] = visit{User: i}
+	}
+
+	changed, err := hll.AddMulti(ctx, items)
+	if err != nil {
+		t.Error(err)
+	}
+	if !changed {
+		t.Error("Changed is not true")
+	}
+
+	changed, err = hll.Add(ctx, items[0])
+	if err != nil {
+		t.Error(err)
+	}
+	if changed {
+		t.Error("Changed is not false")
+	}
+
+	time.Sleep(time.Second)
+
+	_, err = hll.AddMulti(ctx, items[50:])
+	if err != nil {
+		t.Error(err)
+	}
+
+	count, err := hll.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count < 95 || count > 105 {
+		t.Errorf("Count is not around 100: %d", count)
+	}
+
+	count, err = hll.CountRange(ctx, time.Now(), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	if count < 45 || count > 55 {
+		t.Errorf("Count of the current window is not around 50: %d", count)
+	}
+
+	err = hll.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	count, err = hll.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Errorf("Count is not 0: %d", count)
+	}
+}
+
+func TestEncodeHyperLogLogItem(t *testing.T) {
+	for _, c := range []struct {
+		item     any
+		expected string
+	}{
+		{item: "a", expected: "a"},
+		{item: []byte("b"), expected: "b"},
+		{item: 1, expected: "1"},
+		{item: struct{ A int }{A: 1}, expected: `{"A":1}`},
+	} {
+		encoded, err := encodeHyperLogLogItem(c.item)
+		if err != nil {
+			t.Error(err)
+		}
+		if encoded != c.expected {
+			t.Errorf("Encoded is not %s: %s", c.expected, encoded)
+		}
+	}
+
+	if _, err := encodeHyperLogLogItem(make(chan int)); err == nil {
+		t.Error("Error is nil")
+	}
+}
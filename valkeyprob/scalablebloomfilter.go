@@ -0,0 +1,396 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	// All layers are stored in the single bitmap of KEYS[2] at the cumulative bit offsets kept in the meta hash of KEYS[1],
+	// so that the scripts only access the declared keys even though AddMulti adds the layers on demand.
+	scalableBloomFilterLuaFunctions = `
+local metaKey = KEYS[1]
+local bitsKey = KEYS[2]
+
+local layers = {}
+local numLayers = tonumber(redis.call('HGET', metaKey, 'layers') or 0)
+for i=0, numLayers-1 do
+	local v = redis.call('HMGET', metaKey, 'm:' .. i, 'k:' .. i, 'c:' .. i, 'n:' .. i, 'o:' .. i)
+	table.insert(layers, {m = tonumber(v[1]), k = tonumber(v[2]), c = tonumber(v[3]), n = tonumber(v[4]), o = tonumber(v[5])})
+end
+
+local function bits(layer, h1, h2, op, ...)
+	local l = layers[layer]
+	local args = {'BITFIELD', bitsKey}
+	for j=0, l.k-1 do
+		table.insert(args, op)
+		table.insert(args, 'u1')
+		table.insert(args, l.o + (h1 + j * h2) % l.m)
+		for _, v in ipairs({...}) do
+			table.insert(args, v)
+		end
+	end
+	return redis.call(unpack(args))
+end
+
+local function exists(h1, h2)
+	for i=#layers, 1, -1 do
+		local found = true
+		for _, bit in ipairs(bits(i, h1, h2, 'GET')) do
+			if bit == 0 then
+				found = false
+				break
+			end
+		end
+		if found then
+			return true
+		end
+	end
+	return false
+end
+`
+
+	scalableBloomFilterAddMultiScript = scalableBloomFilterLuaFunctions + `
+local capacity = tonumber(ARGV[1])
+local errorRate = tonumber(ARGV[2])
+local growthFactor = tonumber(ARGV[3])
+local tighteningRatio = tonumber(ARGV[4])
+local numElements = (#ARGV - 4) / 2
+
+local function addLayer()
+	local i = #layers
+	local c = math.floor(capacity * growthFactor ^ i)
+	local p = errorRate * (1 - tighteningRatio) * tighteningRatio ^ i
+	local m = math.ceil(-c * math.log(p) / (math.log(2) ^ 2))
+	local o = 0
+	if i > 0 then
+		o = layers[i].o + layers[i].m
+	end
+	if o + m > 4294967296 then
+		return false
+	end
+	local k = math.max(1, math.floor(m / c * math.log(2) + 0.5))
+
+	redis.call('HSET', metaKey, 'm:' .. i, m, 'k:' .. i, k, 'c:' .. i, c, 'n:' .. i, 0, 'o:' .. i, o, 'layers', i + 1)
+	table.insert(layers, {m = m, k = k, c = c, n = 0, o = o})
+	return true
+end
+
+for e=0, numElements-1 do
+	local h1 = tonumber(ARGV[5 + e * 2])
+	local h2 = tonumber(ARGV[6 + e * 2])
+
+	if not exists(h1, h2) then
+		local last = #layers
+		if last == 0 or layers[last].n >= layers[last].c then
+			if not addLayer() then
+				return redis.error_reply('scalable bloom filter is full')
+			end
+			last = #layers
+		end
+
+		bits(last, h1, h2, 'SET', 1)
+		layers[last].n = layers[last].n + 1
+		redis.call('HINCRBY', metaKey, 'n:' .. (last - 1), 1)
+		redis.call('HINCRBY', metaKey, 'count', 1)
+	end
+end
+
+return 1
+`
+
+	scalableBloomFilterExistsMultiScript = scalableBloomFilterLuaFunctions + `
+local numElements = #ARGV / 2
+
+local result = {}
+for e=0, numElements-1 do
+	table.insert(result, exists(tonumber(ARGV[1 + e * 2]), tonumber(ARGV[2 + e * 2])))
+end
+
+return result
+`
+)
+
+var (
+	ErrGrowthFactorZero          = errors.New("growth factor cannot be zero")
+	ErrTighteningRatioOutOfRange = errors.New("tightening ratio must be in range (0, 1)")
+)
+
+type ScalableBloomFilterOptions struct {
+	growthFactor    uint
+	tighteningRatio float64
+}
+
+type ScalableBloomFilterOptionFunc func(o *ScalableBloomFilterOptions)
+
+// WithGrowthFactor sets the capacity ratio of each layer to the previous one.
+// The default is 2.
+func WithGrowthFactor(growthFactor uint) ScalableBloomFilterOptionFunc {
+	return func(o *ScalableBloomFilterOptions) {
+		o.growthFactor = growthFactor
+	}
+}
+
+// WithTighteningRatio sets the false positive rate ratio of each layer to the previous one.
+// The default is 0.5.
+func WithTighteningRatio(tighteningRatio float64) ScalableBloomFilterOptionFunc {
+	return func(o *ScalableBloomFilterOptions) {
+		o.tighteningRatio = tighteningRatio
+	}
+}
+
+// ScalableBloomFilter is a BloomFilter which grows with insertions.
+// When the last layer is full, a new layer is added with a larger capacity and a tighter false positive rate,
+// so that the overall false positive rate stays under the configured one.
+// Each layer is based on Valkey Bitmaps.
+// ScalableBloomFilter uses a 128-bit murmur3 hash function.
+type ScalableBloomFilter interface {
+	BloomFilter
+
+	// Layers returns the number of layers in the Scalable Bloom filter.
+	Layers(ctx context.Context) (uint, error)
+
+	// FalsePositiveRate returns the estimated false positive rate of the Scalable Bloom filter
+	// from the number of items in each layer.
+	FalsePositiveRate(ctx context.Context) (float64, error)
+}
+
+type scalableBloomFilter struct {
+	client valkey.Client
+
+	addMultiScript *valkey.Lua
+
+	existsMultiScript *valkey.Lua
+
+	// name is the name of the meta hash of the Scalable Bloom filter.
+	// It is used as a key in the Valkey.
+	name string
+
+	// bitsName is the name of the bitmap holding all the layers at the offsets kept in the meta hash.
+	// It has the same hash tag as the name, so they are in the same slot in a Valkey cluster.
+	bitsName string
+
+	capacityString        string
+	errorRateString       string
+	growthFactorString    string
+	tighteningRatioString string
+
+	keys []string
+}
+
+// NewScalableBloomFilter creates a new Scalable Bloom filter.
+// The first layer holds initialCapacity items and each following layer holds growthFactor times more.
+// NOTE: '{name}:sbf:m' is used as a meta key in the Valkey to keep track of the layers and the number of items,
+// and '{name}:sbf:b' is used as a filter key holding all the layers one after another in the Valkey.
+// Both are hash tagged by '{name}', so they are in the same slot in a Valkey cluster.
+// The total size of all the layers is limited by the maximum size of a Valkey bitmap, which is 2^32 bits.
+func NewScalableBloomFilter(
+	client valkey.Client,
+	name string,
+	initialCapacity uint,
+	falsePositiveRate float64,
+	opts ...ScalableBloomFilterOptionFunc,
+) (ScalableBloomFilter, error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyName
+	}
+
+	if initialCapacity == 0 {
+		return nil, ErrCapacityZero
+	}
+
+	if falsePositiveRate <= 0 {
+		return nil, ErrFalsePositiveRateLessThanEqualZero
+	}
+	if falsePositiveRate >= 1 {
+		return nil, ErrFalsePositiveRateGreaterThanOne
+	}
+
+	options := &ScalableBloomFilterOptions{
+		growthFactor:    2,
+		tighteningRatio: 0.5,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.growthFactor == 0 {
+		return nil, ErrGrowthFactorZero
+	}
+	if options.tighteningRatio <= 0 || options.tighteningRatio >= 1 {
+		return nil, ErrTighteningRatioOutOfRange
+	}
+
+	size := numberOfBloomFilterBits(initialCapacity, falsePositiveRate*(1-options.tighteningRatio))
+	if size > maxSize {
+		return nil, ErrBitsSizeTooLarge
+	}
+
+	// NOTE: https://redis.io/docs/reference/cluster-spec/#hash-tags
+	prefix := "{" + name + "}:sbf:"
+	metaName := prefix + "m"
+	bitsName := prefix + "b"
+	return &scalableBloomFilter{
+		client:                client,
+		name:                  metaName,
+		bitsName:              bitsName,
+		capacityString:        strconv.FormatUint(uint64(initialCapacity), 10),
+		errorRateString:       strconv.FormatFloat(falsePositiveRate, 'g', -1, 64),
+		growthFactorString:    strconv.FormatUint(uint64(options.growthFactor), 10),
+		tighteningRatioString: strconv.FormatFloat(options.tighteningRatio, 'g', -1, 64),
+		addMultiScript:        valkey.NewLuaScript(scalableBloomFilterAddMultiScript),
+		existsMultiScript:     valkey.NewLuaScript(scalableBloomFilterExistsMultiScript),
+		keys:                  []string{metaName, bitsName},
+	}, nil
+}
+
+// hashes appends the lower 32 bits of the two hashes of each key to the args,
+// so that the Lua scripts can compute the indexes of every layer without losing precision.
+func (c *scalableBloomFilter) hashes(keys []string, buf *[]byte, args []string) []string {
+	for _, key := range keys {
+		h1, h2 := hash([]byte(key))
+		for _, h := range [2]uint64{h1, h2} {
+			offset := len(*buf)
+			*buf = strconv.AppendUint(*buf, h&math.MaxUint32, 10)
+			args = append(args, valkey.BinaryString((*buf)[offset:]))
+		}
+	}
+	return args
+}
+
+func (c *scalableBloomFilter) Add(ctx context.Context, key string) error {
+	return c.AddMulti(ctx, []string{key})
+}
+
+func (c *scalableBloomFilter) AddMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*2*10)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*2+4)
+	args = append(args, c.capacityString, c.errorRateString, c.growthFactorString, c.tighteningRatioString)
+	args = c.hashes(keys, &buf.s, args)
+
+	resp := c.addMultiScript.Exec(ctx, c.client, c.keys, args)
+	return resp.Error()
+}
+
+func (c *scalableBloomFilter) Exists(ctx context.Context, key string) (bool, error) {
+	exists, err := c.ExistsMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+
+	return exists[0], nil
+}
+
+func (c *scalableBloomFilter) ExistsMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*2*10)
+	defer bytesPool.Put(buf)
+
+	args := c.hashes(keys, &buf.s, make([]string, 0, len(keys)*2))
+
+	resp := c.existsMultiScript.Exec(ctx, c.client, c.keys, args)
+	return toBools(resp)
+}
+
+// Reset removes all layers, and the first layer is added again by the next insertion.
+func (c *scalableBloomFilter) Reset(ctx context.Context) error {
+	return c.Delete(ctx)
+}
+
+func (c *scalableBloomFilter) Delete(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Del().
+			Key(c.name, c.bitsName).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *scalableBloomFilter) Count(ctx context.Context) (uint64, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Hget().
+			Key(c.name).
+			Field("count").
+			Build(),
+	)
+	count, err := resp.AsUint64()
+	if err != nil {
+		if valkey.IsValkeyNil(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (c *scalableBloomFilter) Layers(ctx context.Context) (uint, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Hget().
+			Key(c.name).
+			Field("layers").
+			Build(),
+	)
+	layers, err := resp.AsUint64()
+	if err != nil {
+		if valkey.IsValkeyNil(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return uint(layers), nil
+}
+
+func (c *scalableBloomFilter) FalsePositiveRate(ctx context.Context) (float64, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Hgetall().
+			Key(c.name).
+			Build(),
+	)
+	meta, err := resp.AsIntMap()
+	if err != nil {
+		return 0, err
+	}
+
+	return scalableBloomFilterFalsePositiveRate(meta), nil
+}
+
+// scalableBloomFilterFalsePositiveRate estimates the false positive rate from the meta hash.
+// An item is a false positive if any of the layers reports it, so the rate is 1 - Π(1 - p_i),
+// where p_i = (1 - e^(-k*n/m))^k is the estimated false positive rate of each layer.
+func scalableBloomFilterFalsePositiveRate(meta map[string]int64) float64 {
+	negative := 1.0
+	for i := int64(0); i < meta["layers"]; i++ {
+		layer := strconv.FormatInt(i, 10)
+		m, k, n := float64(meta["m:"+layer]), float64(meta["k:"+layer]), float64(meta["n:"+layer])
+		if m == 0 {
+			continue
+		}
+		negative *= 1 - math.Pow(1-math.Exp(-k*n/m), k)
+	}
+	return 1 - negative
+}
@@ -0,0 +1,250 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNewScalableBloomFilter(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	sbf, err := NewScalableBloomFilter(client, "test", 100, 0.01, WithGrowthFactor(4), WithTighteningRatio(0.8))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if sbf == nil {
+		t.Error("Scalable Bloom filter is nil")
+	}
+	if sbf.(*scalableBloomFilter).name != "{test}:sbf:m" {
+		t.Error("Name is not {test}:sbf:m")
+	}
+	if sbf.(*scalableBloomFilter).bitsName != "{test}:sbf:b" {
+		t.Error("Bits name is not {test}:sbf:b")
+	}
+	if sbf.(*scalableBloomFilter).growthFactorString != "4" {
+		t.Error("Growth factor is not 4")
+	}
+	if sbf.(*scalableBloomFilter).tighteningRatioString != "0.8" {
+		t.Error("Tightening ratio is not 0.8")
+	}
+}
+
+func TestNewScalableBloomFilterError(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := NewScalableBloomFilter(client, "", 100, 0.01)
+		if !errors.Is(err, ErrEmptyName) {
+			t.Error("Error is not ErrEmptyName")
+		}
+	})
+
+	t.Run("CapacityZero", func(t *testing.T) {
+		_, err := NewScalableBloomFilter(client, "test", 0, 0.01)
+		if !errors.Is(err, ErrCapacityZero) {
+			t.Error("Error is not ErrCapacityZero")
+		}
+	})
+
+	t.Run("FalsePositiveRateOutOfRange", func(t *testing.T) {
+		_, err := NewScalableBloomFilter(client, "test", 100, 0)
+		if !errors.Is(err, ErrFalsePositiveRateLessThanEqualZero) {
+			t.Error("Error is not ErrFalsePositiveRateLessThanEqualZero")
+		}
+
+		_, err = NewScalableBloomFilter(client, "test", 100, 1)
+		if !errors.Is(err, ErrFalsePositiveRateGreaterThanOne) {
+			t.Error("Error is not ErrFalsePositiveRateGreaterThanOne")
+		}
+	})
+
+	t.Run("GrowthFactorZero", func(t *testing.T) {
+		_, err := NewScalableBloomFilter(client, "test", 100, 0.01, WithGrowthFactor(0))
+		if !errors.Is(err, ErrGrowthFactorZero) {
+			t.Error("Error is not ErrGrowthFactorZero")
+		}
+	})
+
+	t.Run("TighteningRatioOutOfRange", func(t *testing.T) {
+		_, err := NewScalableBloomFilter(client, "test", 100, 0.01, WithTighteningRatio(1))
+		if !errors.Is(err, ErrTighteningRatioOutOfRange) {
+			t.Error("Error is not ErrTighteningRatioOutOfRange")
+		}
+	})
+}
+
+func TestScalableBloomFilterAddMulti(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	sbf, err := NewScalableBloomFilter(client, "test", 100, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	layers, err := sbf.Layers(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if layers != 0 {
+		t.Errorf("Layers is not 0: %d", layers)
+	}
+
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+	}
+
+	err = sbf.AddMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := sbf.ExistsMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+	for i, e := range exists {
+		if !e {
+			t.Errorf("Key %s does not exist", keys[i])
+		}
+	}
+
+	// NOTE: 100 + 200 + 400 < 1000 <= 100 + 200 + 400 + 800
+	layers, err = sbf.Layers(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if layers != 4 {
+		t.Errorf("Layers is not 4: %d", layers)
+	}
+
+	// NOTE: items reported as existing by a false positive are not counted.
+	count, err := sbf.Count(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count < 950 || count > 1000 {
+		t.Errorf("Count is not around 1000: %d", count)
+	}
+
+	rate, err := sbf.FalsePositiveRate(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if rate <= 0 || rate > 0.01 {
+		t.Errorf("False positive rate is not in range (0, 0.01]: %f", rate)
+	}
+
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		exists, err := sbf.Exists(ctx, "item"+strconv.Itoa(i))
+		if err != nil {
+			t.Error(err)
+		}
+		if exists {
+			falsePositives++
+		}
+	}
+	if float64(falsePositives)/10000 > 0.02 {
+		t.Errorf("False positive rate is too high: %d/10000", falsePositives)
+	}
+}
+
+func TestScalableBloomFilterReset(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	sbf, err := NewScalableBloomFilter(client, "test", 10, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = "item" + strconv.Itoa(i)
+	}
+
+	err = sbf.AddMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = sbf.Reset(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exists, err := sbf.Exists(ctx, keys[0])
+	if err != nil {
+		t.Error(err)
+	}
+	if exists {
+		t.Error("Key exists after reset")
+	}
+
+	n, err := client.Do(ctx, client.B().Exists().Key("{test}:sbf:m", "{test}:sbf:b").Build()).AsInt64()
+	if err != nil {
+		t.Error(err)
+	}
+	if n != 0 {
+		t.Errorf("Keys are not deleted: %d", n)
+	}
+}
+
+func TestScalableBloomFilterFalsePositiveRate(t *testing.T) {
+	rate := scalableBloomFilterFalsePositiveRate(map[string]int64{})
+	if rate != 0 {
+		t.Errorf("False positive rate is not 0: %f", rate)
+	}
+
+	rate = scalableBloomFilterFalsePositiveRate(map[string]int64{
+		"layers": 2,
+		"m:0":    1000, "k:0": 7, "n:0": 100,
+		"m:1": 2000, "k:1": 7, "n:1": 0,
+	})
+	expected := math.Pow(1-math.Exp(-0.7), 7)
+	if math.Abs(rate-expected) > 1e-12 {
+		t.Errorf("False positive rate is not %f: %f", expected, rate)
+	}
+}
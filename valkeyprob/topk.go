@@ -0,0 +1,294 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+const (
+	topKAddMultiScript = `
+local k = tonumber(ARGV[1])
+local depth = tonumber(ARGV[2])
+local numElements = (#ARGV - 2) / (depth + 1)
+local sketchKey = KEYS[1]
+local heapKey = KEYS[2]
+
+local result = {}
+for i=0, numElements-1 do
+	local base = 3 + i * (depth + 1)
+	local item = ARGV[base]
+	local args = {'BITFIELD', sketchKey, 'OVERFLOW', 'SAT'}
+	for j=1, depth do
+		table.insert(args, 'INCRBY')
+		table.insert(args, 'u32')
+		table.insert(args, ARGV[base+j])
+		table.insert(args, 1)
+	end
+
+	local counts = redis.call(unpack(args))
+	local minCount = counts[1]
+	for j=2, #counts do
+		if counts[j] < minCount then
+			minCount = counts[j]
+		end
+	end
+
+	local expelled = false
+	if redis.call('ZSCORE', heapKey, item) or redis.call('ZCARD', heapKey) < k then
+		redis.call('ZADD', heapKey, minCount, item)
+	else
+		local lowest = redis.call('ZRANGE', heapKey, 0, 0, 'WITHSCORES')
+		if tonumber(lowest[2]) < minCount then
+			redis.call('ZREM', heapKey, lowest[1])
+			redis.call('ZADD', heapKey, minCount, item)
+			expelled = lowest[1]
+		end
+	end
+
+	table.insert(result, expelled)
+end
+
+return result
+`
+
+	topKResetScript = `
+local sketchKey = KEYS[1]
+local heapKey = KEYS[2]
+
+redis.call('SET', sketchKey, "")
+redis.call('DEL', heapKey)
+
+return 1
+`
+)
+
+var ErrTopKZero = errors.New("k must be greater than 0")
+
+// TopKItem is an item of the Top-K list with its estimated count.
+type TopKItem struct {
+	Key   string
+	Count uint64
+}
+
+// TopK keeps track of the k most frequent items.
+// The counts are estimated by a Count-Min Sketch based on Valkey Bitmaps,
+// and the k most frequent items are kept in a Valkey Sorted Set.
+// TopK uses a 128-bit murmur3 hash function.
+type TopK interface {
+	// Add adds an item to the Top-K.
+	// It returns the item expelled from the Top-K list or an empty string if there is none.
+	Add(ctx context.Context, key string) (string, error)
+
+	// AddMulti adds one or more items to the Top-K.
+	// It returns the items expelled from the Top-K list in the order of the keys.
+	// An empty string means no item was expelled by the corresponding key.
+	// NOTE: If keys are too many, it can block the Valkey server for a long time.
+	AddMulti(ctx context.Context, keys []string) ([]string, error)
+
+	// Query checks if an item is in the Top-K list.
+	Query(ctx context.Context, key string) (bool, error)
+
+	// QueryMulti checks if one or more items are in the Top-K list.
+	QueryMulti(ctx context.Context, keys []string) ([]bool, error)
+
+	// List returns the items in the Top-K list in descending order of their estimated counts.
+	List(ctx context.Context) ([]TopKItem, error)
+
+	// Reset resets the Top-K.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the Top-K.
+	Delete(ctx context.Context) error
+}
+
+type topK struct {
+	client valkey.Client
+
+	addMultiScript *valkey.Lua
+
+	// name is the name of the Count-Min Sketch of the Top-K.
+	// It is used as a key in the Valkey.
+	name string
+
+	// heap is the name of the Sorted Set of the Top-K list.
+	heap string
+
+	kString     string
+	depthString string
+
+	addMultiKeys []string
+
+	// width is the number of counters in each row of the Count-Min Sketch.
+	width uint
+
+	// depth is the number of rows of the Count-Min Sketch.
+	depth uint
+}
+
+// NewTopK creates a new Top-K which keeps track of the k most frequent items.
+// errorRate and probability are used to size the underlying Count-Min Sketch. See NewCountMinSketch.
+// NOTE: 'name:topk' is used as a sketch key in the Valkey and
+// 'name:topk:z' is used as a sorted set key in the Valkey to keep the Top-K list.
+func NewTopK(
+	client valkey.Client,
+	name string,
+	k uint,
+	errorRate float64,
+	probability float64,
+) (TopK, error) {
+	if len(name) == 0 {
+		return nil, ErrEmptyName
+	}
+
+	if k == 0 {
+		return nil, ErrTopKZero
+	}
+
+	width, depth, err := countMinSketchSize(errorRate, probability)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: https://redis.io/docs/reference/cluster-spec/#hash-tags
+	topKName := "{" + name + "}:topk"
+	heapName := topKName + ":z"
+	return &topK{
+		client:         client,
+		name:           topKName,
+		heap:           heapName,
+		width:          width,
+		depth:          depth,
+		kString:        strconv.FormatUint(uint64(k), 10),
+		depthString:    strconv.FormatUint(uint64(depth), 10),
+		addMultiScript: valkey.NewLuaScript(topKAddMultiScript),
+		addMultiKeys:   []string{topKName, heapName},
+	}, nil
+}
+
+func (c *topK) Add(ctx context.Context, key string) (string, error) {
+	expelled, err := c.AddMulti(ctx, []string{key})
+	if err != nil {
+		return "", err
+	}
+
+	return expelled[0], nil
+}
+
+func (c *topK) AddMulti(ctx context.Context, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	buf := bytesPool.Get(0, len(keys)*int(c.depth)*12)
+	defer bytesPool.Put(buf)
+
+	args := make([]string, 0, len(keys)*int(c.depth+1)+2)
+	args = append(args, c.kString, c.depthString)
+	for _, key := range keys {
+		args = append(args, key)
+		args = countMinSketchOffsets(key, c.width, c.depth, &buf.s, args)
+	}
+
+	resp := c.addMultiScript.Exec(ctx, c.client, c.addMultiKeys, args)
+	arr, err := resp.ToArray()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(arr))
+	for i, el := range arr {
+		if el.IsNil() {
+			continue
+		}
+		if result[i], err = el.ToString(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (c *topK) Query(ctx context.Context, key string) (bool, error) {
+	exists, err := c.QueryMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+
+	return exists[0], nil
+}
+
+func (c *topK) QueryMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Zmscore().
+			Key(c.heap).
+			Member(keys...).
+			Build(),
+	)
+	arr, err := resp.ToArray()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(arr))
+	for i, el := range arr {
+		result[i] = !el.IsNil()
+	}
+	return result, nil
+}
+
+func (c *topK) List(ctx context.Context) ([]TopKItem, error) {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Zrange().
+			Key(c.heap).
+			Min("+inf").
+			Max("-inf").
+			Byscore().
+			Rev().
+			Withscores().
+			Build(),
+	)
+	scores, err := resp.AsZScores()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TopKItem, len(scores))
+	for i, score := range scores {
+		result[i] = TopKItem{Key: score.Member, Count: uint64(score.Score)}
+	}
+	return result, nil
+}
+
+func (c *topK) Reset(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Eval().
+			Script(topKResetScript).
+			Numkeys(2).
+			Key(c.name, c.heap).
+			Build(),
+	)
+	return resp.Error()
+}
+
+func (c *topK) Delete(ctx context.Context) error {
+	resp := c.client.Do(
+		ctx,
+		c.client.B().
+			Del().
+			Key(c.name, c.heap).
+			Build(),
+	)
+	return resp.Error()
+}
@@ -0,0 +1,237 @@
+package valkeyprob
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNewTopK(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	topk, err := NewTopK(client, "test", 3, 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if topk == nil {
+		t.Error("Top-K is nil")
+	}
+	if topk.(*topK).name != "{test}:topk" {
+		t.Error("Name is not {test}:topk")
+	}
+	if topk.(*topK).heap != "{test}:topk:z" {
+		t.Error("Heap is not {test}:topk:z")
+	}
+	if topk.(*topK).kString != "3" {
+		t.Error("K is not 3")
+	}
+}
+
+func TestNewTopKError(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := NewTopK(client, "", 3, 0.01, 0.01)
+		if !errors.Is(err, ErrEmptyName) {
+			t.Error("Error is not ErrEmptyName")
+		}
+	})
+
+	t.Run("ZeroK", func(t *testing.T) {
+		_, err := NewTopK(client, "test", 0, 0.01, 0.01)
+		if !errors.Is(err, ErrTopKZero) {
+			t.Error("Error is not ErrTopKZero")
+		}
+	})
+
+	t.Run("ErrorRateOutOfRange", func(t *testing.T) {
+		_, err := NewTopK(client, "test", 3, 0, 0.01)
+		if !errors.Is(err, ErrErrorRateOutOfRange) {
+			t.Error("Error is not ErrErrorRateOutOfRange")
+		}
+	})
+}
+
+func TestTopKAdd(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	topk, err := NewTopK(client, "test", 2, 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	for i, key := range []string{"a", "a", "a", "b", "b"} {
+		expelled, err := topk.Add(ctx, key)
+		if err != nil {
+			t.Error(err)
+		}
+		if expelled != "" {
+			t.Errorf("Item %d expelled %s", i, expelled)
+		}
+	}
+
+	// NOTE: "c" has a lower count than "b", so nothing is expelled.
+	expelled, err := topk.Add(ctx, "c")
+	if err != nil {
+		t.Error(err)
+	}
+	if expelled != "" {
+		t.Errorf("Expelled is not empty: %s", expelled)
+	}
+
+	expelled, err = topk.Add(ctx, "c")
+	if err != nil {
+		t.Error(err)
+	}
+	if expelled != "" {
+		t.Errorf("Expelled is not empty: %s", expelled)
+	}
+
+	expelled, err = topk.Add(ctx, "c")
+	if err != nil {
+		t.Error(err)
+	}
+	if expelled != "b" {
+		t.Errorf("Expelled is not b: %s", expelled)
+	}
+
+	exists, err := topk.QueryMulti(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !exists[0] || exists[1] || !exists[2] {
+		t.Errorf("Exists is not [true false true]: %v", exists)
+	}
+
+	items, err := topk.List(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Items length is not 2: %d", len(items))
+	}
+	for _, item := range items {
+		if (item.Key != "a" && item.Key != "c") || item.Count != 3 {
+			t.Errorf("Item is not a or c with 3: %v", item)
+		}
+	}
+}
+
+func TestTopKAddMulti(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	topk, err := NewTopK(client, "test", 10, 0.001, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 0, 5050)
+	for i := 1; i <= 100; i++ {
+		for j := 0; j < i; j++ {
+			keys = append(keys, "item"+strconv.Itoa(i))
+		}
+	}
+
+	expelled, err := topk.AddMulti(ctx, keys)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(expelled) != len(keys) {
+		t.Errorf("Expelled length is not %d: %d", len(keys), len(expelled))
+	}
+
+	items, err := topk.List(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(items) != 10 {
+		t.Fatalf("Items length is not 10: %d", len(items))
+	}
+	for i, item := range items {
+		if item.Key != "item"+strconv.Itoa(100-i) {
+			t.Errorf("Item %d is not item%d: %s", i, 100-i, item.Key)
+		}
+	}
+}
+
+func TestTopKReset(t *testing.T) {
+	client, flushAllAndClose, err := setupValkey7Cluster()
+	if err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		err := flushAllAndClose()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	topk, err := NewTopK(client, "test", 3, 0.01, 0.01)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ctx := context.Background()
+	_, err = topk.Add(ctx, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = topk.Reset(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	items, err := topk.List(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Items is not empty: %v", items)
+	}
+
+	err = topk.Delete(ctx)
+	if err != nil {
+		t.Error(err)
+	}
+}
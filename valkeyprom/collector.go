@@ -0,0 +1,328 @@
+package valkeyprom
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/valkeyhook"
+)
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// Option is the Functional Options interface
+type Option func(o *options)
+
+type options struct {
+	constLabels prometheus.Labels
+	namespace   string
+	buckets     []float64
+}
+
+// WithNamespace sets the namespace of all metrics. The default is "valkey".
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithConstLabels sets labels added to all metrics.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// WithHistogramBuckets sets the buckets in seconds of the latency histograms.
+// If not set, prometheus.DefBuckets will be used.
+func WithHistogramBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+// Collector is a prometheus.Collector of valkey.Client metrics:
+//   - command_duration_seconds: histogram of command latency by command
+//   - command_errors_total: number of command errors by command and error prefix, such as MOVED, LOADING and WRONGTYPE
+//   - cache_hits_total, cache_misses_total and cache_hit_ratio: client side caching effectiveness
+//   - connections: number of open connections by node
+//   - pipe_in_flight_commands and pipe_queued_commands: pipeline depth by node
+//   - pool_connections, pool_idle_connections and pool_wait_seconds: blocking pool stats by node
+//   - redirects_total: number of MOVED and ASK redirects by node
+//
+// Command metrics are collected from clients wrapped by WithClient, and connection metrics are
+// collected from clients created with the ClientOption returned by Instrument.
+type Collector struct {
+	duration  *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	conns     *prometheus.GaugeVec
+	poolWait  *prometheus.HistogramVec
+	redirects *prometheus.CounterVec
+
+	cacheHits     *prometheus.Desc
+	cacheMisses   *prometheus.Desc
+	cacheHitRatio *prometheus.Desc
+	inFlight      *prometheus.Desc
+	queued        *prometheus.Desc
+	poolConns     *prometheus.Desc
+	poolIdle      *prometheus.Desc
+
+	pipes map[*pipeEntry]struct{}
+	pools map[*poolEntry]struct{}
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	mu sync.Mutex
+}
+
+type pipeEntry struct {
+	stats func() valkey.PipeStats
+	addr  string
+}
+
+type poolEntry struct {
+	stats func() valkey.PoolStats
+	addr  string
+}
+
+// NewCollector creates a Collector. Register it to a prometheus.Registerer to export the metrics.
+func NewCollector(opts ...Option) *Collector {
+	o := &options{namespace: "valkey", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(o)
+	}
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(o.namespace, "", name), help, labels, o.constLabels)
+	}
+	return &Collector{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Name:        "command_duration_seconds",
+			Help:        "Histogram of command latency in seconds.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"command"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "command_errors_total",
+			Help:        "Number of command errors by error prefix.",
+			ConstLabels: o.constLabels,
+		}, []string{"command", "prefix"}),
+		conns: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Name:        "connections",
+			Help:        "Number of open connections.",
+			ConstLabels: o.constLabels,
+		}, []string{"node"}),
+		poolWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Name:        "pool_wait_seconds",
+			Help:        "Histogram of time waiting for a blocking pool connection in seconds.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"node"}),
+		redirects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Name:        "redirects_total",
+			Help:        "Number of MOVED and ASK redirects.",
+			ConstLabels: o.constLabels,
+		}, []string{"node", "type"}),
+		cacheHits:     desc("cache_hits_total", "Number of replies served from the client side cache."),
+		cacheMisses:   desc("cache_misses_total", "Number of client side cache misses."),
+		cacheHitRatio: desc("cache_hit_ratio", "Ratio of client side cache hits to all cacheable commands."),
+		inFlight:      desc("pipe_in_flight_commands", "Number of commands waiting for replies.", "node"),
+		queued:        desc("pipe_queued_commands", "Number of commands waiting to be written.", "node"),
+		poolConns:     desc("pool_connections", "Number of connections of the blocking pool.", "node"),
+		poolIdle:      desc("pool_idle_connections", "Number of idle connections of the blocking pool.", "node"),
+		pipes:         make(map[*pipeEntry]struct{}),
+		pools:         make(map[*poolEntry]struct{}),
+	}
+}
+
+// NewClient creates a valkey.Client with the option returned by Instrument and wraps it with WithClient.
+func (c *Collector) NewClient(option valkey.ClientOption) (valkey.Client, error) {
+	client, err := valkey.NewClient(c.Instrument(option))
+	if err != nil {
+		return nil, err
+	}
+	return c.WithClient(client), nil
+}
+
+// WithClient wraps the client to collect command metrics, in the same way valkeyhook.WithHook does.
+// Commands sent together by DoMulti and DoMultiCache share the latency of the whole batch.
+func (c *Collector) WithClient(client valkey.Client) valkey.Client {
+	return valkeyhook.WithHook(client, &hook{c: c})
+}
+
+// Instrument returns a copy of the option with DialCtxFn and MetricsHooks wrapped to collect
+// connection, pipeline and pool metrics. Hooks already set in the option are still called.
+func (c *Collector) Instrument(option valkey.ClientOption) valkey.ClientOption {
+	dialFn := option.DialCtxFn
+	if dialFn == nil {
+		if option.DialFn != nil {
+			fn := option.DialFn
+			dialFn = func(_ context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+				return fn(dst, dialer, cfg)
+			}
+		} else {
+			dialFn = valkey.DefaultDialCtxFn
+		}
+	}
+	option.DialCtxFn = func(ctx context.Context, dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+		conn, err := dialFn(ctx, dst, dialer, cfg)
+		if err != nil {
+			return nil, err
+		}
+		gauge := c.conns.WithLabelValues(dst)
+		gauge.Inc()
+		return &connTracker{Conn: conn, gauge: gauge}, nil
+	}
+	option.MetricsHooks = c.hooks(option.MetricsHooks)
+	return option
+}
+
+func (c *Collector) hooks(prev valkey.MetricsHooks) valkey.MetricsHooks {
+	hooks := prev
+	hooks.OnPipeOpen = func(addr string, stats func() valkey.PipeStats) func() {
+		e := &pipeEntry{addr: addr, stats: stats}
+		c.mu.Lock()
+		c.pipes[e] = struct{}{}
+		c.mu.Unlock()
+		var onClose func()
+		if prev.OnPipeOpen != nil {
+			onClose = prev.OnPipeOpen(addr, stats)
+		}
+		return func() {
+			c.mu.Lock()
+			delete(c.pipes, e)
+			c.mu.Unlock()
+			if onClose != nil {
+				onClose()
+			}
+		}
+	}
+	hooks.OnPoolOpen = func(addr string, stats func() valkey.PoolStats) func() {
+		e := &poolEntry{addr: addr, stats: stats}
+		c.mu.Lock()
+		c.pools[e] = struct{}{}
+		c.mu.Unlock()
+		var onClose func()
+		if prev.OnPoolOpen != nil {
+			onClose = prev.OnPoolOpen(addr, stats)
+		}
+		return func() {
+			c.mu.Lock()
+			delete(c.pools, e)
+			c.mu.Unlock()
+			if onClose != nil {
+				onClose()
+			}
+		}
+	}
+	hooks.OnPoolWait = func(addr string, wait time.Duration) {
+		c.poolWait.WithLabelValues(addr).Observe(wait.Seconds())
+		if prev.OnPoolWait != nil {
+			prev.OnPoolWait(addr, wait)
+		}
+	}
+	hooks.OnRedirect = func(addr string, target string, mode valkey.RedirectMode) {
+		if mode == valkey.RedirectAsk {
+			c.redirects.WithLabelValues(addr, "ask").Inc()
+		} else {
+			c.redirects.WithLabelValues(addr, "moved").Inc()
+		}
+		if prev.OnRedirect != nil {
+			prev.OnRedirect(addr, target, mode)
+		}
+	}
+	return hooks
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.duration.Describe(ch)
+	c.errors.Describe(ch)
+	c.conns.Describe(ch)
+	c.poolWait.Describe(ch)
+	c.redirects.Describe(ch)
+	ch <- c.cacheHits
+	ch <- c.cacheMisses
+	ch <- c.cacheHitRatio
+	ch <- c.inFlight
+	ch <- c.queued
+	ch <- c.poolConns
+	ch <- c.poolIdle
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.duration.Collect(ch)
+	c.errors.Collect(ch)
+	c.conns.Collect(ch)
+	c.poolWait.Collect(ch)
+	c.redirects.Collect(ch)
+
+	hits, misses := c.hits.Load(), c.misses.Load()
+	ch <- prometheus.MustNewConstMetric(c.cacheHits, prometheus.CounterValue, float64(hits))
+	ch <- prometheus.MustNewConstMetric(c.cacheMisses, prometheus.CounterValue, float64(misses))
+	ratio := 0.0
+	if hits+misses > 0 {
+		ratio = float64(hits) / float64(hits+misses)
+	}
+	ch <- prometheus.MustNewConstMetric(c.cacheHitRatio, prometheus.GaugeValue, ratio)
+
+	c.mu.Lock()
+	pipes := make([]*pipeEntry, 0, len(c.pipes))
+	for p := range c.pipes {
+		pipes = append(pipes, p)
+	}
+	pools := make([]*poolEntry, 0, len(c.pools))
+	for p := range c.pools {
+		pools = append(pools, p)
+	}
+	c.mu.Unlock()
+
+	// aggregate the pipes and pools of the same node
+	pipeStats := make(map[string]valkey.PipeStats, len(pipes))
+	for _, p := range pipes {
+		s, t := pipeStats[p.addr], p.stats()
+		s.InFlight += t.InFlight
+		s.Queued += t.Queued
+		pipeStats[p.addr] = s
+	}
+	for addr, s := range pipeStats {
+		ch <- prometheus.MustNewConstMetric(c.inFlight, prometheus.GaugeValue, float64(s.InFlight), addr)
+		ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(s.Queued), addr)
+	}
+	poolStats := make(map[string]valkey.PoolStats, len(pools))
+	for _, p := range pools {
+		s, t := poolStats[p.addr], p.stats()
+		s.Size += t.Size
+		s.Idle += t.Idle
+		poolStats[p.addr] = s
+	}
+	for addr, s := range poolStats {
+		ch <- prometheus.MustNewConstMetric(c.poolConns, prometheus.GaugeValue, float64(s.Size), addr)
+		ch <- prometheus.MustNewConstMetric(c.poolIdle, prometheus.GaugeValue, float64(s.Idle), addr)
+	}
+}
+
+type connTracker struct {
+	net.Conn
+	gauge prometheus.Gauge
+	once  int32
+}
+
+func (t *connTracker) Close() error {
+	if atomic.CompareAndSwapInt32(&t.once, 0, 1) {
+		t.gauge.Dec()
+	}
+	return t.Conn.Close()
+}
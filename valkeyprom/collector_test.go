@@ -0,0 +1,183 @@
+package valkeyprom
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/mock"
+)
+
+func TestCollectorCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mocked := mock.NewClient(ctrl)
+
+	c := NewCollector(WithConstLabels(prometheus.Labels{"app": "test"}))
+	client := c.WithClient(mocked)
+	ctx := context.Background()
+
+	mocked.EXPECT().Do(ctx, mock.Match("GET", "a")).Return(mock.Result(mock.ValkeyNil()))
+	mocked.EXPECT().Do(ctx, mock.Match("SET", "a", "b")).Return(mock.Result(mock.ValkeyError("MOVED 1 127.0.0.1:7001")))
+	mocked.EXPECT().Do(ctx, mock.Match("INCR", "a")).Return(mock.Result(mock.ValkeyError("WRONGTYPE Operation against a key holding the wrong kind of value")))
+	mocked.EXPECT().DoMulti(ctx, mock.Match("GET", "a"), mock.Match("GET", "b")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("1")),
+		mock.ErrorResult(context.DeadlineExceeded),
+	})
+	mocked.EXPECT().DoCache(ctx, mock.Match("GET", "c"), time.Second).Return(mock.Result(mock.ValkeyString("1")))
+	mocked.EXPECT().DoMultiCache(ctx, mock.Match("GET", "d")).Return([]valkey.ValkeyResult{mock.Result(mock.ValkeyString("1"))})
+	mocked.EXPECT().Receive(ctx, mock.Match("SUBSCRIBE", "ch"), gomock.Any()).Return(errors.New("io"))
+
+	client.Do(ctx, client.B().Get().Key("a").Build())
+	client.Do(ctx, client.B().Set().Key("a").Value("b").Build())
+	client.Do(ctx, client.B().Incr().Key("a").Build())
+	client.DoMulti(ctx, client.B().Get().Key("a").Build(), client.B().Get().Key("b").Build())
+	client.DoCache(ctx, client.B().Get().Key("c").Cache(), time.Second)
+	client.DoMultiCache(ctx, valkey.CT(client.B().Get().Key("d").Cache(), time.Second))
+	client.Receive(ctx, client.B().Subscribe().Channel("ch").Build(), func(msg valkey.PubSubMessage) {})
+
+	if n := testutil.CollectAndCount(c, "valkey_command_duration_seconds"); n != 3 {
+		t.Fatalf("unexpected number of duration series %v", n)
+	}
+	expected := `
+# HELP valkey_command_errors_total Number of command errors by error prefix.
+# TYPE valkey_command_errors_total counter
+valkey_command_errors_total{app="test",command="GET",prefix="TIMEOUT"} 1
+valkey_command_errors_total{app="test",command="INCR",prefix="WRONGTYPE"} 1
+valkey_command_errors_total{app="test",command="SET",prefix="MOVED"} 1
+valkey_command_errors_total{app="test",command="SUBSCRIBE",prefix="IO"} 1
+# HELP valkey_cache_hit_ratio Ratio of client side cache hits to all cacheable commands.
+# TYPE valkey_cache_hit_ratio gauge
+valkey_cache_hit_ratio{app="test"} 0
+# HELP valkey_cache_misses_total Number of client side cache misses.
+# TYPE valkey_cache_misses_total counter
+valkey_cache_misses_total{app="test"} 2
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "valkey_command_errors_total", "valkey_cache_hit_ratio", "valkey_cache_misses_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectorInstrument(t *testing.T) {
+	c := NewCollector()
+
+	var prevRedirects, prevPipeClosed int
+	option := c.Instrument(valkey.ClientOption{
+		DialFn: func(dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+			if dst == "bad" {
+				return nil, errors.New("bad")
+			}
+			conn, _ := net.Pipe()
+			return conn, nil
+		},
+		MetricsHooks: valkey.MetricsHooks{
+			OnRedirect: func(addr string, target string, mode valkey.RedirectMode) { prevRedirects++ },
+			OnPipeOpen: func(addr string, stats func() valkey.PipeStats) func() {
+				return func() { prevPipeClosed++ }
+			},
+		},
+	})
+
+	conn1, err := option.DialCtxFn(context.Background(), "n1", &net.Dialer{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn2, err := option.DialCtxFn(context.Background(), "n1", &net.Dialer{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = option.DialCtxFn(context.Background(), "bad", &net.Dialer{}, nil); err == nil {
+		t.Fatal("expected dial error")
+	}
+	conn2.Close()
+	conn2.Close()
+	defer conn1.Close()
+
+	closePipe := option.MetricsHooks.OnPipeOpen("n1", func() valkey.PipeStats { return valkey.PipeStats{InFlight: 3, Queued: 2} })
+	closePool := option.MetricsHooks.OnPoolOpen("n1", func() valkey.PoolStats { return valkey.PoolStats{Size: 4, Idle: 1} })
+	option.MetricsHooks.OnPoolWait("n1", time.Millisecond)
+	option.MetricsHooks.OnRedirect("n1", "n2", valkey.RedirectMove)
+	option.MetricsHooks.OnRedirect("n1", "n2", valkey.RedirectAsk)
+
+	expected := `
+# HELP valkey_connections Number of open connections.
+# TYPE valkey_connections gauge
+valkey_connections{node="n1"} 1
+# HELP valkey_pipe_in_flight_commands Number of commands waiting for replies.
+# TYPE valkey_pipe_in_flight_commands gauge
+valkey_pipe_in_flight_commands{node="n1"} 3
+# HELP valkey_pipe_queued_commands Number of commands waiting to be written.
+# TYPE valkey_pipe_queued_commands gauge
+valkey_pipe_queued_commands{node="n1"} 2
+# HELP valkey_pool_connections Number of connections of the blocking pool.
+# TYPE valkey_pool_connections gauge
+valkey_pool_connections{node="n1"} 4
+# HELP valkey_pool_idle_connections Number of idle connections of the blocking pool.
+# TYPE valkey_pool_idle_connections gauge
+valkey_pool_idle_connections{node="n1"} 1
+# HELP valkey_redirects_total Number of MOVED and ASK redirects.
+# TYPE valkey_redirects_total counter
+valkey_redirects_total{node="n1",type="ask"} 1
+valkey_redirects_total{node="n1",type="moved"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"valkey_connections", "valkey_pipe_in_flight_commands", "valkey_pipe_queued_commands",
+		"valkey_pool_connections", "valkey_pool_idle_connections", "valkey_redirects_total",
+	); err != nil {
+		t.Fatal(err)
+	}
+	if n := testutil.CollectAndCount(c, "valkey_pool_wait_seconds"); n != 1 {
+		t.Fatalf("unexpected number of pool wait series %v", n)
+	}
+
+	closePipe()
+	closePool()
+	if n := testutil.CollectAndCount(c, "valkey_pipe_in_flight_commands", "valkey_pool_connections"); n != 0 {
+		t.Fatalf("unexpected number of series after close %v", n)
+	}
+	if prevRedirects != 2 || prevPipeClosed != 1 {
+		t.Fatalf("previous hooks are not called %v %v", prevRedirects, prevPipeClosed)
+	}
+}
+
+func TestCollectorRegister(t *testing.T) {
+	if err := prometheus.NewPedanticRegistry().Register(NewCollector(WithNamespace("app"), WithHistogramBuckets([]float64{.001, .01}))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrorPrefix(t *testing.T) {
+	for _, c := range []struct {
+		err    error
+		prefix string
+	}{
+		{err: mock.Result(mock.ValkeyError("LOADING Valkey is loading the dataset in memory")).Error(), prefix: "LOADING"},
+		{err: mock.Result(mock.ValkeyError("ERR")).Error(), prefix: "ERR"},
+		{err: context.Canceled, prefix: "CANCELED"},
+		{err: valkey.ErrClosing, prefix: "CLOSING"},
+		{err: net.ErrClosed, prefix: "IO"},
+	} {
+		if p := errorPrefix(c.err); p != c.prefix {
+			t.Fatalf("unexpected prefix of %v: %v", c.err, p)
+		}
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewCollector().NewClient(valkey.ClientOption{InitAddress: []string{"127.0.0.1:6379"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := client.Do(context.Background(), client.B().Ping().Build()).Error(); err != nil {
+		t.Fatal(err)
+	}
+}
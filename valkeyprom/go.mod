@@ -0,0 +1,31 @@
+module github.com/valkey-io/valkey-go/valkeyprom
+
+go 1.23.0
+
+toolchain go1.23.4
+
+replace (
+	github.com/valkey-io/valkey-go => ../
+	github.com/valkey-io/valkey-go/mock => ../mock
+	github.com/valkey-io/valkey-go/valkeyhook => ../valkeyhook
+)
+
+require (
+	github.com/prometheus/client_golang v1.22.0
+	github.com/valkey-io/valkey-go v1.0.64
+	github.com/valkey-io/valkey-go/mock v1.0.64
+	github.com/valkey-io/valkey-go/valkeyhook v1.0.64
+	go.uber.org/mock v0.5.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.62.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	google.golang.org/protobuf v1.36.5 // indirect
+)
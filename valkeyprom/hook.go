@@ -0,0 +1,125 @@
+package valkeyprom
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"github.com/valkey-io/valkey-go/valkeyhook"
+)
+
+var _ valkeyhook.Hook = (*hook)(nil)
+
+type hook struct {
+	c *Collector
+}
+
+func (h *hook) Do(client valkey.Client, ctx context.Context, cmd valkey.Completed) (resp valkey.ValkeyResult) {
+	op := first(cmd.Commands())
+	start := time.Now()
+	resp = client.Do(ctx, cmd)
+	h.record(op, time.Since(start), resp.Error())
+	return
+}
+
+func (h *hook) DoMulti(client valkey.Client, ctx context.Context, multi ...valkey.Completed) (resps []valkey.ValkeyResult) {
+	ops := make([]string, len(multi))
+	for i, cmd := range multi {
+		ops[i] = first(cmd.Commands())
+	}
+	start := time.Now()
+	resps = client.DoMulti(ctx, multi...)
+	elapsed := time.Since(start)
+	for i, resp := range resps {
+		h.record(ops[i], elapsed, resp.Error())
+	}
+	return
+}
+
+func (h *hook) DoCache(client valkey.Client, ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) (resp valkey.ValkeyResult) {
+	op := first(cmd.Commands())
+	start := time.Now()
+	resp = client.DoCache(ctx, cmd, ttl)
+	h.record(op, time.Since(start), resp.Error())
+	h.cache(resp)
+	return
+}
+
+func (h *hook) DoMultiCache(client valkey.Client, ctx context.Context, multi ...valkey.CacheableTTL) (resps []valkey.ValkeyResult) {
+	ops := make([]string, len(multi))
+	for i, cmd := range multi {
+		ops[i] = first(cmd.Cmd.Commands())
+	}
+	start := time.Now()
+	resps = client.DoMultiCache(ctx, multi...)
+	elapsed := time.Since(start)
+	for i, resp := range resps {
+		h.record(ops[i], elapsed, resp.Error())
+		h.cache(resp)
+	}
+	return
+}
+
+func (h *hook) Receive(client valkey.Client, ctx context.Context, subscribe valkey.Completed, fn func(msg valkey.PubSubMessage)) (err error) {
+	op := first(subscribe.Commands())
+	if err = client.Receive(ctx, subscribe, fn); err != nil && !errors.Is(err, context.Canceled) {
+		h.c.errors.WithLabelValues(op, errorPrefix(err)).Inc()
+	}
+	return
+}
+
+func (h *hook) DoStream(client valkey.Client, ctx context.Context, cmd valkey.Completed) valkey.ValkeyResultStream {
+	return client.DoStream(ctx, cmd)
+}
+
+func (h *hook) DoMultiStream(client valkey.Client, ctx context.Context, multi ...valkey.Completed) valkey.MultiValkeyResultStream {
+	return client.DoMultiStream(ctx, multi...)
+}
+
+func (h *hook) record(op string, elapsed time.Duration, err error) {
+	h.c.duration.WithLabelValues(op).Observe(elapsed.Seconds())
+	if err != nil && !valkey.IsValkeyNil(err) {
+		h.c.errors.WithLabelValues(op, errorPrefix(err)).Inc()
+	}
+}
+
+func (h *hook) cache(resp valkey.ValkeyResult) {
+	if resp.NonValkeyError() != nil {
+		return
+	}
+	if resp.IsCacheHit() {
+		h.c.hits.Add(1)
+	} else {
+		h.c.misses.Add(1)
+	}
+}
+
+// errorPrefix returns the first word of a valkey error, such as MOVED, LOADING and WRONGTYPE,
+// or a coarse category of other errors.
+func errorPrefix(err error) string {
+	if ve, ok := valkey.IsValkeyErr(err); ok {
+		msg := ve.Error()
+		if i := strings.IndexByte(msg, ' '); i > 0 {
+			return msg[:i]
+		}
+		return msg
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "TIMEOUT"
+	case errors.Is(err, context.Canceled):
+		return "CANCELED"
+	case errors.Is(err, valkey.ErrClosing):
+		return "CLOSING"
+	}
+	return "IO"
+}
+
+func first(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}